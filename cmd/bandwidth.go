@@ -0,0 +1,221 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// How often a limit read from a file is checked for changes.
+const limitReloadPeriod = 5 * time.Second
+
+// bandwidthLimiter - a token bucket shared by all transfers in one
+// direction, it lets through rate bytes per second with bursts of
+// at most one second worth of data.
+type bandwidthLimiter struct {
+	mu     sync.Mutex
+	rate   uint64 // bytes per second, zero means unlimited
+	tokens float64
+	last   time.Time
+}
+
+// newBandwidthLimiter - a limiter letting through rate bytes per second.
+func newBandwidthLimiter(rate uint64) *bandwidthLimiter {
+	return &bandwidthLimiter{rate: rate, tokens: float64(rate), last: time.Now()}
+}
+
+// setRate - change the rate of the limiter, transfers in progress
+// follow the new rate right away.
+func (l *bandwidthLimiter) setRate(rate uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = rate
+	if l.tokens > float64(rate) {
+		l.tokens = float64(rate)
+	}
+}
+
+// wait - block until n bytes may pass. Bytes are taken from the
+// bucket right away, the bucket is allowed to go into debt which is
+// paid off by the caller sleeping.
+func (l *bandwidthLimiter) wait(n int) {
+	for n > 0 {
+		l.mu.Lock()
+		if l.rate == 0 {
+			l.mu.Unlock()
+			return
+		}
+		now := time.Now()
+		burst := float64(l.rate)
+		l.tokens += now.Sub(l.last).Seconds() * burst
+		l.last = now
+		if l.tokens > burst {
+			l.tokens = burst
+		}
+		take := float64(n)
+		if take > burst {
+			take = burst
+		}
+		l.tokens -= take
+		var delay time.Duration
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / burst * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		n -= int(take)
+		time.Sleep(delay)
+	}
+}
+
+// limitedReader - a reader whose bandwidth is limited by a bandwidthLimiter.
+type limitedReader struct {
+	io.Reader
+	limiter *bandwidthLimiter
+}
+
+func (r *limitedReader) Read(b []byte) (n int, err error) {
+	n, err = r.Reader.Read(b)
+	r.limiter.wait(n)
+	return n, err
+}
+
+// limitReader - limit the bandwidth of reader by limiter, seeking is
+// passed through to reader. The reader is returned as is when there
+// is no limiter.
+func limitReader(reader io.Reader, limiter *bandwidthLimiter) io.Reader {
+	if limiter == nil {
+		return reader
+	}
+	limited := &limitedReader{Reader: reader, limiter: limiter}
+	if seeker, ok := reader.(io.Seeker); ok {
+		return struct {
+			io.Reader
+			io.Seeker
+		}{limited, seeker}
+	}
+	return limited
+}
+
+// parseBandwidth - parse a bandwidth such as 10MiB/s or 500KB, zero
+// means unlimited.
+func parseBandwidth(bandwidth string) (uint64, *probe.Error) {
+	value := strings.TrimSpace(bandwidth)
+	if strings.HasSuffix(strings.ToLower(value), "/s") {
+		value = value[:len(value)-2]
+	}
+	rate, e := humanize.ParseBytes(value)
+	if e != nil {
+		return 0, errInvalidBandwidth(bandwidth).Trace(bandwidth)
+	}
+	return rate, nil
+}
+
+// newLimiterFromFlag - a limiter for the value of a --limit-* flag,
+// which is either a bandwidth or @FILE to read the bandwidth from
+// FILE. A bandwidth read from a file follows the changes of the file,
+// so that the limit of a long running command can be adjusted.
+func newLimiterFromFlag(value string) (*bandwidthLimiter, *probe.Error) {
+	if value == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(value, "@") {
+		rate, err := parseBandwidth(value)
+		if err != nil {
+			return nil, err
+		}
+		return newBandwidthLimiter(rate), nil
+	}
+
+	limitFile := strings.TrimPrefix(value, "@")
+	rate, modTime, err := readBandwidthFile(limitFile)
+	if err != nil {
+		return nil, err
+	}
+	limiter := newBandwidthLimiter(rate)
+	go func() {
+		for range time.NewTicker(limitReloadPeriod).C {
+			st, e := os.Stat(limitFile)
+			if e != nil || st.ModTime().Equal(modTime) {
+				continue
+			}
+			var rate uint64
+			rate, modTime, err = readBandwidthFile(limitFile)
+			if err != nil {
+				errorIf(err, "Unable to reload bandwidth limit, keeping the previous one.")
+				continue
+			}
+			limiter.setRate(rate)
+		}
+	}()
+	return limiter, nil
+}
+
+// readBandwidthFile - read a bandwidth from a file, an empty file
+// means unlimited.
+func readBandwidthFile(limitFile string) (uint64, time.Time, *probe.Error) {
+	st, e := os.Stat(limitFile)
+	if e != nil {
+		return 0, time.Time{}, probe.NewError(e).Trace(limitFile)
+	}
+	data, e := ioutil.ReadFile(limitFile)
+	if e != nil {
+		return 0, time.Time{}, probe.NewError(e).Trace(limitFile)
+	}
+	bandwidth := strings.TrimSpace(string(data))
+	if bandwidth == "" {
+		return 0, st.ModTime(), nil
+	}
+	rate, err := parseBandwidth(bandwidth)
+	if err != nil {
+		return 0, time.Time{}, err.Trace(limitFile)
+	}
+	return rate, st.ModTime(), nil
+}
+
+// checkBandwidthLimit - validate the value of a --limit-* flag.
+func checkBandwidthLimit(value string) *probe.Error {
+	if value == "" {
+		return nil
+	}
+	if strings.HasPrefix(value, "@") {
+		_, _, err := readBandwidthFile(strings.TrimPrefix(value, "@"))
+		return err
+	}
+	_, err := parseBandwidth(value)
+	return err
+}
+
+// setBandwidthLimits - set the limits of the bandwidth to and from
+// object storage for the current command, empty values mean unlimited.
+func setBandwidthLimits(upload, download string) *probe.Error {
+	var err *probe.Error
+	if globalUploadLimiter, err = newLimiterFromFlag(upload); err != nil {
+		return err.Trace(upload)
+	}
+	if globalDownloadLimiter, err = newLimiterFromFlag(download); err != nil {
+		return err.Trace(download)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	testCases := []struct {
+		bandwidth string
+		rate      uint64
+		success   bool
+	}{
+		{"10MiB/s", 10 * 1024 * 1024, true},
+		{"10MiB", 10 * 1024 * 1024, true},
+		{"500KB/S", 500 * 1000, true},
+		{" 1GiB/s ", 1024 * 1024 * 1024, true},
+		{"0", 0, true},
+		{"fast", 0, false},
+		{"10MiB/h", 0, false},
+		{"", 0, false},
+	}
+	for i, testCase := range testCases {
+		rate, err := parseBandwidth(testCase.bandwidth)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: Expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if rate != testCase.rate {
+			t.Fatalf("Test %d: Expected rate %d, got %d", i+1, testCase.rate, rate)
+		}
+	}
+}
+
+func TestLimitReader(t *testing.T) {
+	const rate = 1024 * 1024
+	source := bytes.NewReader(nil)
+	if reader := limitReader(source, nil); reader != io.Reader(source) {
+		t.Fatal("Expected the reader to be returned as is without a limiter")
+	}
+
+	// A full bucket lets a second worth of data through right
+	// away, the rest of the data has to wait.
+	data := make([]byte, rate*3/2)
+	reader := limitReader(bytes.NewReader(data), newBandwidthLimiter(rate))
+	if _, ok := reader.(io.Seeker); !ok {
+		t.Fatal("Expected the limited reader to be seekable")
+	}
+	start := time.Now()
+	n, e := io.Copy(ioutil.Discard, reader)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("Expected %d bytes, got %d", len(data), n)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond || elapsed > 2*time.Second {
+		t.Fatalf("Expected about 500ms to read the data, took %s", elapsed)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"strings"
 	"syscall"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -34,10 +35,20 @@ import (
 )
 
 var (
-	// This is kept dummy for future purposes
-	// and also to add ioFlags and globalFlags
-	// in CLI registration.
-	catFlags = []cli.Flag{}
+	catFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "display a specific version of an object",
+		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "display an object as it was at a date or a duration ago, e.g. 2019-05-21T18:00:00Z or 7d10h",
+		},
+		cli.StringFlag{
+			Name:  "encrypt-client",
+			Usage: "decrypt objects on the client with the 32 byte key in KEYFILE",
+		},
+	}
 )
 
 // Display contents of a file.
@@ -46,7 +57,7 @@ var catCmd = cli.Command{
 	Usage:  "display object contents",
 	Action: mainCat,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(catFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(catFlags, ioFlags...), limitDownloadFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -58,6 +69,7 @@ FLAGS:
   {{end}}
 ENVIRONMENT VARIABLES:
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+   MC_ENCRYPT_KMS:  list of comma delimited prefix=key-id values, key-id may end with ?key=value encryption context
 
 EXAMPLES:
    1. Stream an object from Amazon S3 cloud storage to mplayer standard input.
@@ -71,6 +83,18 @@ EXAMPLES:
 
    4. Save an encrypted object from Amazon S3 cloud storage to a local file.
       $ {{.HelpName}} --encrypt-key 's3/mysql-backups=32byteslongsecretkeymustbegiven1' s3/mysql-backups/backups-201810.gz > /mnt/data/recent.gz
+
+   5. Display the content of a specific version of an object.
+      $ {{.HelpName}} --version-id "3/L4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nr8X8gdRQBpUMLUo" s3/mybucket/myobject.txt
+
+   6. Display the content of an object as it was one day ago.
+      $ {{.HelpName}} --rewind 1d s3/mybucket/myobject.txt
+
+   7. Display the content of an object which was encrypted on the client.
+      $ {{.HelpName}} --encrypt-client ~/.mc/backup.key s3/backups/notes.txt
+
+   8. Display the content of an object, limiting the download bandwidth to 1MiB per second.
+      $ {{.HelpName}} --limit-download 1MiB/s s3/mybucket/myobject.txt
 `,
 }
 
@@ -134,10 +158,17 @@ func checkCatSyntax(ctx *cli.Context) {
 			fatalIf(probe.NewError(errors.New("")), fmt.Sprintf("Unknown flag `%s` passed.", arg))
 		}
 	}
+	if ctx.String("version-id") != "" && len(args) != 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "--version-id requires exactly one object.")
+	}
+	if ctx.String("version-id") != "" && ctx.String("rewind") != "" {
+		fatalIf(errInvalidArgument().Trace(args...), "--version-id cannot be used with --rewind.")
+	}
 }
 
-// catURL displays contents of a URL to stdout.
-func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+// catURL displays contents of a URL to stdout, an empty versionID
+// displays the latest version, or the latest version at timeRef.
+func catURL(sourceURL, versionID string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
 	switch sourceURL {
@@ -150,14 +181,26 @@ func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error
 		// downloaded object is equal to the original one. FS files
 		// are ignored since some of them have zero size though they
 		// have contents like files under /proc.
-		client, content, err := url2Stat(sourceURL, false, encKeyDB)
+		client, content, err := url2StatVersion(sourceURL, versionID, timeRef, encKeyDB)
 		if err == nil && client.GetURL().Type == objectStorage {
 			size = content.Size
 		}
-		if reader, err = getSourceStreamFromURL(sourceURL, encKeyDB); err != nil {
+		if !timeRef.IsZero() {
+			// The version at timeRef has to be resolved before reading it.
+			if err != nil {
+				return err.Trace(sourceURL)
+			}
+			versionID = content.VersionID
+		}
+		var metadata map[string]string
+		if reader, metadata, err = getSourceStreamMetadataFromURL(sourceURL, versionID, encKeyDB); err != nil {
 			return err.Trace(sourceURL)
 		}
 		defer reader.Close()
+		// Objects compressed by mc are displayed decompressed.
+		if size >= 0 {
+			size = uncompressedSize(metadata, size)
+		}
 	}
 	return catOut(reader, size).Trace(sourceURL)
 }
@@ -213,6 +256,10 @@ func mainCat(ctx *cli.Context) error {
 	encKeyDB, err := getEncKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	keyFile := ctx.String("encrypt-client")
+	fatalIf(setCSEKey(keyFile), "Unable to load client-side encryption key `"+keyFile+"`.")
+	fatalIf(setBandwidthLimits("", ctx.String("limit-download")), "Unable to set bandwidth limits.")
+
 	// check 'cat' cli arguments.
 	checkCatSyntax(ctx)
 
@@ -240,9 +287,13 @@ func mainCat(ctx *cli.Context) error {
 		}
 	}
 
+	versionID := ctx.String("version-id")
+	timeRef, err := parseRewind(ctx.String("rewind"))
+	fatalIf(err, "Unable to parse rewind `"+ctx.String("rewind")+"`.")
+
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(url, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
+		fatalIf(catURL(url, versionID, timeRef, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
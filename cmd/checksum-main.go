@@ -0,0 +1,346 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	checksumFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "calculate checksums of all objects under a folder or prefix",
+		},
+		cli.StringFlag{
+			Name:  "part-size",
+			Usage: "part size of the multipart ETag, e.g. 64MiB, detected if not given",
+		},
+		cli.BoolFlag{
+			Name:  "verify",
+			Usage: "verify that all objects of SOURCE are identical in TARGET",
+		},
+	}
+)
+
+// Calculate checksums of objects.
+var checksumCmd = cli.Command{
+	Name:   "checksum",
+	Usage:  "calculate checksums and multipart ETags of objects",
+	Action: mainChecksum,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(checksumFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET...]
+  {{.HelpName}} --verify [FLAGS] SOURCE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Calculate MD5, SHA-256, CRC32C and the multipart ETag of local files and
+  remote objects. The multipart ETag is the ETag S3 returns for an object
+  uploaded in parts of --part-size. Without --part-size, the part size is
+  detected from the ETag of remote objects, and is the part size mc uploads
+  with for local files.
+
+  With --verify, the objects of SOURCE are compared by content with the
+  objects of TARGET. ETags are compared where they are checksums of the
+  data, so that uploads can be verified without downloading them.
+
+LEGEND:
+    > - object is only in source.
+    < - object is only in destination.
+    ! - object differs in size or content.
+
+EXAMPLES:
+  1. Calculate checksums of a local file.
+     $ {{.HelpName}} backup/archive-2019-05.tar
+
+  2. Calculate the multipart ETag of a local file uploaded in parts of 64MiB.
+     $ {{.HelpName}} --part-size 64MiB backup/archive-2019-05.tar
+
+  3. Calculate checksums of all objects under a prefix on Amazon S3 cloud storage.
+     $ {{.HelpName}} --recursive s3/archive/2019/
+
+  4. Verify that a local folder was uploaded bit-identical to MinIO cloud storage.
+     $ {{.HelpName}} --verify backup/ play/archive/
+`,
+}
+
+// checksumMessage container for checksums of an object.
+type checksumMessage struct {
+	Status   string `json:"status"`
+	Key      string `json:"name"`
+	Size     int64  `json:"size"`
+	MD5      string `json:"md5"`
+	SHA256   string `json:"sha256"`
+	CRC32C   string `json:"crc32c"`
+	ETag     string `json:"etag"`
+	PartSize int64  `json:"partSize,omitempty"`
+}
+
+// String colorized checksum message.
+func (c checksumMessage) String() string {
+	etag := c.ETag
+	if c.PartSize > 0 {
+		etag += " (part size " + humanize.IBytes(uint64(c.PartSize)) + ")"
+	}
+	return strings.Join([]string{
+		console.Colorize("Name", fmt.Sprintf("%-10s: %s", "Name", c.Key)),
+		fmt.Sprintf("%-10s: %s", "Size", humanize.IBytes(uint64(c.Size))),
+		fmt.Sprintf("%-10s: %s", "MD5", c.MD5),
+		fmt.Sprintf("%-10s: %s", "SHA-256", c.SHA256),
+		fmt.Sprintf("%-10s: %s", "CRC32C", c.CRC32C),
+		fmt.Sprintf("%-10s: %s", "ETag", etag),
+	}, "\n") + "\n"
+}
+
+// JSON jsonified checksum message.
+func (c checksumMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// parsePartSize - parse the part size given on command line, an
+// empty part size is detected per object.
+func parsePartSize(partSize string) (int64, *probe.Error) {
+	if partSize == "" {
+		return 0, nil
+	}
+	size, e := humanize.ParseBytes(partSize)
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	if size < 5*mib || size > 5*1024*mib {
+		return 0, probe.NewError(fmt.Errorf("part size should be between 5MiB and 5GiB"))
+	}
+	return int64(size), nil
+}
+
+// checkChecksumSyntax - validate all the passed arguments
+func checkChecksumSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "checksum", 1) // last argument is exit code
+	}
+	if ctx.Bool("verify") && len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "checksum", 1) // last argument is exit code
+	}
+	for _, arg := range ctx.Args() {
+		if strings.TrimSpace(arg) == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Unable to validate empty argument.")
+		}
+	}
+	_, err := parsePartSize(ctx.String("part-size"))
+	fatalIf(err.Trace(ctx.String("part-size")), "Invalid part size `"+ctx.String("part-size")+"`.")
+
+	if !ctx.Bool("verify") {
+		return
+	}
+	// Verify works between two directories, like diff.
+	for _, urlStr := range ctx.Args() {
+		_, content, err := url2Stat(urlStr, false, encKeyDB)
+		fatalIf(err.Trace(urlStr), "Unable to stat `"+urlStr+"`.")
+		if !content.Type.IsDir() {
+			fatalIf(errInvalidArgument().Trace(urlStr), "`"+urlStr+"` is not a folder.")
+		}
+	}
+}
+
+// checksumObject - calculate all checksums of the content reading its
+// data once. The multipart ETag is calculated with partSize if given,
+// with the part size detected from the ETag of an object, or with the
+// part size mc uploads with otherwise.
+func checksumObject(alias string, content *clientContent, partSize int64, encKeyDB map[string][]prefixSSEPair) (checksumMessage, *probe.Error) {
+	partSizes := []int64{partSize}
+	if partSize == 0 {
+		partSizes = []int64{mcPartSize(content.Size)}
+		if sum, parts := parseETag(content.ETag); sum != "" && parts == 0 {
+			partSizes = []int64{0}
+		} else if sum != "" {
+			if guessed := guessPartSizes(content.Size, parts); len(guessed) > 0 {
+				partSizes = guessed
+			}
+		}
+	}
+
+	reader, err := checksumContent{alias: alias, content: content, keys: encKeyDB}.get()
+	if err != nil {
+		return checksumMessage{}, err
+	}
+	defer reader.Close()
+
+	md5Hash, sha256Hash := md5.New(), sha256.New()
+	crc32cHash := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	writers := []io.Writer{md5Hash, sha256Hash, crc32cHash}
+	etagHashes := make([]*etagHash, len(partSizes))
+	for i, partSize := range partSizes {
+		etagHashes[i] = newETagHash(partSize)
+		writers = append(writers, etagHashes[i])
+	}
+	if _, e := io.Copy(io.MultiWriter(writers...), reader); e != nil {
+		return checksumMessage{}, probe.NewError(e)
+	}
+
+	msg := checksumMessage{
+		Key:      filepath.ToSlash(filepath.Join(alias, content.URL.Path)),
+		Size:     content.Size,
+		MD5:      hex.EncodeToString(md5Hash.Sum(nil)),
+		SHA256:   hex.EncodeToString(sha256Hash.Sum(nil)),
+		CRC32C:   hex.EncodeToString(crc32cHash.Sum(nil)),
+		ETag:     etagHashes[0].ETag(),
+		PartSize: partSizes[0],
+	}
+	// Report the part size which reproduces the ETag of an object.
+	etag := strings.ToLower(strings.Trim(content.ETag, "\""))
+	for i, h := range etagHashes {
+		if h.ETag() == etag {
+			msg.ETag, msg.PartSize = etag, partSizes[i]
+			break
+		}
+	}
+	return msg, nil
+}
+
+// doChecksum - calculate checksums of the object, or of all objects
+// under the folder with isRecursive.
+func doChecksum(urlStr string, isRecursive bool, partSize int64, encKeyDB map[string][]prefixSSEPair) error {
+	clnt, content, err := url2Stat(urlStr, false, encKeyDB)
+	if err != nil {
+		errorIf(err.Trace(urlStr), "Unable to stat `"+urlStr+"`.")
+		return exitStatus(globalErrorExitStatus)
+	}
+	alias, _, _ := mustExpandAlias(urlStr)
+
+	if !content.Type.IsDir() {
+		msg, err := checksumObject(alias, content, partSize, encKeyDB)
+		if err != nil {
+			errorIf(err.Trace(urlStr), "Unable to calculate checksums of `"+urlStr+"`.")
+			return exitStatus(globalErrorExitStatus)
+		}
+		printMsg(msg)
+		return nil
+	}
+	if !isRecursive {
+		errorIf(errInvalidArgument().Trace(urlStr), "`"+urlStr+"` is a folder, use --recursive.")
+		return exitStatus(globalErrorExitStatus)
+	}
+
+	var cErr error
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(urlStr), "Unable to list `"+urlStr+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		msg, err := checksumObject(alias, content, partSize, encKeyDB)
+		if err != nil {
+			objectPath := filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+			errorIf(err.Trace(objectPath), "Unable to calculate checksums of `"+objectPath+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(msg)
+	}
+	return cErr
+}
+
+// doChecksumVerify - compare all objects of source and target by
+// content, differences are reported like diff does.
+func doChecksumVerify(sourceURL, targetURL string, encKeyDB map[string][]prefixSSEPair) error {
+	// Source and targets are always directories
+	sourceSeparator := string(newClientURL(sourceURL).Separator)
+	if !strings.HasSuffix(sourceURL, sourceSeparator) {
+		sourceURL = sourceURL + sourceSeparator
+	}
+	targetSeparator := string(newClientURL(targetURL).Separator)
+	if !strings.HasSuffix(targetURL, targetSeparator) {
+		targetURL = targetURL + targetSeparator
+	}
+
+	sourceAlias, sourceURL, _ := mustExpandAlias(sourceURL)
+	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
+
+	sourceClnt, err := newClientFromAlias(sourceAlias, sourceURL)
+	fatalIf(err.Trace(sourceAlias, sourceURL), "Unable to initialize `"+sourceURL+"`.")
+	targetClnt, err := newClientFromAlias(targetAlias, targetURL)
+	fatalIf(err.Trace(targetAlias, targetURL), "Unable to initialize `"+targetURL+"`.")
+
+	var cErr error
+	compare := newChecksumComparator(sourceAlias, targetAlias, encKeyDB)
+	for diffMsg := range objectDifference(sourceClnt, targetClnt, sourceURL, targetURL, compare) {
+		cErr = exitStatus(globalErrorExitStatus)
+		if diffMsg.Error != nil {
+			errorIf(diffMsg.Error, "Unable to verify objects.")
+			continue
+		}
+		printMsg(diffMsg)
+	}
+	return cErr
+}
+
+// mainChecksum is the main entry point for checksum command.
+func mainChecksum(ctx *cli.Context) error {
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	// check 'checksum' cli arguments.
+	checkChecksumSyntax(ctx, encKeyDB)
+
+	// Additional command specific theme customization.
+	console.SetColor("Name", color.New(color.Bold, color.FgCyan))
+	console.SetColor("DiffOnlyInFirst", color.New(color.FgRed))
+	console.SetColor("DiffOnlyInSecond", color.New(color.FgGreen))
+	console.SetColor("DiffType", color.New(color.FgMagenta))
+	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffContent", color.New(color.FgYellow, color.Bold))
+
+	if ctx.Bool("verify") {
+		return doChecksumVerify(ctx.Args().Get(0), ctx.Args().Get(1), encKeyDB)
+	}
+
+	partSize, _ := parsePartSize(ctx.String("part-size"))
+	var cErr error
+	for _, urlStr := range ctx.Args() {
+		if e := doChecksum(urlStr, ctx.Bool("recursive"), partSize, encKeyDB); e != nil {
+			cErr = e
+		}
+	}
+	return cErr
+}
@@ -0,0 +1,320 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"hash"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+const mib = 1024 * 1024
+
+// Part sizes commonly used by S3 clients for multipart uploads, in
+// the order they are tried to reproduce a multipart ETag.
+var multipartPartSizes = []int64{
+	128 * mib,
+	64 * mib,
+	5 * mib,
+	8 * mib, // aws-cli
+	15 * mib,
+	16 * mib,
+	32 * mib,
+	100 * mib,
+	256 * mib,
+	512 * mib,
+}
+
+// contentComparator - tells whether two regular contents of the
+// same size have the same data.
+type contentComparator func(first, second *clientContent) (bool, *probe.Error)
+
+// parseETag - split an S3 ETag into its MD5 and the number of parts
+// of a multipart upload, which is 0 for single part uploads. ETags
+// which are no MD5 sum have an empty sum.
+func parseETag(etag string) (sum string, parts int) {
+	etag = strings.Trim(etag, "\"")
+	if i := strings.Index(etag, "-"); i >= 0 {
+		n, e := strconv.Atoi(etag[i+1:])
+		if e != nil || n <= 0 {
+			return "", 0
+		}
+		sum, parts = etag[:i], n
+	} else {
+		sum = etag
+	}
+	if _, e := hex.DecodeString(sum); e != nil || len(sum) != 2*md5.Size {
+		return "", 0
+	}
+	return strings.ToLower(sum), parts
+}
+
+// guessPartSizes - part sizes which split an object of size into
+// the given number of parts, including the size of equal parts
+// rounded up to a MiB.
+func guessPartSizes(size int64, parts int) []int64 {
+	fits := func(partSize int64) bool {
+		return partSize > 0 && (size+partSize-1)/partSize == int64(parts)
+	}
+	var partSizes []int64
+	seen := make(map[int64]bool)
+	candidates := append([]int64{mcPartSize(size)}, multipartPartSizes...)
+	candidates = append(candidates, (size/int64(parts)+mib-1)/mib*mib)
+	for _, partSize := range candidates {
+		if fits(partSize) && !seen[partSize] {
+			seen[partSize] = true
+			partSizes = append(partSizes, partSize)
+		}
+	}
+	return partSizes
+}
+
+// mcPartSize - part size mc uploads an object of size with, 0 for
+// objects uploaded in a single part. Objects of unknown size, -1, are
+// uploaded in parts sized for the largest possible object.
+func mcPartSize(size int64) int64 {
+	const minPartSize, maxPartsCount = 128 * mib, 10000
+	const maxObjectSize = 5 * 1024 * 1024 * mib
+	if size < 0 {
+		size = maxObjectSize
+	}
+	if size < minPartSize {
+		return 0
+	}
+	return (size/maxPartsCount + minPartSize - 1) / minPartSize * minPartSize
+}
+
+// etagHash - calculates the ETag S3 returns for data uploaded in parts
+// of partSize, a partSize of 0 calculates the ETag of a single part
+// upload.
+type etagHash struct {
+	partSize int64
+	written  int64
+	part     hash.Hash
+	sums     []byte
+	parts    int
+}
+
+func newETagHash(partSize int64) *etagHash {
+	return &etagHash{partSize: partSize, part: md5.New()}
+}
+
+func (h *etagHash) Write(p []byte) (int, error) {
+	n := len(p)
+	for h.partSize > 0 && h.written+int64(len(p)) > h.partSize {
+		m := h.partSize - h.written
+		h.part.Write(p[:m])
+		h.sums = h.part.Sum(h.sums)
+		h.parts++
+		h.part.Reset()
+		h.written = 0
+		p = p[m:]
+	}
+	h.part.Write(p)
+	h.written += int64(len(p))
+	return n, nil
+}
+
+// ETag - the ETag of the data written so far.
+func (h *etagHash) ETag() string {
+	if h.partSize <= 0 {
+		return hex.EncodeToString(h.part.Sum(nil))
+	}
+	sums, parts := h.sums, h.parts
+	if h.written > 0 || parts == 0 {
+		sums = h.part.Sum(sums)
+		parts++
+	}
+	etagHash := md5.New()
+	etagHash.Write(sums)
+	return hex.EncodeToString(etagHash.Sum(nil)) + "-" + strconv.Itoa(parts)
+}
+
+// multipartETag - calculate the ETag S3 returns for data uploaded in
+// parts of partSize, a partSize of 0 calculates the ETag of a single
+// part upload.
+func multipartETag(reader io.Reader, partSize int64) (string, error) {
+	h := newETagHash(partSize)
+	if _, e := io.Copy(h, reader); e != nil {
+		return "", e
+	}
+	return h.ETag(), nil
+}
+
+// checksumContent - content of a comparison with the means to read it.
+type checksumContent struct {
+	alias   string
+	content *clientContent
+	keys    map[string][]prefixSSEPair
+}
+
+// client - new client of the content.
+func (c checksumContent) client() (Client, *probe.Error) {
+	return newClientFromAlias(c.alias, c.content.URL.String())
+}
+
+// get - read the data of the content.
+func (c checksumContent) get() (io.ReadCloser, *probe.Error) {
+	clnt, err := c.client()
+	if err != nil {
+		return nil, err.Trace(c.content.URL.String())
+	}
+	path := filepath.ToSlash(filepath.Join(c.alias, c.content.URL.Path))
+	return clnt.GetVersion(c.content.VersionID, getSSE(path, c.keys[c.alias]))
+}
+
+// etag - calculate the ETag of the content as if it was uploaded in
+// parts of partSize.
+func (c checksumContent) etag(partSize int64) (string, *probe.Error) {
+	etags, err := c.etags([]int64{partSize})
+	if err != nil {
+		return "", err
+	}
+	return etags[0], nil
+}
+
+// etags - calculate the ETags of the content for each of partSizes,
+// reading it only once.
+func (c checksumContent) etags(partSizes []int64) ([]string, *probe.Error) {
+	reader, err := c.get()
+	if err != nil {
+		return nil, err.Trace(c.content.URL.String())
+	}
+	defer reader.Close()
+	writers := make([]io.Writer, len(partSizes))
+	etagHashes := make([]*etagHash, len(partSizes))
+	for i, partSize := range partSizes {
+		etagHashes[i] = newETagHash(partSize)
+		writers[i] = etagHashes[i]
+	}
+	if _, e := io.Copy(io.MultiWriter(writers...), reader); e != nil {
+		return nil, probe.NewError(e).Trace(c.content.URL.String())
+	}
+	etags := make([]string, len(partSizes))
+	for i, h := range etagHashes {
+		etags[i] = h.ETag()
+	}
+	return etags, nil
+}
+
+// isOpaqueETag - tells whether the ETag of the object is no checksum
+// of its data, which is the case for encrypted or compressed objects.
+func (c checksumContent) isOpaqueETag() (bool, *probe.Error) {
+	clnt, err := c.client()
+	if err != nil {
+		return false, err.Trace(c.content.URL.String())
+	}
+	path := filepath.ToSlash(filepath.Join(c.alias, c.content.URL.Path))
+	st, err := clnt.StatVersion(c.content.VersionID, getSSE(path, c.keys[c.alias]))
+	if err != nil {
+		return false, err.Trace(c.content.URL.String())
+	}
+	return isEncrypted(st) || isCompressed(st.Metadata), nil
+}
+
+// isEncrypted - tells whether the object is encrypted on the server or
+// on the client, the ETag of an encrypted object is no checksum of its
+// data.
+func isEncrypted(content *clientContent) bool {
+	return len(content.EncryptionHeaders) > 0 || content.Metadata[cseAlgorithmMeta] != ""
+}
+
+// sameETag - compare an object with a file or another object by its
+// ETag, known is false when the ETag cannot tell.
+func sameETag(object, other checksumContent) (same, known bool, err *probe.Error) {
+	sum, parts := parseETag(object.content.ETag)
+	if sum == "" {
+		return false, false, nil
+	}
+	if other.content.URL.Type == objectStorage {
+		if strings.Trim(other.content.ETag, "\"") == strings.Trim(object.content.ETag, "\"") {
+			return true, true, nil
+		}
+		otherSum, otherParts := parseETag(other.content.ETag)
+		// Plain MD5 sums of both objects are comparable.
+		if parts > 0 || otherParts > 0 || otherSum == "" {
+			return false, false, nil
+		}
+	} else {
+		partSizes := []int64{0}
+		if parts > 0 {
+			partSizes = guessPartSizes(object.content.Size, parts)
+		}
+		etags, err := other.etags(partSizes)
+		if err != nil {
+			return false, false, err
+		}
+		for _, etag := range etags {
+			if etag == strings.ToLower(strings.Trim(object.content.ETag, "\"")) {
+				return true, true, nil
+			}
+		}
+		// The part size of a multipart upload may be guessed wrong.
+		if parts > 0 {
+			return false, false, nil
+		}
+	}
+	opaque, err := object.isOpaqueETag()
+	if err != nil {
+		return false, false, err
+	}
+	if other.content.URL.Type == objectStorage && !opaque {
+		opaque, err = other.isOpaqueETag()
+		if err != nil {
+			return false, false, err
+		}
+	}
+	return false, !opaque, nil
+}
+
+// newChecksumComparator - compare contents by ETags where they are
+// checksums of the data, and by MD5 sums of the data otherwise. Keys
+// in encKeyDB are used to read encrypted objects.
+func newChecksumComparator(firstAlias, secondAlias string, encKeyDB map[string][]prefixSSEPair) contentComparator {
+	return func(first, second *clientContent) (bool, *probe.Error) {
+		firstContent := checksumContent{alias: firstAlias, content: first, keys: encKeyDB}
+		secondContent := checksumContent{alias: secondAlias, content: second, keys: encKeyDB}
+		object, other := firstContent, secondContent
+		if first.URL.Type != objectStorage {
+			object, other = secondContent, firstContent
+		}
+		if object.content.URL.Type == objectStorage {
+			same, known, err := sameETag(object, other)
+			if err != nil {
+				return false, err
+			}
+			if known {
+				return same, nil
+			}
+		}
+		// Fall back to stream both contents.
+		firstSum, err := firstContent.etag(0)
+		if err != nil {
+			return false, err
+		}
+		secondSum, err := secondContent.etag(0)
+		if err != nil {
+			return false, err
+		}
+		return firstSum == secondSum, nil
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMultipartETag(t *testing.T) {
+	data := []byte("0123456789")
+	etag, e := multipartETag(bytes.NewReader(data), 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if etag != "781e5e245d69b566979b86e28d23f2c7" {
+		t.Fatalf("unexpected single part ETag %s", etag)
+	}
+
+	var sums []byte
+	for _, part := range []string{"0123", "4567", "89"} {
+		sum := md5.Sum([]byte(part))
+		sums = append(sums, sum[:]...)
+	}
+	expected := fmt.Sprintf("%x-3", md5.Sum(sums))
+	if etag, e = multipartETag(bytes.NewReader(data), 4); e != nil {
+		t.Fatal(e)
+	}
+	if etag != expected {
+		t.Fatalf("expected multipart ETag %s, got %s", expected, etag)
+	}
+	if sum, parts := parseETag("\"" + strings.ToUpper(etag) + "\""); sum != expected[:32] || parts != 3 {
+		t.Fatalf("unexpected parsed ETag %s with %d parts", sum, parts)
+	}
+	for _, opaque := range []string{"", "not-an-md5", "781e5e245d69b566979b86e28d23f2c7-x"} {
+		if sum, _ := parseETag(opaque); sum != "" {
+			t.Fatalf("expected ETag %q to be opaque", opaque)
+		}
+	}
+}
+
+func TestGuessPartSizes(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		parts    int
+		expected []int64
+	}{
+		{20 * mib, 4, []int64{5 * mib}},
+		{100 * mib, 7, []int64{15 * mib, 16 * mib}},
+		{300 * mib, 3, []int64{128 * mib, 100 * mib}},
+		{30 * mib, 10, []int64{3 * mib}},
+		{30 * mib, 31, nil},
+	}
+	for i, testCase := range testCases {
+		partSizes := guessPartSizes(testCase.size, testCase.parts)
+		if !reflect.DeepEqual(partSizes, testCase.expected) {
+			t.Errorf("Test %d: Expected part sizes %v, got %v", i+1, testCase.expected, partSizes)
+		}
+	}
+}
+
+func TestParsePartSize(t *testing.T) {
+	testCases := []struct {
+		partSize string
+		expected int64
+		success  bool
+	}{
+		{"", 0, true},
+		{"64MiB", 64 * mib, true},
+		{"5MiB", 5 * mib, true},
+		{"4MiB", 0, false},
+		{"6GiB", 0, false},
+		{"large", 0, false},
+	}
+	for i, testCase := range testCases {
+		partSize, err := parsePartSize(testCase.partSize)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: Expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if partSize != testCase.expected {
+			t.Errorf("Test %d: Expected part size %d, got %d", i+1, testCase.expected, partSize)
+		}
+	}
+	if partSize := mcPartSize(2 * 1024 * 1024 * mib); partSize != 256*mib {
+		t.Errorf("Expected mc part size %d for 2TiB, got %d", 256*mib, partSize)
+	}
+	// Streams of unknown size are uploaded in parts sized for 5TiB.
+	if partSize := mcPartSize(-1); partSize != 640*mib {
+		t.Errorf("Expected mc part size %d for unknown sizes, got %d", 640*mib, partSize)
+	}
+}
@@ -0,0 +1,359 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+	"github.com/minio/sio"
+)
+
+// Length of master keys, object keys and IVs of client-side encryption.
+const cseKeyLength = 32
+
+// Envelope of a client-side encrypted object, stored in its user
+// metadata. The object key is sealed with a key derived from the
+// master key and the IV, the object itself is encrypted with the
+// object key in the DARE format.
+const (
+	cseAlgorithm     = "DARE-HMAC-SHA256"
+	cseAlgorithmMeta = "X-Amz-Meta-Mc-Cse-Algorithm"
+	cseKeyIDMeta     = "X-Amz-Meta-Mc-Cse-Key-Id"
+	cseIVMeta        = "X-Amz-Meta-Mc-Cse-Iv"
+	cseSealedKeyMeta = "X-Amz-Meta-Mc-Cse-Sealed-Key"
+	cseSizeMeta      = "X-Amz-Meta-Mc-Cse-Size"
+)
+
+// cseKey - master key of client-side encryption.
+type cseKey []byte
+
+// loadCSEKey - read a master key from keyFile, which holds either
+// 32 raw bytes or 64 hex characters.
+func loadCSEKey(keyFile string) (cseKey, *probe.Error) {
+	data, e := ioutil.ReadFile(keyFile)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if hexKey := strings.TrimSpace(string(data)); len(hexKey) == 2*cseKeyLength {
+		if key, e := hex.DecodeString(hexKey); e == nil {
+			return key, nil
+		}
+	}
+	if len(data) != cseKeyLength {
+		return nil, errInvalidCSEKey(keyFile)
+	}
+	return data, nil
+}
+
+// setCSEKey - enable client-side encryption with the master key in
+// keyFile for all clients created afterwards, an empty keyFile
+// disables it. Objects copied into object storage targets are
+// encrypted and other sources are read as they are stored, without
+// such targets objects are decrypted.
+func setCSEKey(keyFile string, targetURLs ...string) *probe.Error {
+	globalCSEKey = nil
+	globalCSETargets = nil
+	if keyFile == "" {
+		return nil
+	}
+	key, err := loadCSEKey(keyFile)
+	if err != nil {
+		return err.Trace(keyFile)
+	}
+	var targets []string
+	for _, targetURL := range targetURLs {
+		_, urlStrFull, hostCfg, err := expandAlias(targetURL)
+		if err != nil {
+			return err.Trace(targetURL)
+		}
+		if hostCfg != nil {
+			targets = append(targets, strings.TrimSuffix(urlStrFull, "/"))
+		}
+	}
+	// Sources could neither be decrypted nor copied as they are.
+	if len(targets) > 0 && len(targets) < len(targetURLs) {
+		return errCSEMixedTargets()
+	}
+	globalCSEKey = key
+	globalCSETargets = targets
+	return nil
+}
+
+// isCSEURL - tells whether objects at urlStr, an expanded URL, are
+// encrypted or decrypted with the client-side encryption key.
+func isCSEURL(urlStr string) bool {
+	if len(globalCSEKey) == 0 {
+		return false
+	}
+	if len(globalCSETargets) == 0 {
+		return true
+	}
+	for _, target := range globalCSETargets {
+		if urlStr == target || strings.HasPrefix(urlStr, target+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// id - identify the master key without revealing it, so that
+// objects sealed with another master key can be told apart.
+func (k cseKey) id() string {
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte("mc client-side encryption key id"))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// kek - derive the key encryption key of an object from its IV.
+func (k cseKey) kek(iv []byte) []byte {
+	mac := hmac.New(sha256.New, k)
+	mac.Write(iv)
+	return mac.Sum(nil)
+}
+
+// seal - generate a random object key and record it in metadata,
+// sealed with the master key.
+func (k cseKey) seal(metadata map[string]string) ([]byte, *probe.Error) {
+	iv := make([]byte, cseKeyLength)
+	if _, e := io.ReadFull(rand.Reader, iv); e != nil {
+		return nil, probe.NewError(e)
+	}
+	objectKey := make([]byte, cseKeyLength)
+	if _, e := io.ReadFull(rand.Reader, objectKey); e != nil {
+		return nil, probe.NewError(e)
+	}
+	var sealedKey bytes.Buffer
+	if _, e := sio.Encrypt(&sealedKey, bytes.NewReader(objectKey), cseConfig(k.kek(iv))); e != nil {
+		return nil, probe.NewError(e)
+	}
+	metadata[cseAlgorithmMeta] = cseAlgorithm
+	metadata[cseKeyIDMeta] = k.id()
+	metadata[cseIVMeta] = base64.StdEncoding.EncodeToString(iv)
+	metadata[cseSealedKeyMeta] = base64.StdEncoding.EncodeToString(sealedKey.Bytes())
+	return objectKey, nil
+}
+
+// unseal - recover the object key recorded in metadata.
+func (k cseKey) unseal(object string, metadata map[string]string) ([]byte, *probe.Error) {
+	if metadata[cseAlgorithmMeta] != cseAlgorithm {
+		return nil, probe.NewError(ObjectNotClientEncrypted{Object: object})
+	}
+	if metadata[cseKeyIDMeta] != k.id() {
+		return nil, probe.NewError(ClientEncryptionKeyMismatch{Object: object})
+	}
+	iv, e := base64.StdEncoding.DecodeString(metadata[cseIVMeta])
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	sealedKey, e := base64.StdEncoding.DecodeString(metadata[cseSealedKeyMeta])
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	var objectKey bytes.Buffer
+	if _, e = sio.Decrypt(&objectKey, bytes.NewReader(sealedKey), cseConfig(k.kek(iv))); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return objectKey.Bytes(), nil
+}
+
+// cseConfig - DARE configuration for key.
+func cseConfig(key []byte) sio.Config {
+	return sio.Config{
+		Key:        key,
+		MinVersion: sio.Version20,
+		MaxVersion: sio.Version20,
+	}
+}
+
+// cseDecryptedSize - size of the plaintext of an encrypted object,
+// sizes which no DARE stream can have are returned as is.
+func cseDecryptedSize(size int64) int64 {
+	if size < 0 {
+		return size
+	}
+	decSize, e := sio.DecryptedSize(uint64(size))
+	if e != nil {
+		return size
+	}
+	return int64(decSize)
+}
+
+// cseClient - encrypts objects before they are uploaded and decrypts
+// them after they are downloaded, the server only ever sees the
+// encrypted objects. Sizes are reported as plaintext sizes.
+type cseClient struct {
+	Client
+	key cseKey
+}
+
+// newCSEClient - wraps clnt to encrypt objects with key, clnt is
+// returned as is for filesystems or when key is empty.
+func newCSEClient(clnt Client, key cseKey) Client {
+	if len(key) == 0 || clnt.GetURL().Type != objectStorage {
+		return clnt
+	}
+	return &cseClient{Client: clnt, key: key}
+}
+
+// toS3Client - returns the object storage client behind clnt, which
+// may be wrapped for client-side encryption.
+func toS3Client(clnt Client) (*s3Client, bool) {
+	if c, ok := clnt.(*cseClient); ok {
+		clnt = c.Client
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	return s3Clnt, ok
+}
+
+// plaintextContent - report the plaintext size of an encrypted
+// object. Without metadata the object is assumed to be encrypted.
+func (c *cseClient) plaintextContent(content *clientContent) *clientContent {
+	if content.Err != nil || content.Type.IsDir() {
+		return content
+	}
+	if size, e := strconv.ParseInt(content.Metadata[cseSizeMeta], 10, 64); e == nil {
+		content.Size = size
+	} else if len(content.Metadata) == 0 || content.Metadata[cseAlgorithmMeta] != "" {
+		content.Size = cseDecryptedSize(content.Size)
+	}
+	return content
+}
+
+// plaintextContents - report plaintext sizes of all listed objects.
+func (c *cseClient) plaintextContents(contentCh <-chan *clientContent) <-chan *clientContent {
+	plaintextCh := make(chan *clientContent)
+	go func() {
+		defer close(plaintextCh)
+		for content := range contentCh {
+			plaintextCh <- c.plaintextContent(content)
+		}
+	}()
+	return plaintextCh
+}
+
+// List - list objects with their plaintext sizes, listings carry no
+// metadata so all objects are assumed to be encrypted.
+func (c *cseClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	if isIncomplete {
+		return c.Client.List(isRecursive, isIncomplete, showDir)
+	}
+	return c.plaintextContents(c.Client.List(isRecursive, isIncomplete, showDir))
+}
+
+// ListVersions - list versions with their plaintext sizes.
+func (c *cseClient) ListVersions(isRecursive bool) <-chan *clientContent {
+	return c.plaintextContents(c.Client.ListVersions(isRecursive))
+}
+
+// Stat - stat the object, reporting its plaintext size.
+func (c *cseClient) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	content, err := c.Client.Stat(isIncomplete, isFetchMeta, sse)
+	if err != nil || isIncomplete {
+		return content, err
+	}
+	return c.plaintextContent(content), nil
+}
+
+// StatVersion - stat a version of the object, reporting its plaintext size.
+func (c *cseClient) StatVersion(versionID string, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	content, err := c.Client.StatVersion(versionID, sse)
+	if err != nil {
+		return nil, err
+	}
+	return c.plaintextContent(content), nil
+}
+
+// Get - get the decrypted object.
+func (c *cseClient) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	return c.GetVersion("", sse)
+}
+
+// GetVersion - get a decrypted version of the object.
+func (c *cseClient) GetVersion(versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	content, err := c.Client.StatVersion(versionID, sse)
+	if err != nil {
+		return nil, err
+	}
+	// Objects stored before encryption was enabled are read as they are.
+	if content.Metadata[cseAlgorithmMeta] == "" {
+		return c.Client.GetVersion(versionID, sse)
+	}
+	objectKey, err := c.key.unseal(c.GetURL().String(), content.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := c.Client.GetVersion(versionID, sse)
+	if err != nil {
+		return nil, err
+	}
+	decReader, e := sio.DecryptReader(reader, cseConfig(objectKey))
+	if e != nil {
+		reader.Close()
+		return nil, probe.NewError(e)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{decReader, reader}, nil
+}
+
+// Put - encrypt and upload the object, progress is reported in
+// plaintext bytes.
+func (c *cseClient) Put(ctx context.Context, reader io.Reader, size int64, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide) (int64, *probe.Error) {
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	// Objects copied from an encrypted source are encrypted already.
+	if metadata[cseAlgorithmMeta] != "" {
+		return c.Client.Put(ctx, reader, size, metadata, progress, sse)
+	}
+	objectKey, err := c.key.seal(metadata)
+	if err != nil {
+		return 0, err
+	}
+	encSize := int64(-1)
+	delete(metadata, cseSizeMeta)
+	if size >= 0 {
+		n, e := sio.EncryptedSize(uint64(size))
+		if e != nil {
+			return 0, probe.NewError(e)
+		}
+		encSize = int64(n)
+		metadata[cseSizeMeta] = strconv.FormatInt(size, 10)
+	}
+	if progress != nil {
+		reader = hookreader.NewHook(reader, progress)
+	}
+	encReader, e := sio.EncryptReader(reader, cseConfig(objectKey))
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	n, err := c.Client.Put(ctx, encReader, encSize, metadata, nil, sse)
+	return cseDecryptedSize(n), err
+}
@@ -16,7 +16,10 @@
 
 package cmd
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 /// Collection of standard errors
 
@@ -56,6 +59,13 @@ func (e BucketNameEmpty) Error() string {
 	return "Bucket name cannot be empty."
 }
 
+// ObjectNameEmpty - object name empty.
+type ObjectNameEmpty struct{}
+
+func (e ObjectNameEmpty) Error() string {
+	return "Object name cannot be empty."
+}
+
 // BucketInvalid - bucket name invalid.
 type BucketInvalid struct {
 	Bucket string
@@ -147,9 +157,14 @@ func (e EmptyPath) Error() string {
 }
 
 // ObjectMissing (EINVAL) - object key missing.
-type ObjectMissing struct{}
+type ObjectMissing struct {
+	timeRef time.Time
+}
 
 func (e ObjectMissing) Error() string {
+	if !e.timeRef.IsZero() {
+		return "Object did not exist at `" + e.timeRef.Format(printDate) + "`"
+	}
 	return "Object does not exist"
 }
 
@@ -191,3 +206,74 @@ type SameFile struct {
 func (e SameFile) Error() string {
 	return fmt.Sprintf("'%s' and '%s' are the same file", e.Source, e.Destination)
 }
+
+// VersionMissing - object version does not exist.
+type VersionMissing struct {
+	VersionID string
+}
+
+func (e VersionMissing) Error() string {
+	return "Object version `" + e.VersionID + "` does not exist."
+}
+
+// VersionIsDeleteMarker - object version is a delete marker.
+type VersionIsDeleteMarker struct {
+	VersionID string
+}
+
+func (e VersionIsDeleteMarker) Error() string {
+	return "Object version `" + e.VersionID + "` is a delete marker."
+}
+
+// ObjectLocked - object is protected by a retention setting or a legal hold.
+type ObjectLocked struct {
+	Object string
+}
+
+func (e ObjectLocked) Error() string {
+	return "Object `" + e.Object + "` is locked by a retention setting or a legal hold."
+}
+
+// BucketLockDisabled - bucket was not created with object lock enabled.
+type BucketLockDisabled GenericBucketError
+
+func (e BucketLockDisabled) Error() string {
+	return "Object lock is not enabled on bucket `" + e.Bucket + "`."
+}
+
+// ObjectNotClientEncrypted - object was not written with client-side encryption.
+type ObjectNotClientEncrypted struct {
+	Object string
+}
+
+func (e ObjectNotClientEncrypted) Error() string {
+	return "Object `" + e.Object + "` is not client-side encrypted."
+}
+
+// ClientEncryptionKeyMismatch - object was encrypted with another client-side encryption key.
+type ClientEncryptionKeyMismatch struct {
+	Object string
+}
+
+func (e ClientEncryptionKeyMismatch) Error() string {
+	return "Object `" + e.Object + "` is encrypted with a different client-side encryption key."
+}
+
+// ObjectCorrupted - target object does not match the data uploaded to it.
+type ObjectCorrupted struct {
+	Object string
+}
+
+func (e ObjectCorrupted) Error() string {
+	return "Object `" + e.Object + "` does not match the data uploaded to it."
+}
+
+// SyncConflict - object was changed on both sides of a sync.
+type SyncConflict struct {
+	First  string
+	Second string
+}
+
+func (e SyncConflict) Error() string {
+	return "Objects `" + e.First + "` and `" + e.Second + "` were both changed since the last sync."
+}
@@ -41,6 +41,9 @@ import (
 // filesystem client
 type fsClient struct {
 	PathURL *clientURL
+
+	// paths excluded from recursive listings, if set
+	excludeRules *excludeRules
 }
 
 const (
@@ -426,6 +429,68 @@ func (f *fsClient) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
 	return f.get()
 }
 
+// ListVersions - not implemented for filesystem.
+func (f *fsClient) ListVersions(isRecursive bool) <-chan *clientContent {
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{
+		Err: probe.NewError(APINotImplemented{
+			API:     "ListVersions",
+			APIType: "filesystem",
+		}),
+	}
+	close(contentCh)
+	return contentCh
+}
+
+// StatVersion - filesystem has only the latest version.
+func (f *fsClient) StatVersion(versionID string, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	if versionID != "" {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "StatVersion",
+			APIType: "filesystem",
+		})
+	}
+	return f.Stat(false, true, sse)
+}
+
+// GetVersion - filesystem has only the latest version.
+func (f *fsClient) GetVersion(versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	if versionID != "" {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "GetVersion",
+			APIType: "filesystem",
+		})
+	}
+	return f.get()
+}
+
+// GetTags - filesystem objects do not have tags.
+func (f *fsClient) GetTags(versionID string) (map[string]string, *probe.Error) {
+	if versionID != "" {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "GetTags",
+			APIType: "filesystem",
+		})
+	}
+	return map[string]string{}, nil
+}
+
+// SetTags - not implemented.
+func (f *fsClient) SetTags(tags map[string]string) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetTags",
+		APIType: "filesystem",
+	})
+}
+
+// DeleteTags - not implemented.
+func (f *fsClient) DeleteTags() *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "DeleteTags",
+		APIType: "filesystem",
+	})
+}
+
 // Remove - remove entry read from clientContent channel.
 func (f *fsClient) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *clientContent) <-chan *probe.Error {
 	errorCh := make(chan *probe.Error)
@@ -435,6 +500,13 @@ func (f *fsClient) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 		defer close(errorCh)
 
 		for content := range contentCh {
+			if content.VersionID != "" {
+				errorCh <- probe.NewError(APINotImplemented{
+					API:     "RemoveVersion",
+					APIType: "filesystem",
+				})
+				return
+			}
 			name := content.URL.Path
 			// Add partSuffix for incomplete uploads.
 			if isIncomplete {
@@ -765,6 +837,7 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *clientContent) {
 	defer close(contentCh)
 	var dirName string
 	var filePrefix string
+	var excludeRoot string
 	pathURL := *f.PathURL
 	visitFS := func(fp string, fi os.FileInfo, e error) error {
 		// If file path ends with filepath.Separator and equals to root path, skip it.
@@ -821,6 +894,18 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *clientContent) {
 			}
 			return e
 		}
+		// Skip excluded paths, excluded folders are not walked
+		// unless paths inside them may be included again.
+		if f.excludeRules != nil {
+			if rel, e := filepath.Rel(excludeRoot, fp); e == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+				if f.excludeRules.isExcluded(excludeRoot, rel, fi.IsDir()) {
+					if fi.IsDir() && !f.excludeRules.hasIncludes() {
+						return ioutils.ErrSkipDir
+					}
+					return nil
+				}
+			}
+		}
 		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
 			fi, e = os.Stat(fp)
 			if e != nil {
@@ -877,6 +962,11 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *clientContent) {
 		// filePrefix is kept for filtering incoming contents through WalkFunc.
 		filePrefix = pathURL.Path
 	}
+	// Exclusion rules are relative to the listed folder.
+	excludeRoot = pathURL.Path
+	if st, e := os.Stat(excludeRoot); e != nil || !st.IsDir() {
+		excludeRoot = dirName
+	}
 	// walks invokes our custom function.
 	e := ioutils.FTW(dirName, visitFS)
 	if e != nil {
@@ -887,10 +977,16 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *clientContent) {
 }
 
 // MakeBucket - create a new bucket.
-func (f *fsClient) MakeBucket(region string, ignoreExisting bool) *probe.Error {
+func (f *fsClient) MakeBucket(region string, ignoreExisting, withLock bool) *probe.Error {
 	// TODO: ignoreExisting has no effect currently. In the future, we want
 	// to call os.Mkdir() when ignoredExisting is disabled and os.MkdirAll()
 	// otherwise.
+	if withLock {
+		return probe.NewError(APINotImplemented{
+			API:     "MakeBucketWithLock",
+			APIType: "filesystem",
+		})
+	}
 	e := os.MkdirAll(f.PathURL.Path, 0777)
 	if e != nil {
 		return probe.NewError(e)
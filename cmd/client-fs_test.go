@@ -194,7 +194,7 @@ func (s *TestSuite) TestPutBucket(c *C) {
 	bucketPath := filepath.Join(root, "bucket")
 	fsClient, err := fsNew(bucketPath)
 	c.Assert(err, IsNil)
-	err = fsClient.MakeBucket("us-east-1", true)
+	err = fsClient.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 }
 
@@ -208,7 +208,7 @@ func (s *TestSuite) TestStatBucket(c *C) {
 
 	fsClient, err := fsNew(bucketPath)
 	c.Assert(err, IsNil)
-	err = fsClient.MakeBucket("us-east-1", true)
+	err = fsClient.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 	_, err = fsClient.Stat(false, false, nil)
 	c.Assert(err, IsNil)
@@ -223,7 +223,7 @@ func (s *TestSuite) TestBucketACLFails(c *C) {
 	bucketPath := filepath.Join(root, "bucket")
 	fsClient, err := fsNew(bucketPath)
 	c.Assert(err, IsNil)
-	err = fsClient.MakeBucket("us-east-1", true)
+	err = fsClient.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 
 	// On windows setting permissions is not supported.
@@ -363,3 +363,23 @@ func (s *TestSuite) TestCopy(c *C) {
 	err = fsClientTarget.Copy(sourcePath, int64(len(data)), nil, nil, nil, nil)
 	c.Assert(err, IsNil)
 }
+
+// Test that tagging filesystem objects is not implemented.
+func (s *TestSuite) TestTagsNotImplemented(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "fs-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	fsClient, err := fsNew(filepath.Join(root, "object"))
+	c.Assert(err, IsNil)
+
+	err = fsClient.SetTags(map[string]string{"key": "value"})
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(APINotImplemented)
+	c.Assert(ok, Equals, true)
+
+	err = fsClient.DeleteTags()
+	c.Assert(err, NotNil)
+	_, ok = err.ToGoError().(APINotImplemented)
+	c.Assert(ok, Equals, true)
+}
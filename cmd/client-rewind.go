@@ -0,0 +1,169 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// rewindClient - presents objects of a versioned bucket as they
+// were at timeRef, each key resolves to the version which was the
+// latest one at that instant. Keys whose version at that instant
+// is a delete marker, or which did not exist yet, are hidden.
+type rewindClient struct {
+	Client
+	timeRef time.Time
+}
+
+// newRewindClient - wraps clnt to rewind it to timeRef, clnt is
+// returned as is when timeRef is zero.
+func newRewindClient(clnt Client, timeRef time.Time) Client {
+	if timeRef.IsZero() {
+		return clnt
+	}
+	return &rewindClient{Client: clnt, timeRef: timeRef}
+}
+
+// List - list objects as they were at timeRef. Incomplete uploads
+// have no versions, and directories are always listed first.
+func (r *rewindClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	contentCh := make(chan *clientContent)
+	go func() {
+		defer close(contentCh)
+		// Versions of a key are listed newest first, remember
+		// the last resolved key to skip its older versions.
+		var resolvedKey string
+		for content := range r.Client.ListVersions(isRecursive) {
+			if content.Err != nil || content.Type.IsDir() {
+				if content.Type.IsDir() && isRecursive && showDir == DirNone {
+					continue
+				}
+				contentCh <- content
+				continue
+			}
+			if content.URL.Path == resolvedKey || content.Time.After(r.timeRef) {
+				continue
+			}
+			resolvedKey = content.URL.Path
+			if content.IsDeleteMarker {
+				continue
+			}
+			content.IsLatest = false
+			contentCh <- content
+		}
+	}()
+	return contentCh
+}
+
+// Stat - stat the object or directory as it was at timeRef.
+func (r *rewindClient) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	s3Clnt, ok := toS3Client(r.Client)
+	if !ok {
+		return nil, probe.NewError(APINotImplemented{API: "Rewind", APIType: "filesystem"})
+	}
+	bucket, object := s3Clnt.url2BucketAndObject()
+	separator := string(s3Clnt.targetURL.Separator)
+	if isIncomplete || object == "" || strings.HasSuffix(object, separator) {
+		return r.Client.Stat(isIncomplete, isFetchMeta, sse)
+	}
+
+	objectPath := s3Clnt.joinPath(bucket, object)
+	for content := range r.List(false, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(objectPath)
+		}
+		if content.Type.IsDir() && strings.TrimSuffix(content.URL.Path, separator) == objectPath {
+			return &clientContent{URL: s3Clnt.GetURL(), Time: content.Time, Type: os.ModeDir}, nil
+		}
+		if content.URL.Path != objectPath {
+			continue
+		}
+		if !isFetchMeta {
+			content.URL = s3Clnt.GetURL()
+			return content, nil
+		}
+		return r.Client.StatVersion(content.VersionID, sse)
+	}
+	return nil, probe.NewError(ObjectMissing{timeRef: r.timeRef})
+}
+
+// Get - get the object as it was at timeRef.
+func (r *rewindClient) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	content, err := r.Stat(false, false, sse)
+	if err != nil {
+		return nil, err
+	}
+	if content.Type.IsDir() {
+		return nil, errInvalidSource(content.URL.String())
+	}
+	return r.Client.GetVersion(content.VersionID, sse)
+}
+
+// GetVersion - an explicit version takes precedence over timeRef.
+func (r *rewindClient) GetVersion(versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	if versionID == "" {
+		return r.Get(sse)
+	}
+	return r.Client.GetVersion(versionID, sse)
+}
+
+// StatVersion - an explicit version takes precedence over timeRef.
+func (r *rewindClient) StatVersion(versionID string, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	if versionID == "" {
+		return r.Stat(false, true, sse)
+	}
+	return r.Client.StatVersion(versionID, sse)
+}
+
+// GetTags - get tags of the version which was the latest at timeRef,
+// an explicit version takes precedence over timeRef.
+func (r *rewindClient) GetTags(versionID string) (map[string]string, *probe.Error) {
+	if versionID == "" {
+		content, err := r.Stat(false, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		versionID = content.VersionID
+	}
+	return r.Client.GetTags(versionID)
+}
+
+// parseRewind parses the --rewind value into an absolute point in
+// time, value is either a date (RFC3339 or YYYY-MM-DD) or a duration
+// in the past like 7d10h.
+func parseRewind(rewind string) (time.Time, *probe.Error) {
+	if rewind == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, e := time.Parse(layout, rewind); e == nil {
+			return t, nil
+		}
+	}
+	duration, e := ioutils.ParseDurationTime(rewind)
+	if e != nil {
+		return time.Time{}, probe.NewError(e)
+	}
+	return UTCNow().Add(-duration), nil
+}
@@ -0,0 +1,60 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRewind(t *testing.T) {
+	testCases := []struct {
+		rewind   string
+		expected time.Time
+		age      time.Duration
+		success  bool
+	}{
+		{rewind: "", success: true},
+		{rewind: "2019-05-21T18:24:21Z", expected: time.Date(2019, 5, 21, 18, 24, 21, 0, time.UTC), success: true},
+		{rewind: "2019-05-21", expected: time.Date(2019, 5, 21, 0, 0, 0, 0, time.UTC), success: true},
+		{rewind: "1d2h", age: 26 * time.Hour, success: true},
+		{rewind: "yesterday", success: false},
+	}
+
+	for i, testCase := range testCases {
+		now := UTCNow()
+		timeRef, err := parseRewind(testCase.rewind)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: Expected success, got %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: Expected error, got success", i+1)
+		}
+		if err != nil {
+			continue
+		}
+		if testCase.age != 0 {
+			if age := now.Sub(timeRef).Round(time.Minute); age != testCase.age {
+				t.Errorf("Test %d: Expected age %s, got %s", i+1, testCase.age, age)
+			}
+			continue
+		}
+		if !timeRef.Equal(testCase.expected) {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, testCase.expected, timeRef)
+		}
+	}
+}
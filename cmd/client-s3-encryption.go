@@ -0,0 +1,123 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Default bucket encryption algorithms.
+const (
+	sseAlgorithmAES256 = "AES256"
+	sseAlgorithmKMS    = "aws:kms"
+)
+
+// bucketEncryption - default server side encryption of a bucket.
+type bucketEncryption struct {
+	XMLName xml.Name               `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ServerSideEncryptionConfiguration"`
+	Rules   []bucketEncryptionRule `xml:"Rule"`
+}
+
+// bucketEncryptionRule - encryption applied to objects uploaded
+// without encryption headers.
+type bucketEncryptionRule struct {
+	Default struct {
+		SSEAlgorithm   string `xml:"SSEAlgorithm"`
+		KMSMasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+	} `xml:"ApplyServerSideEncryptionByDefault"`
+}
+
+// GetBucketEncryption - get the default encryption of the bucket, an
+// empty algorithm means objects are not encrypted by default.
+func (c *s3Client) GetBucketEncryption() (algorithm, keyID string, err *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", "", probe.NewError(BucketNameEmpty{})
+	}
+	config := bucketEncryption{}
+	e := c.executeXML(context.Background(), s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		query:  url.Values{"encryption": []string{""}},
+	}, &config)
+	if e != nil {
+		if minio.ToErrorResponse(e).Code == "ServerSideEncryptionConfigurationNotFoundError" {
+			return "", "", nil
+		}
+		return "", "", c.toEncryptionError(e, bucket)
+	}
+	if len(config.Rules) == 0 {
+		return "", "", nil
+	}
+	return config.Rules[0].Default.SSEAlgorithm, config.Rules[0].Default.KMSMasterKeyID, nil
+}
+
+// SetBucketEncryption - set the default encryption of the bucket, keyID
+// is only used with SSE-KMS. An empty algorithm clears the default
+// encryption.
+func (c *s3Client) SetBucketEncryption(algorithm, keyID string) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	query := url.Values{"encryption": []string{""}}
+	if algorithm == "" {
+		_, e := c.executeDiscard(context.Background(), s3Request{
+			method: http.MethodDelete,
+			bucket: bucket,
+			query:  query,
+		})
+		if e != nil {
+			return c.toEncryptionError(e, bucket)
+		}
+		return nil
+	}
+	rule := bucketEncryptionRule{}
+	rule.Default.SSEAlgorithm = algorithm
+	if algorithm == sseAlgorithmKMS {
+		rule.Default.KMSMasterKeyID = keyID
+	}
+	config := bucketEncryption{Rules: []bucketEncryptionRule{rule}}
+	data, e := xml.Marshal(config)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	_, e = c.executeDiscard(context.Background(), s3Request{
+		method:  http.MethodPut,
+		bucket:  bucket,
+		query:   query,
+		content: data,
+	})
+	if e != nil {
+		return c.toEncryptionError(e, bucket)
+	}
+	return nil
+}
+
+// toEncryptionError - convert bucket encryption API errors into typed errors.
+func (c *s3Client) toEncryptionError(e error, bucket string) *probe.Error {
+	if minio.ToErrorResponse(e).Code == "NotImplemented" {
+		return probe.NewError(APINotImplemented{API: "BucketEncryption", APIType: c.targetURL.Host})
+	}
+	return c.toVersionError(e, bucket, "")
+}
@@ -0,0 +1,174 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"sort"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Lifecycle rule states.
+const (
+	lifecycleEnabled  = "Enabled"
+	lifecycleDisabled = "Disabled"
+)
+
+// lifecycleConfiguration - lifecycle configuration of a bucket, the
+// same document is used by ilm export and ilm import in JSON form.
+type lifecycleConfiguration struct {
+	XMLName xml.Name        `xml:"LifecycleConfiguration" json:"-"`
+	Rules   []lifecycleRule `xml:"Rule" json:"Rules"`
+}
+
+// lifecycleRule - a single expiration and/or transition rule.
+type lifecycleRule struct {
+	ID         string               `xml:"ID" json:"ID"`
+	Status     string               `xml:"Status" json:"Status"`
+	Prefix     string               `xml:"Prefix,omitempty" json:"-"`
+	Filter     lifecycleFilter      `xml:"Filter" json:"Filter"`
+	Expiration *lifecycleExpiration `xml:"Expiration,omitempty" json:"Expiration,omitempty"`
+	Transition *lifecycleTransition `xml:"Transition,omitempty" json:"Transition,omitempty"`
+}
+
+// lifecycleFilter - objects a rule applies to, Tag and And are
+// mutually exclusive with a plain Prefix.
+type lifecycleFilter struct {
+	Prefix string        `xml:"Prefix,omitempty" json:"Prefix,omitempty"`
+	Tag    *objectTag    `xml:"Tag,omitempty" json:"Tag,omitempty"`
+	And    *lifecycleAnd `xml:"And,omitempty" json:"And,omitempty"`
+}
+
+// lifecycleAnd - combines a prefix with several tags.
+type lifecycleAnd struct {
+	Prefix string      `xml:"Prefix,omitempty" json:"Prefix,omitempty"`
+	Tags   []objectTag `xml:"Tag" json:"Tags"`
+}
+
+// lifecycleExpiration - when objects matching a rule are removed.
+type lifecycleExpiration struct {
+	Days int        `xml:"Days,omitempty" json:"Days,omitempty"`
+	Date *time.Time `xml:"Date,omitempty" json:"Date,omitempty"`
+}
+
+// lifecycleTransition - when objects matching a rule move to another
+// storage class.
+type lifecycleTransition struct {
+	Days         int        `xml:"Days,omitempty" json:"Days,omitempty"`
+	Date         *time.Time `xml:"Date,omitempty" json:"Date,omitempty"`
+	StorageClass string     `xml:"StorageClass" json:"StorageClass"`
+}
+
+// newLifecycleFilter - build the filter of a rule from a prefix and tags.
+func newLifecycleFilter(prefix string, tags map[string]string) lifecycleFilter {
+	if len(tags) == 0 {
+		return lifecycleFilter{Prefix: prefix}
+	}
+	var tagSet []objectTag
+	for k, v := range tags {
+		tagSet = append(tagSet, objectTag{Key: k, Value: v})
+	}
+	sort.Slice(tagSet, func(i, j int) bool { return tagSet[i].Key < tagSet[j].Key })
+	if prefix == "" && len(tagSet) == 1 {
+		return lifecycleFilter{Tag: &tagSet[0]}
+	}
+	return lifecycleFilter{And: &lifecycleAnd{Prefix: prefix, Tags: tagSet}}
+}
+
+// prefix - prefix of the objects the rule applies to.
+func (f lifecycleFilter) prefix() string {
+	if f.And != nil {
+		return f.And.Prefix
+	}
+	return f.Prefix
+}
+
+// tags - tags of the objects the rule applies to.
+func (f lifecycleFilter) tags() map[string]string {
+	tags := map[string]string{}
+	if f.Tag != nil {
+		tags[f.Tag.Key] = f.Tag.Value
+	}
+	if f.And != nil {
+		for _, tag := range f.And.Tags {
+			tags[tag.Key] = tag.Value
+		}
+	}
+	return tags
+}
+
+// GetLifecycle - get the lifecycle configuration of the bucket, a
+// bucket without lifecycle configuration has no rules.
+func (c *s3Client) GetLifecycle() (lifecycleConfiguration, *probe.Error) {
+	config := lifecycleConfiguration{}
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return config, probe.NewError(BucketNameEmpty{})
+	}
+	lifecycle, e := c.api.GetBucketLifecycle(bucket)
+	if e != nil {
+		return lifecycleConfiguration{}, c.toLifecycleError(e, bucket)
+	}
+	if lifecycle == "" {
+		return config, nil
+	}
+	if e = xml.Unmarshal([]byte(lifecycle), &config); e != nil {
+		return lifecycleConfiguration{}, probe.NewError(e)
+	}
+	// Rules written before filters were introduced carry the
+	// prefix at the top level of the rule.
+	for i, rule := range config.Rules {
+		if rule.Prefix != "" && rule.Filter.prefix() == "" {
+			config.Rules[i].Filter.Prefix = rule.Prefix
+		}
+		config.Rules[i].Prefix = ""
+	}
+	return config, nil
+}
+
+// SetLifecycle - replace the lifecycle configuration of the bucket,
+// a configuration without rules removes it.
+func (c *s3Client) SetLifecycle(config lifecycleConfiguration) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	// An empty configuration removes the lifecycle of the bucket.
+	var lifecycle string
+	if len(config.Rules) > 0 {
+		data, e := xml.Marshal(config)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		lifecycle = string(data)
+	}
+	if e := c.api.SetBucketLifecycle(bucket, lifecycle); e != nil {
+		return c.toLifecycleError(e, bucket)
+	}
+	return nil
+}
+
+// toLifecycleError - convert lifecycle API errors into typed errors.
+func (c *s3Client) toLifecycleError(e error, bucket string) *probe.Error {
+	if minio.ToErrorResponse(e).Code == "NotImplemented" {
+		return probe.NewError(APINotImplemented{API: "Lifecycle", APIType: c.targetURL.Host})
+	}
+	return c.toVersionError(e, bucket, "")
+}
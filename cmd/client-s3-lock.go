@@ -0,0 +1,314 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Object lock retention modes.
+const (
+	retentionGovernance = "GOVERNANCE"
+	retentionCompliance = "COMPLIANCE"
+)
+
+// Units of a default retention validity.
+const (
+	validityDays  = "DAYS"
+	validityYears = "YEARS"
+)
+
+// Object legal hold states.
+const (
+	legalHoldOn  = "ON"
+	legalHoldOff = "OFF"
+)
+
+// objectLockConfiguration - object lock configuration of a bucket.
+type objectLockConfiguration struct {
+	XMLName           xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ObjectLockConfiguration"`
+	ObjectLockEnabled string          `xml:"ObjectLockEnabled"`
+	Rule              *objectLockRule `xml:"Rule,omitempty"`
+}
+
+// objectLockRule - default retention applied to new objects of a bucket.
+type objectLockRule struct {
+	DefaultRetention struct {
+		Mode  string `xml:"Mode"`
+		Days  uint64 `xml:"Days,omitempty"`
+		Years uint64 `xml:"Years,omitempty"`
+	} `xml:"DefaultRetention"`
+}
+
+// objectRetention - retention setting of an object.
+type objectRetention struct {
+	XMLName         xml.Name   `xml:"http://s3.amazonaws.com/doc/2006-03-01/ Retention"`
+	Mode            string     `xml:"Mode,omitempty"`
+	RetainUntilDate *time.Time `xml:"RetainUntilDate,omitempty"`
+}
+
+// objectLegalHold - legal hold status of an object.
+type objectLegalHold struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LegalHold"`
+	Status  string   `xml:"Status"`
+}
+
+// makeBucketWithLock - create a bucket with object lock enabled,
+// which is only possible at bucket creation.
+func (c *s3Client) makeBucketWithLock(bucket, region string) error {
+	var content []byte
+	if region != "" && region != "us-east-1" {
+		config := struct {
+			XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CreateBucketConfiguration"`
+			Location string   `xml:"LocationConstraint"`
+		}{Location: region}
+		var e error
+		if content, e = xml.Marshal(config); e != nil {
+			return e
+		}
+	}
+	_, e := c.executeDiscard(context.Background(), s3Request{
+		method:  http.MethodPut,
+		bucket:  bucket,
+		header:  http.Header{"X-Amz-Bucket-Object-Lock-Enabled": []string{"true"}},
+		content: content,
+	})
+	return e
+}
+
+// GetObjectLockConfig - get the default retention of the bucket, an
+// empty mode means no default retention is set.
+func (c *s3Client) GetObjectLockConfig() (mode string, validity uint64, unit string, err *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", 0, "", probe.NewError(BucketNameEmpty{})
+	}
+	config := objectLockConfiguration{}
+	e := c.executeXML(context.Background(), s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		query:  url.Values{"object-lock": []string{""}},
+	}, &config)
+	if e != nil {
+		return "", 0, "", c.toLockError(e, bucket, "")
+	}
+	if config.Rule == nil {
+		return "", 0, "", nil
+	}
+	retention := config.Rule.DefaultRetention
+	if retention.Years > 0 {
+		return retention.Mode, retention.Years, validityYears, nil
+	}
+	return retention.Mode, retention.Days, validityDays, nil
+}
+
+// SetObjectLockConfig - set the default retention of the bucket, an
+// empty mode clears the default retention.
+func (c *s3Client) SetObjectLockConfig(mode string, validity uint64, unit string) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	config := objectLockConfiguration{ObjectLockEnabled: "Enabled"}
+	if mode != "" {
+		config.Rule = &objectLockRule{}
+		config.Rule.DefaultRetention.Mode = mode
+		if unit == validityYears {
+			config.Rule.DefaultRetention.Years = validity
+		} else {
+			config.Rule.DefaultRetention.Days = validity
+		}
+	}
+	data, e := xml.Marshal(config)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	_, e = c.executeDiscard(context.Background(), s3Request{
+		method:  http.MethodPut,
+		bucket:  bucket,
+		query:   url.Values{"object-lock": []string{""}},
+		content: data,
+	})
+	if e != nil {
+		return c.toLockError(e, bucket, "")
+	}
+	return nil
+}
+
+// GetObjectRetention - get retention mode and retain until date of
+// the object, an empty mode means no retention is set.
+func (c *s3Client) GetObjectRetention() (mode string, until time.Time, err *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if object == "" {
+		return "", time.Time{}, probe.NewError(ObjectNameEmpty{})
+	}
+	retention := objectRetention{}
+	e := c.executeXML(context.Background(), s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"retention": []string{""}},
+	}, &retention)
+	if e != nil {
+		if minio.ToErrorResponse(e).Code == "NoSuchObjectLockConfiguration" {
+			return "", time.Time{}, nil
+		}
+		return "", time.Time{}, c.toLockError(e, bucket, object)
+	}
+	if retention.RetainUntilDate != nil {
+		until = *retention.RetainUntilDate
+	}
+	return retention.Mode, until, nil
+}
+
+// SetObjectRetention - set retention of the object, an empty mode
+// clears the retention. Shortening or clearing a governance mode
+// retention requires bypassGovernance.
+func (c *s3Client) SetObjectRetention(mode string, until time.Time, bypassGovernance bool) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if object == "" {
+		return probe.NewError(ObjectNameEmpty{})
+	}
+	retention := objectRetention{Mode: mode}
+	if mode != "" {
+		until = until.UTC()
+		retention.RetainUntilDate = &until
+	}
+	data, e := xml.Marshal(retention)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	header := http.Header{}
+	if bypassGovernance {
+		header.Set("X-Amz-Bypass-Governance-Retention", "true")
+	}
+	_, e = c.executeDiscard(context.Background(), s3Request{
+		method:  http.MethodPut,
+		bucket:  bucket,
+		object:  object,
+		query:   url.Values{"retention": []string{""}},
+		header:  header,
+		content: data,
+	})
+	if e != nil {
+		return c.toLockError(e, bucket, object)
+	}
+	return nil
+}
+
+// GetObjectLegalHold - get legal hold status of the object.
+func (c *s3Client) GetObjectLegalHold() (string, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if object == "" {
+		return "", probe.NewError(ObjectNameEmpty{})
+	}
+	legalHold := objectLegalHold{}
+	e := c.executeXML(context.Background(), s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"legal-hold": []string{""}},
+	}, &legalHold)
+	if e != nil {
+		if minio.ToErrorResponse(e).Code == "NoSuchObjectLockConfiguration" {
+			return legalHoldOff, nil
+		}
+		return "", c.toLockError(e, bucket, object)
+	}
+	return legalHold.Status, nil
+}
+
+// SetObjectLegalHold - set legal hold status of the object.
+func (c *s3Client) SetObjectLegalHold(status string) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if object == "" {
+		return probe.NewError(ObjectNameEmpty{})
+	}
+	data, e := xml.Marshal(objectLegalHold{Status: status})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	_, e = c.executeDiscard(context.Background(), s3Request{
+		method:  http.MethodPut,
+		bucket:  bucket,
+		object:  object,
+		query:   url.Values{"legal-hold": []string{""}},
+		content: data,
+	})
+	if e != nil {
+		return c.toLockError(e, bucket, object)
+	}
+	return nil
+}
+
+// isObjectLockedError - returns true if the request was refused
+// because of a retention setting or a legal hold.
+func isObjectLockedError(e error) bool {
+	errResp := minio.ToErrorResponse(e)
+	if errResp.Code == "ObjectLocked" {
+		return true
+	}
+	if errResp.Code != "AccessDenied" {
+		return false
+	}
+	message := strings.ToLower(errResp.Message)
+	for _, reason := range []string{"worm", "object lock", "retention", "legal hold"} {
+		if strings.Contains(message, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// toRemoveError - convert object removal errors into typed errors,
+// so that objects protected by object lock can be told apart.
+func (c *s3Client) toRemoveError(bucket, object, versionID string, e error) *probe.Error {
+	if isObjectLockedError(e) {
+		return probe.NewError(ObjectLocked{Object: c.joinPath(bucket, object)})
+	}
+	if versionID != "" {
+		return c.toVersionError(e, bucket, versionID)
+	}
+	return probe.NewError(e)
+}
+
+// toLockError - convert object lock API errors into typed errors.
+func (c *s3Client) toLockError(e error, bucket, object string) *probe.Error {
+	if isObjectLockedError(e) {
+		return probe.NewError(ObjectLocked{Object: c.joinPath(bucket, object)})
+	}
+	errResp := minio.ToErrorResponse(e)
+	switch errResp.Code {
+	case "NotImplemented":
+		return probe.NewError(APINotImplemented{API: "ObjectLock", APIType: c.targetURL.Host})
+	case "ObjectLockConfigurationNotFoundError":
+		return probe.NewError(BucketLockDisabled{Bucket: bucket})
+	case "InvalidRequest":
+		if strings.Contains(strings.ToLower(errResp.Message), "object lock") {
+			return probe.NewError(BucketLockDisabled{Bucket: bucket})
+		}
+	}
+	return c.toVersionError(e, bucket, "")
+}
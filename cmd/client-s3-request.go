@@ -0,0 +1,161 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/s3signer"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+)
+
+// s3Request - describes a raw S3 API request, used for the S3
+// APIs which are not yet available in minio-go.
+type s3Request struct {
+	method  string
+	bucket  string
+	object  string
+	query   url.Values
+	header  http.Header
+	content []byte
+}
+
+// executeMethod - signs and sends a raw S3 API request. Responses
+// with a non 2xx status code are converted into minio.ErrorResponse,
+// so that callers can inspect them with minio.ToErrorResponse().
+func (c *s3Client) executeMethod(ctx context.Context, r s3Request) (*http.Response, error) {
+	// Let minio-go resolve the endpoint of the bucket, this takes
+	// care of virtual host style, accelerated and regional endpoints.
+	u, e := c.api.Presign(r.method, r.bucket, r.object, time.Minute, nil)
+	if e != nil {
+		return nil, e
+	}
+	targetURL := *u
+	targetURL.RawQuery = s3utils.QueryEncode(r.query)
+
+	req, e := http.NewRequest(r.method, targetURL.String(), bytes.NewReader(r.content))
+	if e != nil {
+		return nil, e
+	}
+	req = req.WithContext(ctx)
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	if len(r.content) > 0 {
+		md5Sum := md5.Sum(r.content)
+		req.Header.Set("Content-Md5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+	} else {
+		req.Body = nil
+		req.ContentLength = 0
+	}
+	shaSum := sha256.Sum256(r.content)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(shaSum[:]))
+
+	value, e := c.creds.Get()
+	if e != nil {
+		return nil, e
+	}
+	switch {
+	case value.SignerType.IsAnonymous():
+	case value.SignerType.IsV2():
+		req = s3signer.SignV2(*req, value.AccessKeyID, value.SecretAccessKey, c.virtualStyle)
+	default:
+		location := "us-east-1"
+		if r.bucket != "" {
+			if location, e = c.api.GetBucketLocation(r.bucket); e != nil {
+				return nil, e
+			}
+		}
+		req = s3signer.SignV4(*req, value.AccessKeyID, value.SecretAccessKey, value.SessionToken, location)
+	}
+
+	resp, e := c.httpClient.Do(req)
+	if e != nil {
+		return nil, e
+	}
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	return nil, s3ErrorResponse(resp, r.bucket, r.object)
+}
+
+// s3ErrorResponse - converts an unsuccessful HTTP response into minio.ErrorResponse.
+func s3ErrorResponse(resp *http.Response, bucket, object string) error {
+	errResp := minio.ErrorResponse{
+		StatusCode: resp.StatusCode,
+		BucketName: bucket,
+		Key:        object,
+	}
+	body, e := ioutil.ReadAll(resp.Body)
+	if e == nil && len(body) > 0 {
+		if xml.Unmarshal(body, &errResp) == nil {
+			errResp.StatusCode = resp.StatusCode
+			return errResp
+		}
+	}
+	// Responses to HEAD requests do not have a body.
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		if object == "" {
+			errResp.Code = "NoSuchBucket"
+		} else {
+			errResp.Code = "NoSuchKey"
+		}
+	case http.StatusForbidden:
+		errResp.Code = "AccessDenied"
+	case http.StatusMethodNotAllowed:
+		errResp.Code = "MethodNotAllowed"
+	default:
+		errResp.Code = resp.Status
+	}
+	errResp.Message = resp.Status
+	errResp.RequestID = resp.Header.Get("x-amz-request-id")
+	return errResp
+}
+
+// executeXML - sends a raw S3 API request and decodes the XML response into v.
+func (c *s3Client) executeXML(ctx context.Context, r s3Request, v interface{}) error {
+	resp, e := c.executeMethod(ctx, r)
+	if e != nil {
+		return e
+	}
+	defer resp.Body.Close()
+	return xml.NewDecoder(resp.Body).Decode(v)
+}
+
+// executeDiscard - sends a raw S3 API request whose response body is not needed.
+func (c *s3Client) executeDiscard(ctx context.Context, r s3Request) (http.Header, error) {
+	resp, e := c.executeMethod(ctx, r)
+	if e != nil {
+		return nil, e
+	}
+	defer resp.Body.Close()
+	ioutil.ReadAll(resp.Body)
+	return resp.Header, nil
+}
@@ -0,0 +1,205 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// Headers of object tagging, amzTagging sets the URL encoded tags of
+// an uploaded object, amzTaggingCount tells how many tags an object has.
+const (
+	amzTagging          = "X-Amz-Tagging"
+	amzTaggingDirective = "X-Amz-Tagging-Directive"
+	amzTaggingCount     = "X-Amz-Tagging-Count"
+)
+
+// objectTag - a single object tag.
+type objectTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// objectTagging - tag set of an object.
+type objectTagging struct {
+	XMLName xml.Name    `xml:"Tagging"`
+	TagSet  []objectTag `xml:"TagSet>Tag"`
+}
+
+// taggingQuery - query values of a tagging request, an empty
+// versionID refers to the latest version.
+func taggingQuery(versionID string) url.Values {
+	query := url.Values{"tagging": []string{""}}
+	if versionID != "" {
+		query.Set("versionId", versionID)
+	}
+	return query
+}
+
+// GetTags - get tags of the object, an empty versionID refers to the latest version.
+func (c *s3Client) GetTags(versionID string) (map[string]string, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return nil, probe.NewError(ObjectNameEmpty{})
+	}
+	resp, e := c.executeMethod(context.Background(), s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		object: object,
+		query:  taggingQuery(versionID),
+	})
+	if e != nil {
+		if minio.ToErrorResponse(e).Code == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
+		return nil, c.toTaggingError(e, bucket, versionID)
+	}
+	defer resp.Body.Close()
+	tagging := objectTagging{}
+	if e = xml.NewDecoder(resp.Body).Decode(&tagging); e != nil {
+		// Servers without tagging support ignore the tagging
+		// query and respond with the object itself.
+		return nil, probe.NewError(APINotImplemented{API: "Tagging", APIType: c.targetURL.Host})
+	}
+	tags := make(map[string]string, len(tagging.TagSet))
+	for _, tag := range tagging.TagSet {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+// SetTags - replace all tags of the object.
+func (c *s3Client) SetTags(tags map[string]string) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return probe.NewError(ObjectNameEmpty{})
+	}
+	tagging := objectTagging{}
+	for k, v := range tags {
+		tagging.TagSet = append(tagging.TagSet, objectTag{Key: k, Value: v})
+	}
+	sort.Slice(tagging.TagSet, func(i, j int) bool {
+		return tagging.TagSet[i].Key < tagging.TagSet[j].Key
+	})
+	data, e := xml.Marshal(tagging)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	_, e = c.executeDiscard(context.Background(), s3Request{
+		method:  http.MethodPut,
+		bucket:  bucket,
+		object:  object,
+		query:   taggingQuery(""),
+		content: data,
+	})
+	if e != nil {
+		return c.toTaggingError(e, bucket, "")
+	}
+	return nil
+}
+
+// DeleteTags - remove all tags of the object.
+func (c *s3Client) DeleteTags() *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return probe.NewError(ObjectNameEmpty{})
+	}
+	_, e := c.executeDiscard(context.Background(), s3Request{
+		method: http.MethodDelete,
+		bucket: bucket,
+		object: object,
+		query:  taggingQuery(""),
+	})
+	if e != nil {
+		return c.toTaggingError(e, bucket, "")
+	}
+	return nil
+}
+
+// toTaggingError - convert tagging API errors into typed errors.
+func (c *s3Client) toTaggingError(e error, bucket, versionID string) *probe.Error {
+	if minio.ToErrorResponse(e).Code == "NotImplemented" {
+		return probe.NewError(APINotImplemented{API: "Tagging", APIType: c.targetURL.Host})
+	}
+	return c.toVersionError(e, bucket, versionID)
+}
+
+// encodeTags - value of the tagging header of an upload.
+func encodeTags(tags map[string]string) string {
+	values := make(url.Values, len(tags))
+	for k, v := range tags {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
+
+// taggingSSE - adds the tagging headers to the requests of an upload
+// along with the headers of its encryption, minio-go has no upload
+// option for tags. ServerSide is nil for unencrypted uploads.
+type taggingSSE struct {
+	encrypt.ServerSide
+	tagging string
+	isCopy  bool
+}
+
+// Type - the encryption of the upload, if any.
+func (t taggingSSE) Type() encrypt.Type {
+	if t.ServerSide == nil {
+		return ""
+	}
+	return t.ServerSide.Type()
+}
+
+// Marshal - add the tagging and encryption headers.
+func (t taggingSSE) Marshal(h http.Header) {
+	if t.ServerSide != nil {
+		t.ServerSide.Marshal(h)
+	}
+	h.Set(amzTagging, t.tagging)
+	if t.isCopy {
+		// Tags of the source would be copied otherwise.
+		h.Set(amzTaggingDirective, "REPLACE")
+	}
+}
+
+// withTagging - moves the tagging header out of the metadata of an
+// upload into the headers sent with its encryption sse.
+func withTagging(metadata map[string]string, sse encrypt.ServerSide, isCopy bool) encrypt.ServerSide {
+	tagging, ok := metadata[amzTagging]
+	if !ok {
+		return sse
+	}
+	delete(metadata, amzTagging)
+	return taggingSSE{ServerSide: sse, tagging: tagging, isCopy: isCopy}
+}
@@ -0,0 +1,294 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// Bucket versioning states.
+const (
+	versioningEnabled   = "Enabled"
+	versioningSuspended = "Suspended"
+)
+
+// versioningConfiguration - bucket versioning configuration.
+type versioningConfiguration struct {
+	XMLName   xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ VersioningConfiguration"`
+	Status    string   `xml:"Status,omitempty"`
+	MFADelete string   `xml:"MfaDelete,omitempty"`
+}
+
+// listVersionsEntry - a version or a delete marker of an object.
+type listVersionsEntry struct {
+	XMLName      xml.Name
+	Key          string
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+	LastModified time.Time
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+// listVersionsResult - result of a ListObjectVersions call, versions
+// and delete markers are kept in a single list to preserve their order.
+type listVersionsResult struct {
+	Name                string
+	Prefix              string
+	IsTruncated         bool
+	NextKeyMarker       string
+	NextVersionIDMarker string `xml:"NextVersionIdMarker"`
+	CommonPrefixes      []struct {
+		Prefix string
+	}
+	Entries []listVersionsEntry `xml:",any"`
+}
+
+// GetVersioning - get bucket versioning status, an empty
+// status means versioning was never enabled on the bucket.
+func (c *s3Client) GetVersioning() (string, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", probe.NewError(BucketNameEmpty{})
+	}
+	config := versioningConfiguration{}
+	e := c.executeXML(context.Background(), s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		query:  url.Values{"versioning": []string{""}},
+	}, &config)
+	if e != nil {
+		return "", c.toVersionError(e, bucket, "")
+	}
+	return config.Status, nil
+}
+
+// SetVersioning - enable or suspend bucket versioning.
+func (c *s3Client) SetVersioning(status string) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	data, e := xml.Marshal(versioningConfiguration{Status: status})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	_, e = c.executeDiscard(context.Background(), s3Request{
+		method:  http.MethodPut,
+		bucket:  bucket,
+		query:   url.Values{"versioning": []string{""}},
+		content: data,
+	})
+	if e != nil {
+		return c.toVersionError(e, bucket, "")
+	}
+	return nil
+}
+
+// ListVersions - list all versions and delete markers of objects
+// at the current path, newest version of each object first.
+func (c *s3Client) ListVersions(isRecursive bool) <-chan *clientContent {
+	contentCh := make(chan *clientContent)
+	go func() {
+		defer close(contentCh)
+		bucket, object := c.url2BucketAndObject()
+		if bucket == "" {
+			contentCh <- &clientContent{Err: probe.NewError(BucketNameEmpty{})}
+			return
+		}
+		query := url.Values{}
+		query.Set("versions", "")
+		query.Set("prefix", object)
+		if !isRecursive {
+			query.Set("delimiter", string(c.targetURL.Separator))
+		}
+		for {
+			result := listVersionsResult{}
+			e := c.executeXML(context.Background(), s3Request{
+				method: http.MethodGet,
+				bucket: bucket,
+				query:  query,
+			}, &result)
+			if e != nil {
+				contentCh <- &clientContent{Err: c.toVersionError(e, bucket, "")}
+				return
+			}
+			for _, prefix := range result.CommonPrefixes {
+				url := *c.targetURL
+				url.Path = c.joinPath(bucket, prefix.Prefix)
+				contentCh <- &clientContent{URL: url, Time: time.Now(), Type: os.ModeDir}
+			}
+			for _, entry := range result.Entries {
+				switch entry.XMLName.Local {
+				case "Version", "DeleteMarker":
+					contentCh <- c.versionEntry2ClientContent(bucket, entry)
+				}
+			}
+			if !result.IsTruncated {
+				return
+			}
+			query.Set("key-marker", result.NextKeyMarker)
+			query.Set("version-id-marker", result.NextVersionIDMarker)
+		}
+	}()
+	return contentCh
+}
+
+// versionEntry2ClientContent - convert a listed version into clientContent.
+func (c *s3Client) versionEntry2ClientContent(bucket string, entry listVersionsEntry) *clientContent {
+	url := *c.targetURL
+	url.Path = c.joinPath(bucket, entry.Key)
+	content := &clientContent{
+		URL:            url,
+		Time:           entry.LastModified,
+		Size:           entry.Size,
+		ETag:           entry.ETag,
+		Type:           os.FileMode(0664),
+		VersionID:      entry.VersionID,
+		IsLatest:       entry.IsLatest,
+		IsDeleteMarker: entry.XMLName.Local == "DeleteMarker",
+	}
+	return content
+}
+
+// StatVersion - fetch metadata of a specific version of an object.
+func (c *s3Client) StatVersion(versionID string, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	if versionID == "" {
+		return c.Stat(false, true, sse)
+	}
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	header := http.Header{}
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		sse.Marshal(header)
+	}
+	respHeader, e := c.executeDiscard(context.Background(), s3Request{
+		method: http.MethodHead,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"versionId": []string{versionID}},
+		header: header,
+	})
+	if e != nil {
+		return nil, c.toVersionError(e, bucket, versionID)
+	}
+	return c.header2ClientContent(respHeader), nil
+}
+
+// header2ClientContent - convert the response headers of an object into clientContent.
+func (c *s3Client) header2ClientContent(header http.Header) *clientContent {
+	content := &clientContent{
+		URL:               *c.targetURL,
+		Type:              os.FileMode(0664),
+		ETag:              strings.Trim(header.Get("ETag"), "\""),
+		VersionID:         header.Get("X-Amz-Version-Id"),
+		IsDeleteMarker:    header.Get("X-Amz-Delete-Marker") == "true",
+		Metadata:          map[string]string{},
+		EncryptionHeaders: map[string]string{},
+	}
+	content.Size, _ = strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	content.Time, _ = time.Parse(http.TimeFormat, header.Get("Last-Modified"))
+	content.Expires, _ = time.Parse(http.TimeFormat, header.Get("Expires"))
+	for k, v := range header {
+		if len(v) == 0 {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(k), serverEncryptionKeyPrefix) {
+			content.EncryptionHeaders[k] = v[0]
+			continue
+		}
+		content.Metadata[k] = v[0]
+	}
+	return content
+}
+
+// GetVersion - get a specific version of an object.
+func (c *s3Client) GetVersion(versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	if versionID == "" {
+		return c.Get(sse)
+	}
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	header := http.Header{}
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		sse.Marshal(header)
+	}
+	resp, e := c.executeMethod(context.Background(), s3Request{
+		method: http.MethodGet,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"versionId": []string{versionID}},
+		header: header,
+	})
+	if e != nil {
+		return nil, c.toVersionError(e, bucket, versionID)
+	}
+	return resp.Body, nil
+}
+
+// removeVersion - permanently remove a specific version of an object.
+func (c *s3Client) removeVersion(bucket, object, versionID string) error {
+	_, e := c.executeDiscard(context.Background(), s3Request{
+		method: http.MethodDelete,
+		bucket: bucket,
+		object: object,
+		query:  url.Values{"versionId": []string{versionID}},
+	})
+	return e
+}
+
+// toVersionError - convert versioning API errors into typed errors.
+func (c *s3Client) toVersionError(e error, bucket, versionID string) *probe.Error {
+	switch minio.ToErrorResponse(e).Code {
+	case "AccessDenied":
+		return probe.NewError(PathInsufficientPermission{Path: c.targetURL.String()})
+	case "NoSuchBucket":
+		return probe.NewError(BucketDoesNotExist{Bucket: bucket})
+	case "InvalidBucketName":
+		return probe.NewError(BucketInvalid{Bucket: bucket})
+	case "NoSuchKey":
+		return probe.NewError(ObjectMissing{})
+	case "NoSuchVersion", "InvalidArgument":
+		if versionID != "" {
+			return probe.NewError(VersionMissing{VersionID: versionID})
+		}
+	case "MethodNotAllowed":
+		if versionID != "" {
+			return probe.NewError(VersionIsDeleteMarker{VersionID: versionID})
+		}
+	case "NotImplemented":
+		return probe.NewError(APINotImplemented{API: "Versioning", APIType: c.targetURL.Host})
+	}
+	return probe.NewError(e)
+}
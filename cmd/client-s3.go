@@ -50,6 +50,11 @@ type s3Client struct {
 	targetURL    *clientURL
 	api          *minio.Client
 	virtualStyle bool
+
+	// Credentials and transport used for the raw S3 API
+	// requests which are not covered by minio-go.
+	creds      *credentials.Credentials
+	httpClient *http.Client
 }
 
 const (
@@ -83,6 +88,7 @@ var cseHeaders = []string{
 // newFactory encloses New function with client cache.
 func newFactory() func(config *Config) (Client, *probe.Error) {
 	clientCache := make(map[uint32]*minio.Client)
+	transportCache := make(map[uint32]http.RoundTripper)
 	mutex := &sync.Mutex{}
 
 	// Return New function.
@@ -121,15 +127,16 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		// Lookup previous cache by hash.
 		mutex.Lock()
 		defer mutex.Unlock()
+		// if Signature version '4' use NewV4 directly.
+		creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
+		// if Signature version '2' use NewV2 directly.
+		if strings.ToUpper(config.Signature) == "S3V2" {
+			creds = credentials.NewStaticV2(config.AccessKey, config.SecretKey, "")
+		}
+
 		var api *minio.Client
 		var found bool
 		if api, found = clientCache[confSum]; !found {
-			// if Signature version '4' use NewV4 directly.
-			creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
-			// if Signature version '2' use NewV2 directly.
-			if strings.ToUpper(config.Signature) == "S3V2" {
-				creds = credentials.NewStaticV2(config.AccessKey, config.SecretKey, "")
-			}
 			// Not found. Instantiate a new MinIO
 			var e error
 
@@ -211,10 +218,13 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 			// Cache the new MinIO Client with hash of config as key.
 			clientCache[confSum] = api
+			transportCache[confSum] = transport
 		}
 
 		// Store the new api object.
 		s3Clnt.api = api
+		s3Clnt.creds = creds
+		s3Clnt.httpClient = &http.Client{Transport: transportCache[confSum]}
 
 		return s3Clnt, nil
 	}
@@ -726,9 +736,19 @@ func (c *s3Client) Copy(source string, size int64, progress io.Reader, srcSSE, t
 
 	tokens := splitStr(source, string(c.targetURL.Separator), 3)
 
+	// Only SSE-C keys are needed to read the source, SSE-S3 and
+	// SSE-KMS sources are decrypted by the server. Passing them
+	// along would encrypt the target with the source key.
+	if srcSSE != nil && srcSSE.Type() != encrypt.SSEC {
+		srcSSE = nil
+	}
+
 	// Source object
 	src := minio.NewSourceInfo(tokens[1], tokens[2], srcSSE)
 
+	// Tags passed in metadata replace the tags of the source.
+	tgtSSE = withTagging(metadata, tgtSSE, true)
+
 	// Destination object
 	dst, e := minio.NewDestinationInfo(dstBucket, dstObject, tgtSSE, metadata)
 	if e != nil {
@@ -795,6 +815,7 @@ func (c *s3Client) Put(ctx context.Context, reader io.Reader, size int64, metada
 	if ok {
 		delete(metadata, "X-Amz-Storage-Class")
 	}
+	sse = withTagging(metadata, sse, false)
 	if bucket == "" {
 		return 0, probe.NewError(BucketNameEmpty{})
 	}
@@ -907,7 +928,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 					close(objectsCh)
 				}
 				for removeStatus := range statusCh {
-					errorCh <- probe.NewError(removeStatus.Err)
+					errorCh <- c.toRemoveError(prevBucket, removeStatus.ObjectName, "", removeStatus.Err)
 				}
 				// Remove bucket if it qualifies.
 				if isRemoveBucket && !isIncomplete {
@@ -925,7 +946,13 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 				prevBucket = bucket
 			}
 
-			if objectName != "" {
+			if objectName != "" && content.VersionID != "" && !isIncomplete {
+				// Versions are removed one at a time, as minio-go
+				// multi-object delete is not version aware.
+				if e := c.removeVersion(bucket, objectName, content.VersionID); e != nil {
+					errorCh <- c.toRemoveError(bucket, objectName, content.VersionID, e)
+				}
+			} else if objectName != "" {
 				// Send object name once but continuously checks for pending
 				// errors in parallel, the reason is that minio-go RemoveObjects
 				// can block if there is any pending error not received yet.
@@ -935,7 +962,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 					case objectsCh <- objectName:
 						sent = true
 					case removeStatus := <-statusCh:
-						errorCh <- probe.NewError(removeStatus.Err)
+						errorCh <- c.toRemoveError(bucket, removeStatus.ObjectName, "", removeStatus.Err)
 					}
 				}
 			} else {
@@ -953,7 +980,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 		// Write remove objects status to errorCh
 		if statusCh != nil {
 			for removeStatus := range statusCh {
-				errorCh <- probe.NewError(removeStatus.Err)
+				errorCh <- c.toRemoveError(prevBucket, removeStatus.ObjectName, "", removeStatus.Err)
 			}
 		}
 		// Remove last bucket if it qualifies.
@@ -967,7 +994,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 }
 
 // MakeBucket - make a new bucket.
-func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
+func (c *s3Client) MakeBucket(region string, ignoreExisting, withLock bool) *probe.Error {
 	bucket, object := c.url2BucketAndObject()
 	if bucket == "" {
 		return probe.NewError(BucketNameEmpty{})
@@ -978,7 +1005,11 @@ func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
 			if _, e := c.api.PutObject(bucket, object, bytes.NewReader([]byte("")), 0, minio.PutObjectOptions{}); e != nil {
 				switch minio.ToErrorResponse(e).Code {
 				case "NoSuchBucket":
-					e = c.api.MakeBucket(bucket, region)
+					if withLock {
+						e = c.makeBucketWithLock(bucket, region)
+					} else {
+						e = c.api.MakeBucket(bucket, region)
+					}
 					if e != nil {
 						return probe.NewError(e)
 					}
@@ -991,7 +1022,12 @@ func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
 		return probe.NewError(BucketNameTopLevel{})
 	}
 
-	e := c.api.MakeBucket(bucket, region)
+	var e error
+	if withLock {
+		e = c.makeBucketWithLock(bucket, region)
+	} else {
+		e = c.api.MakeBucket(bucket, region)
+	}
 	if e != nil {
 		// Ignore bucket already existing error when ignoreExisting flag is enabled
 		if ignoreExisting {
@@ -20,10 +20,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"time"
 
 	minio "github.com/minio/minio-go/v6"
 	. "gopkg.in/check.v1"
@@ -103,6 +109,7 @@ func (h objectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Length", strconv.Itoa(len(h.data)))
 		w.Header().Set("Last-Modified", UTCNow().Format(http.TimeFormat))
 		w.Header().Set("ETag", "9af2f8218b150c351ad802c6f3d66abe")
+		w.Header().Set("X-Amz-Version-Id", "v1")
 		w.WriteHeader(http.StatusOK)
 	case r.Method == "POST":
 		// Handler for multipart upload request.
@@ -171,7 +178,7 @@ func (s *TestSuite) TestBucketOperations(c *C) {
 	s3c, err := s3New(conf)
 	c.Assert(err, IsNil)
 
-	err = s3c.MakeBucket("us-east-1", true)
+	err = s3c.MakeBucket("us-east-1", true, false)
 	c.Assert(err, IsNil)
 
 	conf.HostURL = server.URL + string(s3c.GetURL().Separator)
@@ -235,6 +242,12 @@ func (s *TestSuite) TestObjectOperations(c *C) {
 		c.Assert(err, IsNil)
 		c.Assert(buffer.Bytes(), DeepEquals, object.data)
 	}
+
+	// A version is reported only when it was asked for.
+	st, err := s3c.(*s3Client).getObjectStat("bucket", "object", minio.StatObjectOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(st.Size, Equals, int64(len(object.data)))
+	c.Assert(st.VersionID, Equals, "")
 }
 
 var testSelectCompressionTypeCases = []struct {
@@ -261,3 +274,771 @@ func (s *TestSuite) TestSelectCompressionType(c *C) {
 		c.Assert(cType, DeepEquals, test.compressionType)
 	}
 }
+
+// versionHandler is an http.Handler that serves bucket versioning and object version requests.
+type versionHandler struct {
+	versioning string
+	data       []byte
+}
+
+func (h *versionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch {
+	case r.Method == "GET" && query.Get("versionId") != "":
+		if query.Get("versionId") != "v1" || r.URL.Path != "/bucket/object" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchVersion</Code><Message>The specified version does not exist.</Message></Error>"))
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(h.data)))
+		w.Header().Set("X-Amz-Version-Id", "v1")
+		w.Write(h.data)
+	case r.Method == "HEAD" && query.Get("versionId") != "":
+		if query.Get("versionId") == "v2" {
+			w.Header().Set("X-Amz-Delete-Marker", "true")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(h.data)))
+		w.Header().Set("Last-Modified", UTCNow().Format(http.TimeFormat))
+		w.Header().Set("X-Amz-Version-Id", query.Get("versionId"))
+		w.WriteHeader(http.StatusOK)
+	case r.Method == "GET":
+		if _, ok := query["location"]; ok {
+			w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+			return
+		}
+		if _, ok := query["versioning"]; ok {
+			w.Write([]byte("<VersioningConfiguration xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Status>" + h.versioning + "</Status></VersioningConfiguration>"))
+			return
+		}
+		if _, ok := query["versions"]; ok {
+			w.Write([]byte("<ListVersionsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Name>bucket</Name><Prefix></Prefix><KeyMarker></KeyMarker><VersionIdMarker></VersionIdMarker><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated>" +
+				"<DeleteMarker><Key>object</Key><VersionId>v2</VersionId><IsLatest>true</IsLatest><LastModified>2019-05-21T18:24:21.097Z</LastModified></DeleteMarker>" +
+				"<Version><Key>object</Key><VersionId>v1</VersionId><IsLatest>false</IsLatest><LastModified>2019-05-20T18:24:21.097Z</LastModified><ETag>\"259d04a13802ae09c7e41be50ccc6baa\"</ETag><Size>12</Size><StorageClass>STANDARD</StorageClass></Version>" +
+				"</ListVersionsResult>"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == "PUT":
+		if _, ok := query["versioning"]; !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var config versioningConfiguration
+		if e := xml.NewDecoder(r.Body).Decode(&config); e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h.versioning = config.Status
+		w.WriteHeader(http.StatusOK)
+	case r.Method == "DELETE":
+		if query.Get("versionId") != "v2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Test versioning operations.
+func (s *TestSuite) TestVersionOperations(c *C) {
+	handler := &versionHandler{data: []byte("Hello, World")}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+	s3c := clnt.(*s3Client)
+
+	err = s3c.SetVersioning(versioningEnabled)
+	c.Assert(err, IsNil)
+	status, err := s3c.GetVersioning()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, versioningEnabled)
+
+	var contents []*clientContent
+	for content := range s3c.ListVersions(true) {
+		c.Assert(content.Err, IsNil)
+		contents = append(contents, content)
+	}
+	c.Assert(len(contents), Equals, 2)
+	c.Assert(contents[0].IsDeleteMarker, Equals, true)
+	c.Assert(contents[0].IsLatest, Equals, true)
+	c.Assert(contents[0].VersionID, Equals, "v2")
+	c.Assert(contents[1].IsDeleteMarker, Equals, false)
+	c.Assert(contents[1].VersionID, Equals, "v1")
+	c.Assert(contents[1].Size, Equals, int64(12))
+	c.Assert(contents[1].URL.Path, Equals, "/bucket/object")
+
+	conf.HostURL = server.URL + "/bucket/object"
+	clnt, err = s3New(conf)
+	c.Assert(err, IsNil)
+
+	reader, err := clnt.GetVersion("v1", nil)
+	c.Assert(err, IsNil)
+	var buffer bytes.Buffer
+	{
+		_, e := io.Copy(&buffer, reader)
+		c.Assert(e, IsNil)
+		c.Assert(buffer.Bytes(), DeepEquals, handler.data)
+	}
+	reader.Close()
+
+	_, err = clnt.GetVersion("v3", nil)
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(VersionMissing)
+	c.Assert(ok, Equals, true)
+
+	st, err := clnt.StatVersion("v1", nil)
+	c.Assert(err, IsNil)
+	c.Assert(st.VersionID, Equals, "v1")
+	c.Assert(st.Size, Equals, int64(len(handler.data)))
+
+	_, err = clnt.StatVersion("v2", nil)
+	c.Assert(err, NotNil)
+	_, ok = err.ToGoError().(VersionIsDeleteMarker)
+	c.Assert(ok, Equals, true)
+
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{URL: clnt.GetURL(), VersionID: "v2"}
+	close(contentCh)
+	for err = range clnt.Remove(false, false, contentCh) {
+		c.Assert(err, IsNil)
+	}
+}
+
+// Test listing and reading objects rewound to an earlier time.
+func (s *TestSuite) TestRewindOperations(c *C) {
+	handler := &versionHandler{data: []byte("Hello, World")}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	// v1 was the latest version of the object on 2019-05-21.
+	timeRef := time.Date(2019, 5, 21, 0, 0, 0, 0, time.UTC)
+	rClnt := newRewindClient(clnt, timeRef)
+	var contents []*clientContent
+	for content := range rClnt.List(true, false, DirNone) {
+		c.Assert(content.Err, IsNil)
+		contents = append(contents, content)
+	}
+	c.Assert(len(contents), Equals, 1)
+	c.Assert(contents[0].VersionID, Equals, "v1")
+
+	st, err := rClnt.Stat(false, false, nil)
+	c.Assert(err, IsNil)
+	c.Assert(st.VersionID, Equals, "v1")
+
+	reader, err := rClnt.Get(nil)
+	c.Assert(err, IsNil)
+	var buffer bytes.Buffer
+	{
+		_, e := io.Copy(&buffer, reader)
+		c.Assert(e, IsNil)
+		c.Assert(buffer.Bytes(), DeepEquals, handler.data)
+	}
+	reader.Close()
+
+	// The object was deleted on 2019-05-21 and did not exist before 2019-05-20.
+	for _, timeRef = range []time.Time{
+		time.Date(2019, 5, 22, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 5, 19, 0, 0, 0, 0, time.UTC),
+	} {
+		_, err = newRewindClient(clnt, timeRef).Stat(false, false, nil)
+		c.Assert(err, NotNil)
+		_, ok := err.ToGoError().(ObjectMissing)
+		c.Assert(ok, Equals, true)
+	}
+
+	// A zero time does not rewind.
+	c.Assert(newRewindClient(clnt, time.Time{}), Equals, clnt)
+}
+
+// tagHandler is an http.Handler that serves object tagging requests.
+type tagHandler struct {
+	tagging []byte
+	// tagging header of the last upload
+	header string
+}
+
+func (h *tagHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["location"]; ok {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	if _, ok := query["tagging"]; !ok && r.Method == "PUT" && r.URL.Path == "/bucket/object" {
+		h.header = r.Header.Get(amzTagging)
+		w.Header().Set("ETag", "\"bd01856bfd2065d0d1ee20c03bd3a9af\"")
+		return
+	}
+	if _, ok := query["tagging"]; !ok || r.URL.Path != "/bucket/object" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		w.Write([]byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"))
+		if h.tagging == nil {
+			w.Write([]byte("<Tagging><TagSet></TagSet></Tagging>"))
+			return
+		}
+		w.Write(h.tagging)
+	case "PUT":
+		var e error
+		if h.tagging, e = ioutil.ReadAll(r.Body); e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	case "DELETE":
+		h.tagging = nil
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Test object tagging operations.
+func (s *TestSuite) TestTagOperations(c *C) {
+	handler := &tagHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	tags, err := clnt.GetTags("")
+	c.Assert(err, IsNil)
+	c.Assert(len(tags), Equals, 0)
+
+	expected := map[string]string{"project": "mc", "cost-center": "eng"}
+	err = clnt.SetTags(expected)
+	c.Assert(err, IsNil)
+	c.Assert(string(handler.tagging), Equals, "<Tagging><TagSet><Tag><Key>cost-center</Key><Value>eng</Value></Tag><Tag><Key>project</Key><Value>mc</Value></Tag></TagSet></Tagging>")
+
+	tags, err = clnt.GetTags("")
+	c.Assert(err, IsNil)
+	c.Assert(tags, DeepEquals, expected)
+
+	err = clnt.DeleteTags()
+	c.Assert(err, IsNil)
+	tags, err = clnt.GetTags("")
+	c.Assert(err, IsNil)
+	c.Assert(len(tags), Equals, 0)
+
+	// Tags are sent along with an upload.
+	data := []byte("Hello, World")
+	metadata := map[string]string{amzTagging: encodeTags(expected)}
+	_, err = clnt.Put(context.Background(), bytes.NewReader(data), int64(len(data)), metadata, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(handler.header, Equals, "cost-center=eng&project=mc")
+
+	// Object name is required.
+	conf.HostURL = server.URL + "/bucket"
+	clnt, err = s3New(conf)
+	c.Assert(err, IsNil)
+	_, err = clnt.GetTags("")
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(ObjectNameEmpty)
+	c.Assert(ok, Equals, true)
+}
+
+// lockHandler is an http.Handler that serves object lock requests.
+type lockHandler struct {
+	lockConfig []byte
+	retention  []byte
+	legalHold  []byte
+}
+
+func (h *lockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var config *[]byte
+	switch {
+	case len(query["location"]) > 0:
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	case r.Method == "DELETE":
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Object is WORM protected and cannot be overwritten</Message></Error>"))
+		return
+	case r.Method == "PUT" && strings.TrimSuffix(r.URL.Path, "/") == "/lockedbucket" && r.URL.RawQuery == "":
+		if r.Header.Get("X-Amz-Bucket-Object-Lock-Enabled") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		return
+	case len(query["object-lock"]) > 0:
+		config = &h.lockConfig
+	case len(query["retention"]) > 0:
+		if r.Method == "PUT" && r.Header.Get("X-Amz-Bypass-Governance-Retention") != "true" && h.retention != nil {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Access Denied because object protected by object lock.</Message></Error>"))
+			return
+		}
+		config = &h.retention
+	case len(query["legal-hold"]) > 0:
+		config = &h.legalHold
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		if *config == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchObjectLockConfiguration</Code><Message>The specified object does not have a ObjectLock configuration</Message></Error>"))
+			return
+		}
+		w.Write(*config)
+	case "PUT":
+		data, e := ioutil.ReadAll(r.Body)
+		if e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*config = data
+	}
+}
+
+// Test object lock operations.
+func (s *TestSuite) TestLockOperations(c *C) {
+	handler := &lockHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/lockedbucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+	err = clnt.MakeBucket("us-east-1", false, true)
+	c.Assert(err, IsNil)
+
+	s3c := clnt.(*s3Client)
+	err = s3c.SetObjectLockConfig(retentionGovernance, 30, validityDays)
+	c.Assert(err, IsNil)
+	mode, validity, unit, err := s3c.GetObjectLockConfig()
+	c.Assert(err, IsNil)
+	c.Assert(mode, Equals, retentionGovernance)
+	c.Assert(validity, Equals, uint64(30))
+	c.Assert(unit, Equals, validityDays)
+
+	conf.HostURL = server.URL + "/lockedbucket/object"
+	clnt, err = s3New(conf)
+	c.Assert(err, IsNil)
+	s3c = clnt.(*s3Client)
+
+	mode, _, err = s3c.GetObjectRetention()
+	c.Assert(err, IsNil)
+	c.Assert(mode, Equals, "")
+
+	until := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = s3c.SetObjectRetention(retentionGovernance, until, false)
+	c.Assert(err, IsNil)
+	mode, retainUntil, err := s3c.GetObjectRetention()
+	c.Assert(err, IsNil)
+	c.Assert(mode, Equals, retentionGovernance)
+	c.Assert(retainUntil.Equal(until), Equals, true)
+
+	// Clearing a governance retention requires bypass.
+	err = s3c.SetObjectRetention("", time.Time{}, false)
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(ObjectLocked)
+	c.Assert(ok, Equals, true)
+	err = s3c.SetObjectRetention("", time.Time{}, true)
+	c.Assert(err, IsNil)
+
+	status, err := s3c.GetObjectLegalHold()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, legalHoldOff)
+	err = s3c.SetObjectLegalHold(legalHoldOn)
+	c.Assert(err, IsNil)
+	status, err = s3c.GetObjectLegalHold()
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, legalHoldOn)
+
+	// Removing a locked version reports a distinct error.
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{URL: clnt.GetURL(), VersionID: "v1"}
+	close(contentCh)
+	for err = range clnt.Remove(false, false, contentCh) {
+		c.Assert(err, NotNil)
+		_, ok = err.ToGoError().(ObjectLocked)
+		c.Assert(ok, Equals, true)
+	}
+}
+
+// lifecycleHandler is an http.Handler that serves bucket lifecycle requests.
+type lifecycleHandler struct {
+	config []byte
+}
+
+func (h *lifecycleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(r.URL.Query()["location"]) > 0 {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	if len(r.URL.Query()["lifecycle"]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		if h.config == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchLifecycleConfiguration</Code><Message>The lifecycle configuration does not exist</Message></Error>"))
+			return
+		}
+		w.Write(h.config)
+	case "PUT":
+		data, e := ioutil.ReadAll(r.Body)
+		if e != nil || r.Header.Get("Content-Md5") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h.config = data
+	case "DELETE":
+		h.config = nil
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Test bucket lifecycle operations.
+func (s *TestSuite) TestLifecycleOperations(c *C) {
+	handler := &lifecycleHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+	s3c := clnt.(*s3Client)
+
+	config, err := s3c.GetLifecycle()
+	c.Assert(err, IsNil)
+	c.Assert(len(config.Rules), Equals, 0)
+
+	rule := lifecycleRule{
+		ID:         "archive",
+		Status:     lifecycleEnabled,
+		Filter:     newLifecycleFilter("logs/", map[string]string{"tier": "cold"}),
+		Expiration: &lifecycleExpiration{Days: 365},
+		Transition: &lifecycleTransition{Days: 30, StorageClass: "GLACIER"},
+	}
+	c.Assert(validateLifecycleRule(rule), IsNil)
+	err = s3c.SetLifecycle(lifecycleConfiguration{Rules: []lifecycleRule{rule}})
+	c.Assert(err, IsNil)
+
+	config, err = s3c.GetLifecycle()
+	c.Assert(err, IsNil)
+	c.Assert(len(config.Rules), Equals, 1)
+	c.Assert(config.Rules[0].Filter.prefix(), Equals, "logs/")
+	c.Assert(config.Rules[0].Filter.tags(), DeepEquals, map[string]string{"tier": "cold"})
+	c.Assert(config.Rules[0].Expiration.Days, Equals, 365)
+	c.Assert(config.Rules[0].Transition.StorageClass, Equals, "GLACIER")
+
+	// Rules without a filter carry their prefix at the top level.
+	handler.config = []byte("<LifecycleConfiguration><Rule><ID>old</ID><Status>Enabled</Status><Prefix>tmp/</Prefix><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>")
+	config, err = s3c.GetLifecycle()
+	c.Assert(err, IsNil)
+	c.Assert(config.Rules[0].Filter.prefix(), Equals, "tmp/")
+
+	// An exported configuration can be imported as is.
+	exported, e := json.Marshal(config)
+	c.Assert(e, IsNil)
+	imported, err := readLifecycleConfig(bytes.NewReader(exported))
+	c.Assert(err, IsNil)
+	c.Assert(imported.Rules, DeepEquals, config.Rules)
+
+	_, err = readLifecycleConfig(strings.NewReader(`{"Rules": [{"ID": "a", "Status": "Enabled", "Expiration": {"Days": 1}}, {"ID": "a", "Status": "Enabled", "Expiration": {"Days": 2}}]}`))
+	c.Assert(err, NotNil)
+	_, err = readLifecycleConfig(strings.NewReader(`{"Rules": [{"ID": "a", "Status": "Enabled", "Expiration": {"Days": 10}, "Transition": {"Days": 20, "StorageClass": "GLACIER"}}]}`))
+	c.Assert(err, NotNil)
+
+	err = s3c.SetLifecycle(lifecycleConfiguration{})
+	c.Assert(err, IsNil)
+	c.Assert(handler.config, IsNil)
+}
+
+// encryptionHandler is an http.Handler that serves bucket encryption requests.
+type encryptionHandler struct {
+	config []byte
+}
+
+func (h *encryptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(r.URL.Query()["location"]) > 0 {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	if len(r.URL.Query()["encryption"]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		if h.config == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>ServerSideEncryptionConfigurationNotFoundError</Code><Message>The server side encryption configuration was not found</Message></Error>"))
+			return
+		}
+		w.Write(h.config)
+	case "PUT":
+		data, e := ioutil.ReadAll(r.Body)
+		if e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h.config = data
+	case "DELETE":
+		h.config = nil
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Test default bucket encryption operations.
+func (s *TestSuite) TestBucketEncryptionOperations(c *C) {
+	handler := &encryptionHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+	s3c := clnt.(*s3Client)
+
+	algorithm, _, err := s3c.GetBucketEncryption()
+	c.Assert(err, IsNil)
+	c.Assert(algorithm, Equals, "")
+
+	err = s3c.SetBucketEncryption(sseAlgorithmKMS, "my-key")
+	c.Assert(err, IsNil)
+	algorithm, keyID, err := s3c.GetBucketEncryption()
+	c.Assert(err, IsNil)
+	c.Assert(algorithm, Equals, sseAlgorithmKMS)
+	c.Assert(keyID, Equals, "my-key")
+	c.Assert(sseAlgorithmString(algorithm, keyID), Equals, "SSE-KMS (my-key)")
+
+	// The key ID only applies to SSE-KMS.
+	err = s3c.SetBucketEncryption(sseAlgorithmAES256, "my-key")
+	c.Assert(err, IsNil)
+	algorithm, keyID, err = s3c.GetBucketEncryption()
+	c.Assert(err, IsNil)
+	c.Assert(algorithm, Equals, sseAlgorithmAES256)
+	c.Assert(keyID, Equals, "")
+
+	err = s3c.SetBucketEncryption("", "")
+	c.Assert(err, IsNil)
+	c.Assert(handler.config, IsNil)
+}
+
+// rotateHandler is an http.Handler that serves a SSE-C encrypted object
+// and accepts copies of it onto itself with another key.
+type rotateHandler struct {
+	key     string
+	rotated bool
+}
+
+func (h *rotateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(r.URL.Query()["location"]) > 0 {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	switch {
+	case r.Method == "HEAD" && r.URL.Path == "/bucket/object":
+		if r.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key") != h.key {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Length", "5")
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("X-Amz-Server-Side-Encryption-Customer-Algorithm", "AES256")
+	case r.Method == "PUT" && r.URL.Path == "/bucket/object" && r.Header.Get("X-Amz-Copy-Source") != "":
+		if r.Header.Get("X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key") != h.key ||
+			r.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h.key = r.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key")
+		h.rotated = true
+		w.Write([]byte("<CopyObjectResult><ETag>\"etag2\"</ETag><LastModified>2019-05-21T18:24:21.000Z</LastModified></CopyObjectResult>"))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Test SSE-C key rotation with a server side copy.
+func (s *TestSuite) TestEncryptRotate(c *C) {
+	oldKey := "32byteslongsecretkeymustbegiven1"
+	newKey := "32byteslongsecretkeymustbegiven2"
+	handler := &rotateHandler{key: base64.StdEncoding.EncodeToString([]byte(oldKey))}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	_, err = parseSSECKey("shortkey")
+	c.Assert(err, NotNil)
+	oldSSE, err := parseSSECKey(oldKey)
+	c.Assert(err, IsNil)
+	newSSE, err := parseSSECKey(newKey)
+	c.Assert(err, IsNil)
+
+	// The new key cannot decrypt the object yet.
+	err = clnt.Copy("/bucket/object", 5, nil, newSSE, oldSSE, nil)
+	c.Assert(err, NotNil)
+	c.Assert(handler.rotated, Equals, false)
+
+	err = clnt.Copy("/bucket/object", 5, nil, oldSSE, newSSE, nil)
+	c.Assert(err, IsNil)
+	c.Assert(handler.rotated, Equals, true)
+	c.Assert(handler.key, Equals, base64.StdEncoding.EncodeToString([]byte(newKey)))
+}
+
+// cseHandler is an http.Handler that stores a single object along
+// with its user metadata.
+type cseHandler struct {
+	data     []byte
+	metadata http.Header
+}
+
+func (h *cseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(r.URL.Query()["location"]) > 0 {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	switch {
+	case r.Method == "PUT" && r.URL.Path == "/bucket/object":
+		data, e := ioutil.ReadAll(r.Body)
+		if e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h.data = data
+		h.metadata = http.Header{}
+		for k, v := range r.Header {
+			if strings.HasPrefix(k, "X-Amz-Meta-") {
+				h.metadata[k] = v
+			}
+		}
+		w.Header().Set("ETag", "\"etag\"")
+	case r.Method == "GET" && r.URL.Path == "/bucket/":
+		w.Write([]byte("<ListBucketResult><Name>bucket</Name></ListBucketResult>"))
+	case (r.Method == "HEAD" || r.Method == "GET") && r.URL.Path == "/bucket/object":
+		if h.data == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for k, v := range h.metadata {
+			w.Header()[k] = v
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(h.data)))
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == "GET" {
+			w.Write(h.data)
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Test client-side encryption of objects.
+func (s *TestSuite) TestClientSideEncryption(c *C) {
+	handler := &cseHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v2"
+	s3c, err := s3New(conf)
+	c.Assert(err, IsNil)
+
+	key := cseKey("32byteslongsecretkeymustbegiven1")
+	clnt := newCSEClient(s3c, key)
+	data := []byte("Hello, World")
+	n, err := clnt.Put(context.Background(), bytes.NewReader(data), int64(len(data)), map[string]string{
+		"Content-Type": "text/plain",
+	}, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, int64(len(data)))
+	c.Assert(bytes.Contains(handler.data, data), Equals, false)
+	c.Assert(handler.metadata.Get(cseAlgorithmMeta), Equals, cseAlgorithm)
+	c.Assert(handler.metadata.Get(cseKeyIDMeta), Equals, key.id())
+
+	content, err := clnt.Stat(false, true, nil)
+	c.Assert(err, IsNil)
+	c.Assert(content.Size, Equals, int64(len(data)))
+
+	reader, err := clnt.Get(nil)
+	c.Assert(err, IsNil)
+	plaintext, e := ioutil.ReadAll(reader)
+	c.Assert(e, IsNil)
+	c.Assert(plaintext, DeepEquals, data)
+
+	// Objects sealed with another key are refused.
+	_, err = newCSEClient(s3c, cseKey("32byteslongsecretkeymustbegiven2")).Get(nil)
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(ClientEncryptionKeyMismatch)
+	c.Assert(ok, Equals, true)
+
+	// Tampered objects fail authentication.
+	handler.data[len(handler.data)-1] ^= 0xff
+	reader, err = clnt.Get(nil)
+	c.Assert(err, IsNil)
+	_, e = ioutil.ReadAll(reader)
+	c.Assert(e, NotNil)
+
+	// Objects without envelope are read as they are stored.
+	handler.data = data
+	handler.metadata = http.Header{}
+	reader, err = clnt.Get(nil)
+	c.Assert(err, IsNil)
+	plaintext, e = ioutil.ReadAll(reader)
+	c.Assert(e, IsNil)
+	c.Assert(plaintext, DeepEquals, data)
+
+	// Only objects under object storage targets are encrypted.
+	globalCSEKey, globalCSETargets = key, []string{server.URL + "/bucket/backup"}
+	defer func() { globalCSEKey, globalCSETargets = nil, nil }()
+	c.Assert(isCSEURL(server.URL+"/bucket/backup"), Equals, true)
+	c.Assert(isCSEURL(server.URL+"/bucket/backup/object"), Equals, true)
+	c.Assert(isCSEURL(server.URL+"/bucket/backups/object"), Equals, false)
+	c.Assert(isCSEURL(server.URL+"/bucket/object"), Equals, false)
+	globalCSETargets = nil
+	c.Assert(isCSEURL(server.URL+"/bucket/object"), Equals, true)
+}
@@ -0,0 +1,126 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"time"
+)
+
+// Number of listed entries buffered for every consumer of a tee, which
+// lets consumers working at different speeds drift apart.
+const teeBufferSize = 10000
+
+// listingTee - fans a single listing out to several consumers. A
+// consumer which does not take an entry within stallTimeout is left
+// behind, it receives an error in place of the rest of the listing,
+// so that it cannot hold up the other consumers indefinitely.
+type listingTee struct {
+	outputs      []chan *clientContent
+	doneChs      []chan struct{}
+	stallTimeout time.Duration
+}
+
+// newListingTee - starts to fan contentCh out to n consumers.
+func newListingTee(contentCh <-chan *clientContent, n int, stallTimeout time.Duration) *listingTee {
+	t := &listingTee{
+		outputs:      make([]chan *clientContent, n),
+		doneChs:      make([]chan struct{}, n),
+		stallTimeout: stallTimeout,
+	}
+	for i := 0; i < n; i++ {
+		t.outputs[i] = make(chan *clientContent, teeBufferSize)
+		t.doneChs[i] = make(chan struct{})
+	}
+	go t.run(contentCh)
+	return t
+}
+
+// output - the listing as seen by the i-th consumer.
+func (t *listingTee) output(i int) <-chan *clientContent {
+	return t.outputs[i]
+}
+
+// release - the i-th consumer is not interested in the rest of the
+// listing, it has to be called once the consumer is done.
+func (t *listingTee) release(i int) {
+	close(t.doneChs[i])
+}
+
+func (t *listingTee) run(contentCh <-chan *clientContent) {
+	detached := make([]bool, len(t.outputs))
+	defer func() {
+		for i, out := range t.outputs {
+			if !detached[i] {
+				close(out)
+			}
+		}
+	}()
+
+	for content := range contentCh {
+		// A single entry waits at most stallTimeout for all
+		// consumers together.
+		ctx, cancel := context.WithTimeout(context.Background(), t.stallTimeout)
+		for i, out := range t.outputs {
+			if detached[i] {
+				continue
+			}
+			select {
+			case out <- content:
+			case <-t.doneChs[i]:
+				detached[i] = true
+				close(out)
+			case <-ctx.Done():
+				detached[i] = true
+				go t.leaveBehind(i)
+			}
+		}
+		cancel()
+	}
+}
+
+// leaveBehind - end the listing of a stalled consumer with an error,
+// the consumer must not mistake the truncated listing for a complete one.
+func (t *listingTee) leaveBehind(i int) {
+	defer close(t.outputs[i])
+	select {
+	case t.outputs[i] <- &clientContent{Err: errTargetStalled(t.stallTimeout)}:
+	case <-t.doneChs[i]:
+	}
+}
+
+// teeClient - a client whose listing is served by a listingTee, so
+// that a listing can be shared by several consumers.
+type teeClient struct {
+	Client
+	contentCh <-chan *clientContent
+}
+
+// newTeeClients - wraps clnt into n clients sharing a single listing,
+// the arguments of the listing must match the ones of the consumers.
+func newTeeClients(clnt Client, n int, isRecursive, isIncomplete bool, showDir DirOpt, stallTimeout time.Duration) ([]Client, *listingTee) {
+	tee := newListingTee(clnt.List(isRecursive, isIncomplete, showDir), n, stallTimeout)
+	clnts := make([]Client, n)
+	for i := range clnts {
+		clnts[i] = &teeClient{Client: clnt, contentCh: tee.output(i)}
+	}
+	return clnts, tee
+}
+
+// List - serve the shared listing, it can only be consumed once.
+func (t *teeClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	return t.contentCh
+}
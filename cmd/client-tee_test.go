@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListingTee(t *testing.T) {
+	const entries = teeBufferSize + 10
+	contentCh := make(chan *clientContent)
+	go func() {
+		defer close(contentCh)
+		for i := 0; i < entries; i++ {
+			contentCh <- &clientContent{Size: int64(i)}
+		}
+	}()
+
+	// The first consumer reads everything, the second one stalls
+	// and the third one gives up right away.
+	tee := newListingTee(contentCh, 3, 100*time.Millisecond)
+	tee.release(2)
+
+	var received int
+	for content := range tee.output(0) {
+		if content.Err != nil {
+			t.Fatalf("Unexpected error: %s", content.Err)
+		}
+		if content.Size != int64(received) {
+			t.Fatalf("Expected entry %d, got %d", received, content.Size)
+		}
+		received++
+	}
+	if received != entries {
+		t.Fatalf("Expected %d entries, got %d", entries, received)
+	}
+
+	received = 0
+	var stalled bool
+	for content := range tee.output(1) {
+		if content.Err != nil {
+			if content.Err.ToGoError().Error() != errTargetStalled(100*time.Millisecond).ToGoError().Error() {
+				t.Fatalf("Unexpected error: %s", content.Err)
+			}
+			stalled = true
+			continue
+		}
+		if stalled {
+			t.Fatalf("Unexpected entry %d after the error", content.Size)
+		}
+		received++
+	}
+	if !stalled || received != teeBufferSize {
+		t.Fatalf("Expected %d entries followed by an error, got %d entries, error: %t", teeBufferSize, received, stalled)
+	}
+}
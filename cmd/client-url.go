@@ -22,6 +22,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/mimedb"
@@ -186,6 +187,32 @@ func url2Stat(urlStr string, isFetchMeta bool, encKeyDB map[string][]prefixSSEPa
 	return client, content, nil
 }
 
+// url2StatVersion - returns client and metadata of a specific object
+// version. An empty versionID refers to the latest version, or to the
+// version which was the latest at timeRef when timeRef is set.
+func url2StatVersion(urlStr, versionID string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) (client Client, content *clientContent, err *probe.Error) {
+	if versionID == "" && timeRef.IsZero() {
+		return url2Stat(urlStr, false, encKeyDB)
+	}
+	client, err = newClient(urlStr)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
+	}
+	client = newRewindClient(client, timeRef)
+	alias, _ := url2Alias(urlStr)
+	sse := getSSE(urlStr, encKeyDB[alias])
+
+	if versionID == "" {
+		content, err = client.Stat(false, false, sse)
+	} else {
+		content, err = client.StatVersion(versionID, sse)
+	}
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
+	}
+	return client, content, nil
+}
+
 // url2Alias separates alias and path from the URL. Aliased URL is of
 // the form alias/path/to/blah.
 func url2Alias(aliasedURL string) (alias, path string) {
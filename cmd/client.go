@@ -49,7 +49,7 @@ type Client interface {
 	List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent
 
 	// Bucket operations
-	MakeBucket(region string, ignoreExisting bool) *probe.Error
+	MakeBucket(region string, ignoreExisting, withLock bool) *probe.Error
 
 	// Access policy operations.
 	GetAccess() (access string, policyJSON string, error *probe.Error)
@@ -73,7 +73,17 @@ type Client interface {
 	// Watch events
 	Watch(params watchParams) (*watchObject, *probe.Error)
 
-	// Delete operations
+	// Versioning operations, an empty versionID refers to the latest version.
+	ListVersions(isRecursive bool) <-chan *clientContent
+	StatVersion(versionID string, sse encrypt.ServerSide) (content *clientContent, err *probe.Error)
+	GetVersion(versionID string, sse encrypt.ServerSide) (reader io.ReadCloser, err *probe.Error)
+
+	// Tagging operations, an empty versionID refers to the latest version.
+	GetTags(versionID string) (tags map[string]string, err *probe.Error)
+	SetTags(tags map[string]string) *probe.Error
+	DeleteTags() *probe.Error
+
+	// Delete operations, a content with VersionID set removes only that version.
 	Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *clientContent) (errorCh <-chan *probe.Error)
 
 	// GetURL returns back internal url
@@ -91,6 +101,10 @@ type clientContent struct {
 	ETag              string
 	Expires           time.Time
 	EncryptionHeaders map[string]string
+	VersionID         string
+	IsLatest          bool
+	IsDeleteMarker    bool
+	Tags              map[string]string
 	Err               *probe.Error
 }
 
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -28,6 +29,7 @@ import (
 	"gopkg.in/h2non/filetype.v1"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 )
@@ -47,9 +49,14 @@ func getEncKeys(ctx *cli.Context) (map[string][]prefixSSEPair, *probe.Error) {
 		sseKeys = keyPrefix
 	}
 
-	encKeyDB, err := parseAndValidateEncryptionKeys(sseKeys, sseServer)
+	sseKMS := os.Getenv("MC_ENCRYPT_KMS")
+	if kmsPrefix := ctx.String("encrypt-kms"); kmsPrefix != "" {
+		sseKMS = kmsPrefix
+	}
+
+	encKeyDB, err := parseAndValidateEncryptionKeys(sseKeys, sseKMS, sseServer)
 	if err != nil {
-		return nil, err.Trace(sseKeys)
+		return nil, err.Trace(sseKeys, sseKMS)
 	}
 
 	return encKeyDB, nil
@@ -94,41 +101,40 @@ func isAliasURLDir(aliasURL string, keys map[string][]prefixSSEPair) bool {
 	return strings.HasSuffix(pathURL, "/")
 }
 
-// getSourceStreamMetadataFromURL gets a reader from URL.
-func getSourceStreamMetadataFromURL(urlStr string, encKeyDB map[string][]prefixSSEPair) (reader io.ReadCloser,
+// getSourceStreamMetadataFromURL gets a reader from URL, an empty
+// versionID refers to the latest version. Objects compressed by mc
+// are decompressed.
+func getSourceStreamMetadataFromURL(urlStr, versionID string, encKeyDB map[string][]prefixSSEPair) (reader io.ReadCloser,
 	metadata map[string]string, err *probe.Error) {
 	alias, urlStrFull, _, err := expandAlias(urlStr)
 	if err != nil {
 		return nil, nil, err.Trace(urlStr)
 	}
 	sseKey := getSSE(urlStr, encKeyDB[alias])
-	return getSourceStream(alias, urlStrFull, true, sseKey)
-}
-
-// getSourceStreamFromURL gets a reader from URL.
-func getSourceStreamFromURL(urlStr string, encKeyDB map[string][]prefixSSEPair) (reader io.ReadCloser, err *probe.Error) {
-	alias, urlStrFull, _, err := expandAlias(urlStr)
+	reader, metadata, err = getSourceStream(alias, urlStrFull, versionID, true, sseKey)
 	if err != nil {
-		return nil, err.Trace(urlStr)
+		return nil, nil, err.Trace(urlStr)
+	}
+	reader, err = decompressReader(reader, metadata)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
 	}
-	sse := getSSE(urlStr, encKeyDB[alias])
-	reader, _, err = getSourceStream(alias, urlStrFull, false, sse)
-	return reader, err
+	return reader, metadata, nil
 }
 
 // getSourceStream gets a reader from URL.
-func getSourceStream(alias string, urlStr string, fetchStat bool, sse encrypt.ServerSide) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
+func getSourceStream(alias string, urlStr string, versionID string, fetchStat bool, sse encrypt.ServerSide) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
 	sourceClnt, err := newClientFromAlias(alias, urlStr)
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
-	reader, err = sourceClnt.Get(sse)
+	reader, err = sourceClnt.GetVersion(versionID, sse)
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
 	metadata = make(map[string]string)
 	if fetchStat {
-		st, err := sourceClnt.Stat(false, true, sse)
+		st, err := sourceClnt.StatVersion(versionID, sse)
 		if err != nil {
 			return nil, nil, err.Trace(alias, urlStr)
 		}
@@ -164,6 +170,12 @@ func getSourceStream(alias string, urlStr string, fetchStat bool, sse encrypt.Se
 			}
 		}
 	}
+	if sourceClnt.GetURL().Type == objectStorage && globalDownloadLimiter != nil {
+		reader = struct {
+			io.Reader
+			io.Closer
+		}{limitReader(reader, globalDownloadLimiter), reader}
+	}
 	return reader, metadata, nil
 }
 
@@ -173,6 +185,9 @@ func putTargetStream(ctx context.Context, alias string, urlStr string, reader io
 	if err != nil {
 		return 0, err.Trace(alias, urlStr)
 	}
+	if targetClnt.GetURL().Type == objectStorage {
+		reader = limitReader(reader, globalUploadLimiter)
+	}
 	n, err := targetClnt.Put(ctx, reader, size, metadata, progress, sse)
 	if err != nil {
 		return n, err.Trace(alias, urlStr)
@@ -181,8 +196,9 @@ func putTargetStream(ctx context.Context, alias string, urlStr string, reader io
 }
 
 // putTargetStreamWithURL writes to URL from reader. If length=-1, read until EOF.
-func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse encrypt.ServerSide) (int64, *probe.Error) {
-	alias, urlStrFull, _, err := expandAlias(urlStr)
+// Objects are compressed with compression, unless it is empty.
+func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, compression string, sse encrypt.ServerSide) (int64, *probe.Error) {
+	alias, urlStrFull, hostCfg, err := expandAlias(urlStr)
 	if err != nil {
 		return 0, err.Trace(alias, urlStr)
 	}
@@ -190,6 +206,13 @@ func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse enc
 	metadata := map[string]string{
 		"Content-Type": contentType,
 	}
+	if compression != "" && hostCfg != nil {
+		compressedReader := compressReader(reader, compression)
+		defer compressedReader.Close()
+		reader = compressedReader
+		setCompressionMetadata(metadata, compression, size)
+		size = -1
+	}
 	return putTargetStream(context.Background(), alias, urlStrFull, reader, size, metadata, nil, sse)
 }
 
@@ -228,13 +251,25 @@ func createUserMetadata(sourceAlias, sourceURLStr string, srcSSE encrypt.ServerS
 	for k, v := range urls.TargetContent.UserMetadata {
 		metadata[k] = v
 	}
+
+	// The server copies the tags of the source, unless tags are
+	// passed in, which are merged with them.
+	if len(urls.TargetContent.Tags) > 0 {
+		tagging, err := targetTagging(sourceAlias, urls.SourceContent.URL, urls.SourceContent.VersionID, st.Metadata, urls.TargetContent.Tags)
+		if err != nil {
+			return nil, err.Trace(sourceAlias, sourceURLStr)
+		}
+		metadata[amzTagging] = tagging
+	}
 	return metadata, nil
 }
 
 // uploadSourceToTargetURL - uploads to targetURL from source.
 // optionally optimizes copy for object sizes <= 5GiB by using
-// server side copy operation.
-func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader, encKeyDB map[string][]prefixSSEPair) URLs {
+// server side copy operation. With isVerify, streamed uploads are
+// verified and repeated while the target does not match the data
+// uploaded to it.
+func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader, encKeyDB map[string][]prefixSSEPair, isVerify bool) URLs {
 	sourceAlias := urls.SourceAlias
 	sourceURL := urls.SourceContent.URL
 	targetAlias := urls.TargetAlias
@@ -247,49 +282,160 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 	srcSSE := getSSE(sourcePath, encKeyDB[sourceAlias])
 	tgtSSE := getSSE(targetPath, encKeyDB[targetAlias])
 
-	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias {
+	// Objects are compressed on their way to object storage only.
+	compression := urls.TargetContent.Metadata[compressionMeta]
+	if targetURL.Type != objectStorage {
+		compression = ""
+	}
+
+	// Optimize for server side copy if the host is same, server side
+	// copy of older versions is not supported yet, stream them instead.
+	// Objects encrypted or decrypted on the client side and compressed
+	// objects have to pass through the client.
+	isServerSideCopy := sourceAlias == targetAlias && urls.SourceContent.VersionID == "" &&
+		!isCSEURL(sourceURL.String()) && !isCSEURL(targetURL.String()) && compression == ""
+	if isServerSideCopy {
 
 		metadata, err := createUserMetadata(sourceAlias, sourceURL.String(), srcSSE, urls)
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
 
+		// Data of a server side copy does not pass through the
+		// client, there is nothing to verify.
 		sourcePath := filepath.ToSlash(sourceURL.Path)
 		err = copySourceToTargetURL(targetAlias, targetURL.String(), sourcePath, length, progress, srcSSE, tgtSSE, metadata)
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
 	} else {
+		err := streamSourceToTargetURL(ctx, urls, progress, compression, srcSSE, tgtSSE, isVerify)
+		if err != nil {
+			return urls.WithError(err)
+		}
+	}
+	return urls.WithError(nil)
+}
 
-		// Proceed with regular stream copy.
-		reader, metadata, err := getSourceStream(sourceAlias, sourceURL.String(), true, srcSSE)
+// streamSourceToTargetURL - streams the source through the client to
+// the target, with isVerify the target is verified to match the data
+// uploaded to it.
+func streamSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader, compression string, srcSSE, tgtSSE encrypt.ServerSide, isVerify bool) *probe.Error {
+	sourceAlias := urls.SourceAlias
+	sourceURL := urls.SourceContent.URL
+	targetAlias := urls.TargetAlias
+	targetURL := urls.TargetContent.URL
+	length := urls.SourceContent.Size
+
+	reader, metadata, err := getSourceStream(sourceAlias, sourceURL.String(), urls.SourceContent.VersionID, true, srcSSE)
+	if err != nil {
+		return err.Trace(sourceURL.String())
+	}
+	defer reader.Close()
+
+	// Objects compressed by mc are stored decompressed on
+	// filesystems, and copied as they are to object storage.
+	compressed := isCompressed(metadata)
+	if compressed && targetURL.Type != objectStorage {
+		length = uncompressedSize(metadata, length)
+		reader, err = decompressReader(ioutil.NopCloser(hookreader.NewHook(reader, progress)), metadata)
 		if err != nil {
-			return urls.WithError(err.Trace(sourceURL.String()))
+			return err.Trace(sourceURL.String())
 		}
+		progress = nil
+	}
+	if compression != "" && !compressed {
+		reader = compressReader(hookreader.NewHook(reader, progress), compression)
 		defer reader.Close()
-		// Get metadata from target content as well
-		if urls.TargetContent.Metadata != nil {
-			for k, v := range urls.TargetContent.Metadata {
-				metadata[k] = v
+		setCompressionMetadata(metadata, compression, length)
+		length = -1
+		progress = nil
+	}
+
+	// Get metadata from target content as well
+	if urls.TargetContent.Metadata != nil {
+		for k, v := range urls.TargetContent.Metadata {
+			if k == compressionMeta {
+				continue
 			}
+			metadata[k] = v
 		}
-		// Get userMetadata from target content as well
-		if urls.TargetContent.UserMetadata != nil {
-			for k, v := range urls.TargetContent.UserMetadata {
-				metadata[k] = v
-			}
+	}
+	// Get userMetadata from target content as well
+	if urls.TargetContent.UserMetadata != nil {
+		for k, v := range urls.TargetContent.UserMetadata {
+			metadata[k] = v
 		}
-		if srcSSE != nil {
-			delete(metadata, "X-Amz-Server-Side-Encryption-Customer-Algorithm")
-			delete(metadata, "X-Amz-Server-Side-Encryption-Customer-Key-Md5")
+	}
+	// Tags are set on upload, along with the object.
+	if targetURL.Type == objectStorage {
+		tagging, err := targetTagging(sourceAlias, sourceURL, urls.SourceContent.VersionID, metadata, urls.TargetContent.Tags)
+		if err != nil {
+			return err.Trace(sourceURL.String())
+		}
+		if tagging != "" {
+			metadata[amzTagging] = tagging
+		}
+	}
+	delete(metadata, amzTaggingCount)
+
+	// Encryption of the target only depends on tgtSSE, SSE-C
+	// and SSE-KMS headers of the source must not leak into it.
+	for k := range metadata {
+		if strings.HasPrefix(strings.ToLower(k), serverEncryptionKeyPrefix) {
+			delete(metadata, k)
 		}
-		_, err = putTargetStream(ctx, targetAlias, targetURL.String(), reader, length, metadata, progress, tgtSSE)
+	}
+
+	var body io.Reader = reader
+	var digest *uploadDigest
+	if isVerify {
+		digest = newUploadDigest(length)
+		body = hookreader.NewHook(reader, digest)
+	}
+	_, err = putTargetStream(ctx, targetAlias, targetURL.String(), body, length, metadata, progress, tgtSSE)
+	if err != nil {
+		return err.Trace(targetURL.String())
+	}
+	if !isVerify {
+		return nil
+	}
+	targetClnt, err := newClientFromAlias(targetAlias, targetURL.String())
+	if err != nil {
+		return err.Trace(targetAlias, targetURL.String())
+	}
+	return verifyTarget(targetClnt, digest, tgtSSE)
+}
+
+// targetTagging - tagging header of the upload of a target, made of
+// the tags of the source overridden by tags passed in with the same
+// key. Only object storage sources which have tags, as told by the
+// tagging count of their metadata, are asked for them.
+func targetTagging(sourceAlias string, sourceURL clientURL, versionID string, sourceMetadata map[string]string, tags map[string]string) (string, *probe.Error) {
+	targetTags := make(map[string]string)
+	if count := sourceMetadata[amzTaggingCount]; sourceURL.Type == objectStorage && count != "" && count != "0" {
+		sourceClnt, err := newClientFromAlias(sourceAlias, sourceURL.String())
 		if err != nil {
-			return urls.WithError(err.Trace(targetURL.String()))
+			return "", err.Trace(sourceAlias, sourceURL.String())
+		}
+		sourceTags, err := sourceClnt.GetTags(versionID)
+		if err != nil {
+			// Tags cannot be carried over from servers without tagging support.
+			if _, ok := err.ToGoError().(APINotImplemented); !ok {
+				return "", err.Trace(sourceAlias, sourceURL.String())
+			}
+		}
+		for k, v := range sourceTags {
+			targetTags[k] = v
 		}
 	}
-	return urls.WithError(nil)
+	for k, v := range tags {
+		targetTags[k] = v
+	}
+	if len(targetTags) == 0 {
+		return "", nil
+	}
+	return encodeTags(targetTags), nil
 }
 
 // newClientFromAlias gives a new client interface for matching
@@ -317,6 +463,9 @@ func newClientFromAlias(alias, urlStr string) (Client, *probe.Error) {
 	if err != nil {
 		return nil, err.Trace(alias, urlStr)
 	}
+	if isCSEURL(urlStr) {
+		return newCSEClient(s3Client, globalCSEKey), nil
+	}
 	return s3Client, nil
 }
 
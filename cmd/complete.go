@@ -142,19 +142,21 @@ var fsCompleter = fsComplete{}
 // The list of all commands supported by mc with their mapping
 // with their bash completer function
 var completeCmds = map[string]complete.Predictor{
-	"/ls":     complete.PredictOr(s3Completer, fsCompleter),
-	"/cp":     complete.PredictOr(s3Completer, fsCompleter),
-	"/rm":     complete.PredictOr(s3Completer, fsCompleter),
-	"/rb":     complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
-	"/cat":    complete.PredictOr(s3Completer, fsCompleter),
-	"/head":   complete.PredictOr(s3Completer, fsCompleter),
-	"/diff":   complete.PredictOr(s3Completer, fsCompleter),
-	"/find":   complete.PredictOr(s3Completer, fsCompleter),
-	"/mirror": complete.PredictOr(s3Completer, fsCompleter),
-	"/pipe":   complete.PredictOr(s3Completer, fsCompleter),
-	"/stat":   complete.PredictOr(s3Completer, fsCompleter),
-	"/watch":  complete.PredictOr(s3Completer, fsCompleter),
-	"/policy": complete.PredictOr(s3Completer, fsCompleter),
+	"/ls":       complete.PredictOr(s3Completer, fsCompleter),
+	"/cp":       complete.PredictOr(s3Completer, fsCompleter),
+	"/rm":       complete.PredictOr(s3Completer, fsCompleter),
+	"/rb":       complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/cat":      complete.PredictOr(s3Completer, fsCompleter),
+	"/head":     complete.PredictOr(s3Completer, fsCompleter),
+	"/diff":     complete.PredictOr(s3Completer, fsCompleter),
+	"/checksum": complete.PredictOr(s3Completer, fsCompleter),
+	"/find":     complete.PredictOr(s3Completer, fsCompleter),
+	"/mirror":   complete.PredictOr(s3Completer, fsCompleter),
+	"/sync":     complete.PredictOr(s3Completer, fsCompleter),
+	"/pipe":     complete.PredictOr(s3Completer, fsCompleter),
+	"/stat":     complete.PredictOr(s3Completer, fsCompleter),
+	"/watch":    complete.PredictOr(s3Completer, fsCompleter),
+	"/policy":   complete.PredictOr(s3Completer, fsCompleter),
 
 	"/mb":  aliasCompleter,
 	"/sql": s3Completer,
@@ -190,10 +192,37 @@ var completeCmds = map[string]complete.Predictor{
 	"/event/list":   aliasCompleter,
 	"/event/remove": aliasCompleter,
 
+	"/tag/set":    s3Completer,
+	"/tag/get":    s3Completer,
+	"/tag/remove": s3Completer,
+
+	"/retention/set":   s3Completer,
+	"/retention/get":   s3Completer,
+	"/retention/clear": s3Completer,
+
+	"/legalhold/on":   s3Completer,
+	"/legalhold/off":  s3Completer,
+	"/legalhold/info": s3Completer,
+
+	"/ilm/add":    s3Completer,
+	"/ilm/list":   s3Completer,
+	"/ilm/remove": s3Completer,
+	"/ilm/export": s3Completer,
+	"/ilm/import": s3Completer,
+
+	"/encrypt/set":    s3Completer,
+	"/encrypt/info":   s3Completer,
+	"/encrypt/clear":  s3Completer,
+	"/encrypt/rotate": s3Completer,
+
 	"/session/clear":  nil,
 	"/session/list":   nil,
 	"/session/resume": nil,
 
+	"/version/enable":  s3Complete{deepLevel: 2},
+	"/version/suspend": s3Complete{deepLevel: 2},
+	"/version/info":    s3Complete{deepLevel: 2},
+
 	"/share/download": nil,
 	"/share/list":     nil,
 	"/share/upload":   nil,
@@ -0,0 +1,191 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Compression algorithms of uploaded objects.
+const (
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+// User metadata of objects compressed by mc, the original size is
+// kept since the stored size is the compressed one.
+const (
+	compressionMeta      = "X-Amz-Meta-Mc-Compression"
+	uncompressedSizeMeta = "X-Amz-Meta-Mc-Uncompressed-Size"
+)
+
+// checkCompression - validate a compression algorithm, an empty
+// algorithm disables compression.
+func checkCompression(algorithm string) *probe.Error {
+	switch algorithm {
+	case "", compressionGzip, compressionZstd:
+		return nil
+	}
+	return errInvalidCompression(algorithm)
+}
+
+// compressReader - compress the data read from reader on the fly
+// with a valid algorithm, closing the returned reader stops the
+// compression.
+func compressReader(reader io.Reader, algorithm string) io.ReadCloser {
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		var zipWriter io.WriteCloser
+		if algorithm == compressionZstd {
+			encoder, e := zstd.NewWriter(pipeWriter)
+			if e != nil {
+				pipeWriter.CloseWithError(e)
+				return
+			}
+			zipWriter = encoder
+		} else {
+			zipWriter = gzip.NewWriter(pipeWriter)
+		}
+		_, e := io.Copy(zipWriter, reader)
+		if e == nil {
+			e = zipWriter.Close()
+		}
+		pipeWriter.CloseWithError(e)
+	}()
+	return pipeReader
+}
+
+// setCompressionMetadata - record the compression of an object of
+// the given size in its metadata, a negative size is not recorded.
+func setCompressionMetadata(metadata map[string]string, algorithm string, size int64) {
+	metadata["Content-Encoding"] = algorithm
+	metadata[compressionMeta] = algorithm
+	delete(metadata, uncompressedSizeMeta)
+	if size >= 0 {
+		metadata[uncompressedSizeMeta] = strconv.FormatInt(size, 10)
+	}
+}
+
+// isCompressed - tells whether the object was compressed by mc.
+func isCompressed(metadata map[string]string) bool {
+	return metadata[compressionMeta] != ""
+}
+
+// uncompressedSize - size of the object once decompressed, -1 if
+// it was compressed from a stream of unknown size.
+func uncompressedSize(metadata map[string]string, size int64) int64 {
+	if !isCompressed(metadata) {
+		return size
+	}
+	if size, e := strconv.ParseInt(metadata[uncompressedSizeMeta], 10, 64); e == nil {
+		return size
+	}
+	return -1
+}
+
+// decompressReader - decompress objects which were compressed by mc,
+// other objects are returned as they are.
+func decompressReader(reader io.ReadCloser, metadata map[string]string) (io.ReadCloser, *probe.Error) {
+	switch algorithm := metadata[compressionMeta]; algorithm {
+	case "":
+		return reader, nil
+	case compressionGzip:
+		zipReader, e := gzip.NewReader(reader)
+		if e != nil {
+			reader.Close()
+			return nil, probe.NewError(e)
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{zipReader, reader}, nil
+	case compressionZstd:
+		decoder, e := zstd.NewReader(reader)
+		if e != nil {
+			reader.Close()
+			return nil, probe.NewError(e)
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{decoder, closerFunc(func() error {
+			decoder.Close()
+			return reader.Close()
+		})}, nil
+	default:
+		reader.Close()
+		return nil, errInvalidCompression(algorithm)
+	}
+}
+
+// closerFunc - an io.Closer calling a function.
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+// compressionSelectType - compression type of S3 Select requests on
+// objects compressed by mc, S3 Select cannot read zstd.
+func compressionSelectType(metadata map[string]string) (minio.SelectCompressionType, *probe.Error) {
+	switch algorithm := metadata[compressionMeta]; algorithm {
+	case "":
+		return "", nil
+	case compressionGzip:
+		return minio.SelectCompressionGZIP, nil
+	default:
+		return "", probe.NewError(errors.New("S3 Select cannot read objects compressed with `" + algorithm + "`"))
+	}
+}
+
+// statUncompressedSize - size of the listed content once
+// decompressed, files are never compressed.
+func statUncompressedSize(alias string, content *clientContent) (int64, *probe.Error) {
+	if content.URL.Type != objectStorage {
+		return content.Size, nil
+	}
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return 0, err.Trace(alias, content.URL.String())
+	}
+	st, err := clnt.Stat(false, true, nil)
+	if err != nil {
+		return 0, err.Trace(alias, content.URL.String())
+	}
+	return uncompressedSize(st.Metadata, st.Size), nil
+}
+
+// isSameUncompressedSize - tells whether two objects which differ
+// in size only do so because one of them was compressed by mc.
+func isSameUncompressedSize(sourceAlias string, source *clientContent, targetAlias string, target *clientContent) bool {
+	if source.URL.Type != objectStorage && target.URL.Type != objectStorage {
+		return false
+	}
+	sourceSize, err := statUncompressedSize(sourceAlias, source)
+	if err != nil {
+		return false
+	}
+	targetSize, err := statUncompressedSize(targetAlias, target)
+	if err != nil {
+		return false
+	}
+	return sourceSize >= 0 && sourceSize == targetSize
+}
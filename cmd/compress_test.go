@@ -0,0 +1,119 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestCompressReader(t *testing.T) {
+	data := []byte(strings.Repeat("GET /index.html HTTP/1.1 200\n", 1000))
+	for _, algorithm := range []string{compressionGzip, compressionZstd} {
+		if err := checkCompression(algorithm); err != nil {
+			t.Fatalf("%s: %v", algorithm, err)
+		}
+		compressed, e := ioutil.ReadAll(compressReader(bytes.NewReader(data), algorithm))
+		if e != nil {
+			t.Fatal(e)
+		}
+		if len(compressed) >= len(data) {
+			t.Fatalf("%s: expected compressed size below %d, got %d", algorithm, len(data), len(compressed))
+		}
+
+		metadata := map[string]string{}
+		setCompressionMetadata(metadata, algorithm, int64(len(data)))
+		if metadata["Content-Encoding"] != algorithm || !isCompressed(metadata) {
+			t.Fatalf("%s: unexpected compression metadata %v", algorithm, metadata)
+		}
+		if size := uncompressedSize(metadata, int64(len(compressed))); size != int64(len(data)) {
+			t.Fatalf("%s: expected uncompressed size %d, got %d", algorithm, len(data), size)
+		}
+		reader, err := decompressReader(ioutil.NopCloser(bytes.NewReader(compressed)), metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decompressed, e := ioutil.ReadAll(reader)
+		if e != nil {
+			t.Fatal(e)
+		}
+		reader.Close()
+		if !bytes.Equal(decompressed, data) {
+			t.Fatalf("%s: decompressed data differs from the original", algorithm)
+		}
+	}
+
+	// Objects not compressed by mc are read as they are.
+	reader, err := decompressReader(ioutil.NopCloser(bytes.NewReader(data)), map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, _ := ioutil.ReadAll(reader); !bytes.Equal(plain, data) {
+		t.Fatal("uncompressed object was modified")
+	}
+
+	if err = checkCompression("lz4"); err == nil {
+		t.Fatal("expected lz4 to be rejected")
+	}
+}
+
+func TestCompressReaderZstd(t *testing.T) {
+	random := make([]byte, 64*1024)
+	rand.New(rand.NewSource(1)).Read(random)
+	for i, data := range [][]byte{{}, []byte("a"), random} {
+		compressed, e := ioutil.ReadAll(compressReader(bytes.NewReader(data), compressionZstd))
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+
+		// The stream must be plain zstd, readable without mc.
+		decoder, e := zstd.NewReader(bytes.NewReader(compressed))
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		decoded, e := ioutil.ReadAll(decoder)
+		decoder.Close()
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Fatalf("Test %d: zstd decoded data differs from the original", i+1)
+		}
+
+		metadata := map[string]string{}
+		setCompressionMetadata(metadata, compressionZstd, int64(len(data)))
+		reader, err := decompressReader(ioutil.NopCloser(bytes.NewReader(compressed)), metadata)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		decompressed, e := ioutil.ReadAll(reader)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if e = reader.Close(); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if !bytes.Equal(decompressed, data) {
+			t.Fatalf("Test %d: decompressed data differs from the original", i+1)
+		}
+	}
+}
@@ -36,6 +36,14 @@ var hostAddFlags = []cli.Flag{
 		Name:  "api",
 		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
 	},
+	cli.IntFlag{
+		Name:  "retry-attempts",
+		Usage: "attempts of transfers failing for transient reasons, overrides the default of 3",
+	},
+	cli.StringFlag{
+		Name:  "retry-backoff",
+		Usage: "delay before retrying a failed transfer, doubled on every retry, e.g. 2s",
+	},
 }
 var configHostAddCmd = cli.Command{
 	Name:            "add",
@@ -80,6 +88,12 @@ EXAMPLES:
 								minio minio123 --api "s3v4" --lookup "dns"
 		 $ set -o history
 
+  5. Add MinIO cloud storage under "backup" alias, retrying failed transfers up to 10 times starting with a 5 second delay.
+     $ set +o history
+     $ {{.HelpName}} backup http://backup.example.com:9000 \
+                 minio minio123 --retry-attempts 10 --retry-backoff 5s
+     $ set -o history
+
 `,
 }
 
@@ -125,6 +139,10 @@ func checkConfigHostAddSyntax(ctx *cli.Context) {
 		fatalIf(errInvalidArgument().Trace(bucketLookup),
 			"Unrecognized bucket lookup. Valid options are `[dns,auto, path]`.")
 	}
+
+	if _, err := parseRetryOptions(ctx.Int("retry-attempts"), ctx.String("retry-backoff")); err != nil {
+		fatalIf(err.Trace(alias), "Invalid retry policy.")
+	}
 }
 
 // addHost - add a host config.
@@ -146,6 +164,7 @@ func addHost(alias string, hostCfgV9 hostConfigV9) {
 		SecretKey: hostCfgV9.SecretKey,
 		API:       hostCfgV9.API,
 		Lookup:    hostCfgV9.Lookup,
+		Retry:     hostCfgV9.Retry,
 	})
 }
 
@@ -237,12 +256,21 @@ func mainConfigHostAdd(ctx *cli.Context) error {
 	s3Config, err := buildS3Config(url, accessKey, secretKey, api, lookup)
 	fatalIf(err.Trace(ctx.Args()...), "Unable to initialize new config from the provided credentials.")
 
+	var retry *hostRetryConfig
+	if ctx.Int("retry-attempts") != 0 || ctx.String("retry-backoff") != "" {
+		retry = &hostRetryConfig{
+			Attempts: ctx.Int("retry-attempts"),
+			Backoff:  ctx.String("retry-backoff"),
+		}
+	}
+
 	addHost(ctx.Args().Get(0), hostConfigV9{
 		URL:       s3Config.HostURL,
 		AccessKey: s3Config.AccessKey,
 		SecretKey: s3Config.SecretKey,
 		API:       s3Config.Signature,
 		Lookup:    lookup,
+		Retry:     retry,
 	}) // Add a host with specified credentials.
 	return nil
 }
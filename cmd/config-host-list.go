@@ -130,6 +130,7 @@ func listHosts(alias string) {
 				SecretKey:   v.SecretKey,
 				API:         v.API,
 				Lookup:      v.Lookup,
+				Retry:       v.Retry,
 			})
 			return
 		}
@@ -147,6 +148,7 @@ func listHosts(alias string) {
 			SecretKey:   v.SecretKey,
 			API:         v.API,
 			Lookup:      v.Lookup,
+			Retry:       v.Retry,
 		})
 	}
 
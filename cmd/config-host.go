@@ -48,13 +48,14 @@ func mainConfigHost(ctx *cli.Context) error {
 type hostMessage struct {
 	op          string
 	prettyPrint bool
-	Status      string `json:"status"`
-	Alias       string `json:"alias"`
-	URL         string `json:"URL"`
-	AccessKey   string `json:"accessKey,omitempty"`
-	SecretKey   string `json:"secretKey,omitempty"`
-	API         string `json:"api,omitempty"`
-	Lookup      string `json:"lookup,omitempty"`
+	Status      string           `json:"status"`
+	Alias       string           `json:"alias"`
+	URL         string           `json:"URL"`
+	AccessKey   string           `json:"accessKey,omitempty"`
+	SecretKey   string           `json:"secretKey,omitempty"`
+	API         string           `json:"api,omitempty"`
+	Lookup      string           `json:"lookup,omitempty"`
+	Retry       *hostRetryConfig `json:"retry,omitempty"`
 }
 
 // Print the config information of one alias, when prettyPrint flag
@@ -42,6 +42,8 @@ type hostConfigV9 struct {
 	SecretKey string `json:"secretKey"`
 	API       string `json:"api"`
 	Lookup    string `json:"lookup"`
+	// Retry policy of transfers, nil means the default policy.
+	Retry *hostRetryConfig `json:"retry,omitempty"`
 }
 
 // configV8 config version.
@@ -0,0 +1,298 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// maxCopyListLineSize - longest line of a JSON lines copy list.
+const maxCopyListLineSize = 1024 * 1024
+
+// copyListEntry - an object of a copy list, the source is copied to the
+// target with the given metadata, storage class, tags and SSE-C key.
+// The source is copied into a target which is a folder or ends with
+// a separator. Unknown fields are ignored.
+type copyListEntry struct {
+	Source       string            `json:"source"`
+	Target       string            `json:"target"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	StorageClass string            `json:"storageClass,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	EncryptKey   string            `json:"encryptKey,omitempty"`
+}
+
+// copyListReader - reads the entries of a copy list, one JSON object
+// per line, or CSV if its name ends with .csv. A CSV list starts with
+// a header naming its columns, its metadata is written as for --attr
+// and its tags as for --tags. Rows with a status other than failed are
+// skipped, so that a CSV report of cp copies its failed objects again.
+type copyListReader struct {
+	path string
+	file *os.File
+	line int
+	// set once the list cannot be read any further
+	isDone bool
+
+	// rm removes the sources of a list, it needs no targets.
+	isTargetRequired bool
+
+	scanner *bufio.Scanner
+	csv     *csv.Reader
+	columns map[string]int
+}
+
+// openCopyList - open the copy list at path, entries without a target
+// are invalid if isTargetRequired is set.
+func openCopyList(path string, isTargetRequired bool) (*copyListReader, *probe.Error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	r := &copyListReader{path: path, file: f, isTargetRequired: isTargetRequired}
+	if !isCSVReport(path) {
+		r.scanner = bufio.NewScanner(f)
+		r.scanner.Buffer(make([]byte, 64*1024), maxCopyListLineSize)
+		return r, nil
+	}
+
+	r.csv = csv.NewReader(f)
+	r.csv.FieldsPerRecord = -1
+	r.csv.TrimLeadingSpace = true
+	header, e := r.csv.Read()
+	if e != nil {
+		f.Close()
+		if e == io.EOF {
+			return nil, errInvalidCopyList(path, 1, "missing header").Trace(path)
+		}
+		return nil, errInvalidCopyList(path, 1, e.Error()).Trace(path)
+	}
+	r.line = 1
+	r.columns = make(map[string]int)
+	for i, column := range header {
+		r.columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	columns := []string{"source"}
+	if isTargetRequired {
+		columns = append(columns, "target")
+	}
+	for _, column := range columns {
+		if _, ok := r.columns[column]; !ok {
+			f.Close()
+			return nil, errInvalidCopyList(path, 1, "missing `"+column+"` column").Trace(path)
+		}
+	}
+	return r, nil
+}
+
+// next - read the next entry of the list, returns nil at the end of the
+// list. Reading may go on after an invalid entry.
+func (r *copyListReader) next() (*copyListEntry, *probe.Error) {
+	if r.isDone {
+		return nil, nil
+	}
+	if r.csv != nil {
+		return r.nextCSV()
+	}
+	for r.scanner.Scan() {
+		r.line++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry copyListEntry
+		if e := json.Unmarshal([]byte(line), &entry); e != nil {
+			return nil, errInvalidCopyList(r.path, r.line, e.Error()).Trace(r.path)
+		}
+		return r.checkEntry(entry)
+	}
+	r.isDone = true
+	if e := r.scanner.Err(); e != nil {
+		return nil, errInvalidCopyList(r.path, r.line+1, e.Error()).Trace(r.path)
+	}
+	return nil, nil
+}
+
+// nextCSV - read the next entry of a CSV list.
+func (r *copyListReader) nextCSV() (*copyListEntry, *probe.Error) {
+	var record []string
+	field := func(column string) string {
+		if i, ok := r.columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	for {
+		var e error
+		record, e = r.csv.Read()
+		if e == io.EOF {
+			r.isDone = true
+			return nil, nil
+		}
+		r.line++
+		if e != nil {
+			// Reading goes on after a malformed line only.
+			if _, ok := e.(*csv.ParseError); !ok {
+				r.isDone = true
+			}
+			return nil, errInvalidCopyList(r.path, r.line, e.Error()).Trace(r.path)
+		}
+		// Only the failed objects of a report are read, not its totals.
+		if status := field("status"); status == "" || status == reportFailed {
+			break
+		}
+	}
+
+	entry := copyListEntry{
+		Source:       field("source"),
+		Target:       field("target"),
+		StorageClass: field("storageclass"),
+		EncryptKey:   field("encryptkey"),
+	}
+	if metadata := field("metadata"); metadata != "" {
+		var err *probe.Error
+		if entry.Metadata, err = getMetaDataEntry(metadata); err != nil {
+			return nil, errInvalidCopyList(r.path, r.line, "invalid metadata `"+metadata+"`").Trace(r.path)
+		}
+	}
+	if tags := field("tags"); tags != "" {
+		var err *probe.Error
+		if entry.Tags, err = parseTags(tags); err != nil {
+			return nil, errInvalidCopyList(r.path, r.line, err.ToGoError().Error()).Trace(r.path)
+		}
+	}
+	return r.checkEntry(entry)
+}
+
+// checkEntry - verify an entry read from the current line.
+func (r *copyListReader) checkEntry(entry copyListEntry) (*copyListEntry, *probe.Error) {
+	if entry.Source == "" {
+		return nil, errInvalidCopyList(r.path, r.line, "missing source").Trace(r.path)
+	}
+	if entry.Target == "" && r.isTargetRequired {
+		return nil, errInvalidCopyList(r.path, r.line, "missing target").Trace(r.path)
+	}
+	if len(entry.Tags) > 0 {
+		values := make(url.Values)
+		for k, v := range entry.Tags {
+			values.Set(k, v)
+		}
+		if _, err := parseTags(values.Encode()); err != nil {
+			return nil, errInvalidCopyList(r.path, r.line, err.ToGoError().Error()).Trace(r.path)
+		}
+	}
+	if entry.EncryptKey != "" {
+		if _, err := parseSSECKey(entry.EncryptKey); err != nil {
+			return nil, errInvalidCopyList(r.path, r.line, err.ToGoError().Error()).Trace(r.path)
+		}
+	}
+	return &entry, nil
+}
+
+// close - close the list.
+func (r *copyListReader) close() {
+	r.file.Close()
+}
+
+// prepareCopyURLsFromList - prepares target and source clientURLs for
+// copying the objects of a copy list, in the order of the list.
+func prepareCopyURLsFromList(fromList string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+	copyURLsCh := make(chan URLs)
+	go func() {
+		defer close(copyURLsCh)
+		list, err := openCopyList(fromList, true)
+		if err != nil {
+			copyURLsCh <- URLs{Error: err.Trace(fromList)}
+			return
+		}
+		defer list.close()
+
+		for {
+			entry, err := list.next()
+			if err != nil {
+				copyURLsCh <- URLs{Error: err}
+				continue
+			}
+			if entry == nil {
+				return
+			}
+			copyURLsCh <- makeCopyListURLs(*entry, timeRef, encKeyDB)
+		}
+	}()
+	return copyURLsCh
+}
+
+// makeCopyListURLs - CopyURLs content for copying an entry of a copy
+// list. The URLs of a failed entry name its source and target.
+func makeCopyListURLs(entry copyListEntry, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) URLs {
+	var cpURLs URLs
+	if isAliasURLDir(entry.Target, encKeyDB) {
+		cpURLs = prepareCopyURLsTypeB(entry.Source, "", timeRef, entry.Target, encKeyDB)
+	} else {
+		cpURLs = prepareCopyURLsTypeA(entry.Source, "", timeRef, entry.Target, encKeyDB)
+	}
+	if cpURLs.Error != nil {
+		var sourceURL, targetURL string
+		cpURLs.SourceAlias, sourceURL, _ = mustExpandAlias(entry.Source)
+		cpURLs.SourceContent = &clientContent{URL: *newClientURL(sourceURL)}
+		cpURLs.TargetAlias, targetURL, _ = mustExpandAlias(entry.Target)
+		cpURLs.TargetContent = &clientContent{URL: *newClientURL(targetURL)}
+		return cpURLs
+	}
+
+	if len(entry.Metadata) > 0 {
+		cpURLs.TargetContent.UserMetadata = entry.Metadata
+	}
+	if entry.StorageClass != "" {
+		cpURLs.TargetContent.Metadata = map[string]string{"X-Amz-Storage-Class": entry.StorageClass}
+	}
+	if len(entry.Tags) > 0 {
+		cpURLs.TargetContent.Tags = entry.Tags
+	}
+	cpURLs.TargetEncryptKey = entry.EncryptKey
+	return cpURLs
+}
+
+// copyListEncKeys - the encryption keys used to copy cpURLs, the SSE-C
+// key of an object of a copy list encrypts its target only.
+func copyListEncKeys(encKeyDB map[string][]prefixSSEPair, cpURLs URLs) (map[string][]prefixSSEPair, *probe.Error) {
+	if cpURLs.TargetEncryptKey == "" {
+		return encKeyDB, nil
+	}
+	sse, err := parseSSECKey(cpURLs.TargetEncryptKey)
+	if err != nil {
+		return nil, err.Trace(cpURLs.TargetContent.URL.String())
+	}
+	keys := make(map[string][]prefixSSEPair, len(encKeyDB)+1)
+	for alias, pairs := range encKeyDB {
+		keys[alias] = pairs
+	}
+	// The full path of the target is the longest prefix matching it.
+	targetPath := filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path))
+	keys[cpURLs.TargetAlias] = append([]prefixSSEPair{{Prefix: targetPath, SSE: sse}}, encKeyDB[cpURLs.TargetAlias]...)
+	return keys, nil
+}
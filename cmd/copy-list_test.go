@@ -0,0 +1,166 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCopyList(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-copy-list-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	key := "32byteslongsecretkeymustbegiven1"
+	testCases := []struct {
+		name             string
+		data             string
+		isTargetRequired bool
+		entries          []*copyListEntry
+		errLines         []int
+	}{
+		{
+			name: "list.jsonl",
+			data: `{"source": "s3/a/x", "target": "s3/b/y", "storageClass": "STANDARD_IA", "error": "ignored"}
+
+{"source": "s3/a/z", "target": "s3/b/", "metadata": {"k": "v"}, "tags": {"t": "1"}, "encryptKey": "` + key + `"}
+{"source": "s3/a/x"
+{"source": "s3/a/x"}
+{"target": "s3/b/y"}
+{"source": "s3/a/x", "target": "s3/b/y", "encryptKey": "short"}
+`,
+			isTargetRequired: true,
+			entries: []*copyListEntry{
+				{Source: "s3/a/x", Target: "s3/b/y", StorageClass: "STANDARD_IA"},
+				{Source: "s3/a/z", Target: "s3/b/", Metadata: map[string]string{"k": "v"}, Tags: map[string]string{"t": "1"}, EncryptKey: key},
+				nil, nil, nil, nil,
+			},
+			errLines: []int{4, 5, 6, 7},
+		},
+		{
+			name:    "list.jsonl",
+			data:    `{"source": "s3/a/x"}`,
+			entries: []*copyListEntry{{Source: "s3/a/x"}},
+		},
+		{
+			name: "list.csv",
+			data: "Source, target,tags,metadata,unknown\n" +
+				"s3/a/x,s3/b/y,t=1&u=2,\"k1=v1,k2=v2\",ignored\n" +
+				"s3/a/z,s3/b/\n" +
+				"s3/a/x,s3/b/y,t=1&t=2\n" +
+				"s3/a/x,s3/b/y,,k1\n",
+			isTargetRequired: true,
+			entries: []*copyListEntry{
+				{Source: "s3/a/x", Target: "s3/b/y", Tags: map[string]string{"t": "1", "u": "2"}, Metadata: map[string]string{"k1": "v1", "k2": "v2"}},
+				{Source: "s3/a/z", Target: "s3/b/"},
+				nil, nil,
+			},
+			errLines: []int{4, 5},
+		},
+		{
+			name: "report.csv",
+			data: strings.Join(csvReportHeader, ",") + "\n" +
+				"copied,s3/a/x,s3/b/x,1,0.100,10,\n" +
+				"failed,s3/a/y,s3/b/y,1,0.100,10,connection reset\n" +
+				"total,,,1,0.200,5,copied=1 skipped=0 removed=0 failed=1\n",
+			isTargetRequired: true,
+			entries:          []*copyListEntry{{Source: "s3/a/y", Target: "s3/b/y"}},
+		},
+	}
+
+	for i, testCase := range testCases {
+		path := filepath.Join(dir, testCase.name)
+		if e = ioutil.WriteFile(path, []byte(testCase.data), 0600); e != nil {
+			t.Fatal(e)
+		}
+		list, err := openCopyList(path, testCase.isTargetRequired)
+		if err != nil {
+			t.Fatalf("Test %d: unable to open list: %v", i+1, err)
+		}
+		var errLines []int
+		for j, expected := range testCase.entries {
+			entry, err := list.next()
+			if err != nil {
+				errLines = append(errLines, list.line)
+			}
+			if !reflect.DeepEqual(entry, expected) {
+				t.Errorf("Test %d: entry %d expected %+v, got %+v", i+1, j+1, expected, entry)
+			}
+		}
+		if entry, err := list.next(); entry != nil || err != nil {
+			t.Errorf("Test %d: expected the end of the list, got %+v, %v", i+1, entry, err)
+		}
+		list.close()
+		if !reflect.DeepEqual(errLines, testCase.errLines) {
+			t.Errorf("Test %d: expected errors at lines %v, got %v", i+1, testCase.errLines, errLines)
+		}
+	}
+
+	// A CSV list of objects to copy needs a target column.
+	path := filepath.Join(dir, "sources.csv")
+	if e = ioutil.WriteFile(path, []byte("source\ns3/a/x\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	if _, err := openCopyList(path, true); err == nil {
+		t.Error("Expected an error for a list without targets")
+	}
+	list, err := openCopyList(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer list.close()
+	if entry, _ := list.next(); entry == nil || entry.Source != "s3/a/x" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+}
+
+func TestCopyListEncKeys(t *testing.T) {
+	encKeyDB, err := parseEncryptionKeys("s3/b/=32byteslongsecretkeymustbegiven1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpURLs := URLs{
+		TargetAlias:   "s3",
+		TargetContent: &clientContent{URL: *newClientURL("https://s3.amazonaws.com/b/y")},
+	}
+	if keys, _ := copyListEncKeys(encKeyDB, cpURLs); !reflect.DeepEqual(keys, encKeyDB) {
+		t.Errorf("Expected the keys of the command, got %v", keys)
+	}
+
+	cpURLs.TargetEncryptKey = "32byteslongsecretkeymustbegiven2"
+	keys, err := copyListEncKeys(encKeyDB, cpURLs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := parseSSECKey(cpURLs.TargetEncryptKey)
+	if sse := getSSE("s3/b/y", keys["s3"]); !reflect.DeepEqual(sse, expected) {
+		t.Errorf("Expected the key of the object, got %v", sse)
+	}
+	if sse := getSSE("s3/b/z", keys["s3"]); !reflect.DeepEqual(sse, encKeyDB["s3"][0].SSE) {
+		t.Errorf("Expected the key of the command, got %v", sse)
+	}
+	if len(encKeyDB["s3"]) != 1 {
+		t.Errorf("The keys of the command were modified: %v", encKeyDB)
+	}
+}
@@ -17,7 +17,6 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
@@ -27,6 +26,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -57,10 +57,71 @@ var (
 			Name:  "encrypt",
 			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
 		},
+		cli.StringFlag{
+			Name:  "encrypt-kms",
+			Usage: "encrypt objects using server-side encryption with KMS managed keys, e.g. \"s3/finance=my-key-id\"",
+		},
+		cli.StringFlag{
+			Name:  "encrypt-client",
+			Usage: "encrypt/decrypt objects on the client with the 32 byte key in KEYFILE",
+		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress objects on upload to object storage with gzip or zstd",
+		},
+		cli.BoolFlag{
+			Name:  "verify",
+			Usage: "verify copied object(s) against a checksum of the uploaded data, and copy them again on mismatch",
+		},
 		cli.StringFlag{
 			Name:  "attr",
 			Usage: "add custom metadata for the object",
 		},
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "copy a specific version of the source object",
+		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "copy objects as they were at a date or a duration ago, e.g. 2019-05-21T18:00:00Z or 7d10h",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "set tags on copied objects, e.g. \"key1=value1&key2=value2\"",
+		},
+		cli.IntFlag{
+			Name:  "parallel",
+			Usage: "copy with a fixed number of parallel workers, instead of adapting their number to the transfers",
+		},
+		cli.IntFlag{
+			Name:  "max-parallel",
+			Usage: "maximum number of parallel workers when adapting their number to the transfers",
+			Value: maxParallelWorkers,
+		},
+		cli.IntFlag{
+			Name:  "retry-attempts",
+			Usage: "attempts of transfers failing for transient reasons, overrides the retry policy of aliases (default: 3)",
+		},
+		cli.StringFlag{
+			Name:  "retry-backoff",
+			Usage: "delay before retrying a failed transfer, doubled on every retry, e.g. 2s (default: 1s)",
+		},
+		cli.StringFlag{
+			Name:  "exclude-from",
+			Usage: "exclude object(s) that match gitignore style patterns read from a file",
+		},
+		cli.StringSliceFlag{
+			Name:  "include",
+			Usage: "include object(s) that match a gitignore style pattern, even if they are excluded",
+		},
+		cli.StringFlag{
+			Name:  "report",
+			Usage: "record the outcome of every object in a JSON report file, or CSV if its name ends with .csv",
+		},
+		cli.StringFlag{
+			Name:  "from-list",
+			Usage: "copy the sources to the targets read from a JSON lines file, or CSV if its name ends with .csv, e.g. a CSV report",
+		},
 	}
 )
 
@@ -73,12 +134,13 @@ var cpCmd = cli.Command{
 	Usage:  "copy objects",
 	Action: mainCopy,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(cpFlags, ioFlags...), limitFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE [SOURCE...] TARGET
+  {{.HelpName}} [FLAGS] --from-list LIST
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -86,6 +148,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefixes
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+   MC_ENCRYPT_KMS:  list of comma delimited prefix=key-id values, key-id may end with ?key=value encryption context
 
 EXAMPLES:
    1. Copy a list of objects from local file system to Amazon S3 cloud storage.
@@ -121,6 +184,54 @@ EXAMPLES:
 	11. Copy a folder recursively from MinIO cloud storage to Amazon S3 cloud storage with specified metadata.
 			$ {{.HelpName}} --attr key1=value1,key2=value2 --recursive play/mybucket/burningman2011/ s3/mybucket/
 
+  12. Copy a specific version of an object from a versioned bucket to a local path.
+      $ {{.HelpName}} --version-id "3/L4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nr8X8gdRQBpUMLUo" s3/mybucket/report.pdf ~/recovered/
+
+  13. Copy a folder recursively from a versioned bucket as it was on a given date.
+      $ {{.HelpName}} --recursive --rewind 2019-05-21T18:00:00Z s3/mybucket/reports/ ~/reports-may/
+
+  14. Copy a folder recursively to MinIO cloud storage and tag the copied objects.
+      $ {{.HelpName}} --recursive --tags "project=mc&cost-center=eng" backups/ play/mybucket/backups/
+
+  15. Copy a folder recursively to Amazon S3 cloud storage, encrypting objects with a KMS managed key.
+      $ {{.HelpName}} --recursive --encrypt-kms "s3/finance/=arn:aws:kms:us-east-1:xxx:key/xxx" reports/ s3/finance/reports/
+
+  16. Copy a folder recursively to Amazon S3 cloud storage, encrypting objects on the client before upload.
+      $ {{.HelpName}} --recursive --encrypt-client ~/.mc/backup.key reports/ s3/backups/reports/
+
+  17. Copy client-side encrypted objects back to a local folder, decrypting them on the client.
+      $ {{.HelpName}} --recursive --encrypt-client ~/.mc/backup.key s3/backups/reports/ ~/reports/
+
+  18. Copy a folder of logs recursively to MinIO cloud storage, compressing objects on upload.
+      $ {{.HelpName}} --recursive --compress gzip /var/log/nginx/ play/logs/nginx/
+
+  19. Copy a folder recursively to MinIO cloud storage and verify the content of the copied objects.
+      $ {{.HelpName}} --recursive --verify backup/ play/archive/
+
+  20. Copy a folder recursively to Amazon S3 cloud storage, limiting the upload bandwidth to 10MiB per second.
+      $ {{.HelpName}} --recursive --limit-upload 10MiB/s backup/ s3/archive/
+
+  21. Copy a folder of many small files recursively to MinIO cloud storage with 64 parallel workers.
+      $ {{.HelpName}} --recursive --parallel 64 thumbnails/ play/thumbnails/
+
+  22. Copy a folder recursively to Amazon S3 cloud storage, retrying transient failures up to 5 times.
+      $ {{.HelpName}} --recursive --retry-attempts 5 --retry-backoff 2s backup/ s3/archive/
+
+  23. Copy a project folder recursively to MinIO cloud storage, skipping the paths listed in its '.gitignore'
+      and '.mcignore' files, but keeping the 'dist' folder.
+      $ {{.HelpName}} --recursive --exclude-from project/.gitignore --include "/dist/" project/ play/builds/
+
+  24. Copy a folder recursively to Amazon S3 cloud storage and record the outcome of every object in a CSV report.
+      $ {{.HelpName}} --recursive --report backup-report.csv backup/ s3/archive/
+
+  25. Copy the objects listed one per line in a JSON file, each line giving a source, a target and optionally
+      "metadata", "storageClass", "tags" and "encryptKey" of the copied object, e.g.
+      {"source": "s3/raw/2020/05/events.json", "target": "s3/curated/events/2020-05.json", "storageClass": "STANDARD_IA"}
+      $ {{.HelpName}} --from-list objects.jsonl --report copied.csv
+
+  26. Copy again the objects which failed to copy, as recorded in a CSV report. Objects which did not fail are skipped.
+      $ {{.HelpName}} --from-list copied.csv
+
  `,
 }
 
@@ -162,7 +273,7 @@ type ProgressReader interface {
 }
 
 // doCopy - Copy a singe file from source to destination
-func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair) URLs {
+func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair, isVerify bool, throttledFn func()) URLs {
 	if cpURLs.Error != nil {
 		cpURLs.Error = cpURLs.Error.Trace()
 		return cpURLs
@@ -187,7 +298,7 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 			TotalSize:  cpURLs.TotalSize,
 		})
 	}
-	return uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB)
+	return retryUploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB, isVerify, printMsg, throttledFn)
 }
 
 // doCopyFake - Perform a fake copy to update the progress bar appropriately.
@@ -199,33 +310,54 @@ func doCopyFake(cpURLs URLs, pg Progress) URLs {
 }
 
 // doPrepareCopyURLs scans the source URL and prepares a list of objects for copying.
-func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy context.CancelFunc) {
+func doPrepareCopyURLs(session *sessionV9, report *transferReport, trapCh <-chan bool, cancelCopy context.CancelFunc) {
 	// Separate source and target. 'cp' can take only one target,
-	// but any number of sources.
-	sourceURLs := session.Header.CommandArgs[:len(session.Header.CommandArgs)-1]
-	targetURL := session.Header.CommandArgs[len(session.Header.CommandArgs)-1] // Last one is target
+	// but any number of sources. Both are read from the copy list
+	// with --from-list.
+	fromList := session.Header.CommandStringFlags["from-list"]
+	var sourceURLs []string
+	var targetURL string
+	if fromList == "" {
+		sourceURLs = session.Header.CommandArgs[:len(session.Header.CommandArgs)-1]
+		targetURL = session.Header.CommandArgs[len(session.Header.CommandArgs)-1] // Last one is target
+	}
 
 	var totalBytes int64
 	var totalObjects int64
 
 	// Access recursive flag inside the session header.
 	isRecursive := session.Header.CommandBoolFlags["recursive"]
+	versionID := session.Header.CommandStringFlags["version-id"]
+	timeRef, err := parseRewind(session.Header.CommandStringFlags["rewind"])
+	fatalIf(err, "Unable to parse rewind.")
 
 	olderThan := session.Header.CommandStringFlags["older-than"]
 	newerThan := session.Header.CommandStringFlags["newer-than"]
 	encryptKeys := session.Header.CommandStringFlags["encrypt-key"]
+	encryptKMS := session.Header.CommandStringFlags["encrypt-kms"]
 	encrypt := session.Header.CommandStringFlags["encrypt"]
-	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
+	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encryptKMS, encrypt)
 	fatalIf(err, "Unable to parse encryption keys.")
 
-	// Create a session data file to store the processed URLs.
-	dataFP := session.NewDataWriter()
+	// Reset the session data file to store the processed URLs.
+	if err = session.ResetData(); err != nil {
+		session.Delete()
+		fatalIf(err.Trace(), "Unable to prepare session data.")
+	}
+
+	var includes []string
+	if include := session.Header.CommandStringFlags["include"]; include != "" {
+		e := json.Unmarshal([]byte(include), &includes)
+		fatalIf(probe.NewError(e), "Unable to parse include options.")
+	}
+	excludeRules, err := newExcludeRules(session.Header.CommandStringFlags["exclude-from"], includes)
+	fatalIf(err, "Unable to read exclude patterns.")
 
 	var scanBar scanBarFunc
 	if !globalQuiet && !globalJSON { // set up progress bar
 		scanBar = scanBarFactory()
 	}
-	URLsCh := prepareCopyURLs(sourceURLs, targetURL, isRecursive, encKeyDB)
+	URLsCh := prepareCopyURLs(sourceURLs, targetURL, versionID, timeRef, isRecursive, excludeRules, fromList, encKeyDB)
 	done := false
 	for !done {
 		select {
@@ -244,6 +376,7 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 				} else {
 					errorIf(cpURLs.Error.Trace(), "Unable to prepare URL for copying.")
 				}
+				report.recordURLs(cpURLs, reportFailed, "")
 				break
 			}
 
@@ -255,15 +388,20 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 
 			// Skip objects older than --older-than parameter if specified
 			if olderThan != "" && isOlder(cpURLs.SourceContent.Time, olderThan) {
+				report.recordURLs(cpURLs, reportSkipped, "not older than "+olderThan)
 				continue
 			}
 
 			// Skip objects newer than --newer-than parameter if specified
 			if newerThan != "" && isNewer(cpURLs.SourceContent.Time, newerThan) {
+				report.recordURLs(cpURLs, reportSkipped, "not newer than "+newerThan)
 				continue
 			}
 
-			fmt.Fprintln(dataFP, string(jsonData))
+			if err := session.AddEntry(cpURLs.SourceContent.Size, jsonData); err != nil {
+				session.Delete()
+				fatalIf(err.Trace(), "Unable to prepare URL for copying. Error in saving session data.")
+			}
 			if !globalQuiet && !globalJSON {
 				scanBar(cpURLs.SourceContent.URL.String())
 			}
@@ -277,6 +415,7 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 				console.Eraseline()
 			}
 			session.Delete() // If we are interrupted during the URL scanning, we drop the session.
+			errorIf(report.close(), "Unable to write report.")
 			os.Exit(0)
 		}
 	}
@@ -285,20 +424,38 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 	session.Save()
 }
 
-func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) error {
+func doCopySession(session *sessionV9, encKeyDB map[string][]prefixSSEPair) error {
 	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
 
+	// Load the client-side encryption key before any client is
+	// created, sizes of encrypted objects depend on it. Targets of
+	// a copy list are not known, its sources are decrypted.
+	var targetURLs []string
+	if args := session.Header.CommandArgs; session.Header.CommandStringFlags["from-list"] == "" && len(args) > 0 {
+		targetURLs = args[len(args)-1:]
+	}
+	keyFile := session.Header.CommandStringFlags["encrypt-client"]
+	fatalIf(setCSEKey(keyFile, targetURLs...), "Unable to load client-side encryption key `"+keyFile+"`.")
+	fatalIf(setBandwidthLimits(session.Header.CommandStringFlags["limit-upload"], session.Header.CommandStringFlags["limit-download"]),
+		"Unable to set bandwidth limits.")
+
+	// A resumed session adds to the report of the interrupted run.
+	report, err := newTransferReport(session.Header.CommandStringFlags["report"], session.Header.CommandType, session.Header.CommandArgs, session.HasData())
+	fatalIf(err, "Unable to create report.")
+
 	ctx, cancelCopy := context.WithCancel(context.Background())
 	defer cancelCopy()
 	if !session.HasData() {
-		doPrepareCopyURLs(session, trapCh, cancelCopy)
+		doPrepareCopyURLs(session, report, trapCh, cancelCopy)
 	}
 
-	// Prepare URL scanner from session data file.
-	urlScanner := bufio.NewScanner(session.NewDataReader())
-	// isCopied returns true if an object has been already copied
-	// or not. This is useful when we resume from a session.
-	isCopied := isLastFactory(session.Header.LastCopied)
+	tags, err := parseTags(session.Header.CommandStringFlags["tags"])
+	fatalIf(err, "Unable to parse tags.")
+	isVerify := session.Header.CommandBoolFlags["verify"]
+	// Sessions saved before --parallel was added adapt the number of workers.
+	parallelWorkers := session.Header.CommandIntFlags["parallel"]
+	maxParallel := session.Header.CommandIntFlags["max-parallel"]
+	fatalIf(setRetryOptions(session.Header.CommandIntFlags["retry-attempts"], session.Header.CommandStringFlags["retry-backoff"]), "Unable to set retry options.")
 
 	// Store a progress bar or an accounter
 	var pg ProgressReader
@@ -309,11 +466,18 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 	} else {
 		pg = newAccounter(session.Header.TotalBytes)
 	}
+	// Objects copied before the session was resumed are not read again.
+	if progressReader, ok := pg.(*progressBar); ok {
+		progressReader.ProgressBar.Add64(session.Header.ResumeBytes)
+	}
+
+	// Prepare entries from session data file.
+	entryCh := session.Entries()
 
 	var quitCh = make(chan struct{})
 	var statusCh = make(chan URLs)
 
-	parallel, queueCh := newParallelManager(statusCh)
+	parallel, queueCh := newParallelManager(statusCh, parallelWorkers, maxParallel)
 
 	go func() {
 		gracefulStop := func() {
@@ -327,24 +491,33 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 			case <-quitCh:
 				gracefulStop()
 				return
-			default:
-				if !urlScanner.Scan() {
+			case entry, ok := <-entryCh:
+				if !ok {
 					// No more entries, quit immediately
 					gracefulStop()
 					return
 				}
 
-				if e := urlScanner.Err(); e != nil {
+				if entry.Err != nil {
 					// Error while reading. quit immediately
+					errorIf(entry.Err.Trace(), "Unable to read session data.")
 					gracefulStop()
 					return
 				}
 
 				var cpURLs URLs
-				// Unmarshal copyURLs from each line. This expects each line to be
+				// Unmarshal copyURLs from each entry. This expects each entry to be
 				// an entire JSON object.
-				if e := json.Unmarshal([]byte(urlScanner.Text()), &cpURLs); e != nil {
-					errorIf(probe.NewError(e), "Unable to unmarshal %s", urlScanner.Text())
+				if e := json.Unmarshal(entry.Data, &cpURLs); e != nil {
+					errorIf(probe.NewError(e), "Unable to unmarshal %s", string(entry.Data))
+					session.SetEntryStatus(entry.Index, sessionEntryFailed)
+					continue
+				}
+				cpURLs.sessionEntry = entry.Index
+
+				// Verify if previously copied or failed, notify progress bar.
+				if entry.Status != sessionEntryPending {
+					doCopyFake(cpURLs, pg)
 					continue
 				}
 
@@ -354,12 +527,26 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 				// Save totalSize.
 				cpURLs.TotalSize = session.Header.TotalBytes
 
-				// Check and handle storage class if passed in command line args
-				if _, ok := session.Header.CommandStringFlags["storage-class"]; ok {
+				// Check and handle storage class if passed in command line args,
+				// the storage class of an object of a copy list takes precedence.
+				if storageClass := session.Header.CommandStringFlags["storage-class"]; storageClass != "" && cpURLs.TargetContent.Metadata["X-Amz-Storage-Class"] == "" {
 					if cpURLs.TargetContent.Metadata == nil {
 						cpURLs.TargetContent.Metadata = make(map[string]string)
 					}
-					cpURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = session.Header.CommandStringFlags["storage-class"]
+					cpURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = storageClass
+				}
+
+				// Check and handle compression if passed in command line args
+				if compression := session.Header.CommandStringFlags["compress"]; compression != "" {
+					if cpURLs.TargetContent.Metadata == nil {
+						cpURLs.TargetContent.Metadata = make(map[string]string)
+					}
+					cpURLs.TargetContent.Metadata[compressionMeta] = compression
+				}
+
+				// Check and handle tags if passed in command line args
+				if len(tags) != 0 && len(cpURLs.TargetContent.Tags) == 0 {
+					cpURLs.TargetContent.Tags = tags
 				}
 
 				//	metaMap, metaSet := session.Header.UserMetaData
@@ -371,25 +558,25 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 						cpURLs.TargetContent.UserMetadata = make(map[string]string)
 					}
 					for metaDataKey, metaDataVal := range session.Header.UserMetaData {
-						cpURLs.TargetContent.UserMetadata[metaDataKey] = metaDataVal
+						if _, ok := cpURLs.TargetContent.UserMetadata[metaDataKey]; !ok {
+							cpURLs.TargetContent.UserMetadata[metaDataKey] = metaDataVal
+						}
 					}
 				}
 
-				// Verify if previously copied, notify progress bar.
-				if isCopied(cpURLs.SourceContent.URL.String()) {
-					queueCh <- func() URLs {
-						return doCopyFake(cpURLs, pg)
-					}
-				} else {
-					queueCh <- func() URLs {
-						return doCopy(ctx, cpURLs, pg, encKeyDB)
+				keys, keyErr := copyListEncKeys(encKeyDB, cpURLs)
+				queueCh <- func() URLs {
+					if keyErr != nil {
+						return cpURLs.WithError(keyErr)
 					}
+					return doCopy(ctx, cpURLs, pg, keys, isVerify, parallel.reportThrottled)
 				}
 			}
 		}
 	}()
 
 	var retErr error
+	var corrupted []string
 
 loop:
 	for {
@@ -401,6 +588,7 @@ loop:
 			if !globalQuiet && !globalJSON {
 				console.Eraseline()
 			}
+			errorIf(report.close(), "Unable to write report.")
 			session.CloseAndDie()
 		case cpURLs, ok := <-statusCh:
 			// Status channel is closed, we should return.
@@ -408,9 +596,10 @@ loop:
 				break loop
 			}
 			if cpURLs.Error == nil {
-				session.Header.LastCopied = cpURLs.SourceContent.URL.String()
-				session.Save()
+				report.recordURLs(cpURLs, reportCopied, "")
+				session.SetEntryStatus(cpURLs.sessionEntry, sessionEntryDone)
 			} else {
+				report.recordURLs(cpURLs, reportFailed, "")
 
 				// Set exit status for any copy error
 				retErr = exitStatus(globalErrorExitStatus)
@@ -422,12 +611,20 @@ loop:
 				}
 				errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
 					fmt.Sprintf("Failed to copy `%s`.", cpURLs.SourceContent.URL.String()))
+				// Objects failing verification are summarized at the end.
+				if isErrCorrupted(cpURLs.Error) {
+					corrupted = append(corrupted, cpURLs.SourceContent.URL.String())
+					session.SetEntryStatus(cpURLs.sessionEntry, sessionEntryFailed)
+					continue loop
+				}
 				if isErrIgnored(cpURLs.Error) {
+					session.SetEntryStatus(cpURLs.sessionEntry, sessionEntryFailed)
 					continue loop
 				}
 				// For critical errors we should exit. Session
 				// can be resumed after the user figures out
 				// the  problem.
+				errorIf(report.close(), "Unable to write report.")
 				session.CloseAndDie()
 			}
 		}
@@ -442,6 +639,13 @@ loop:
 			printMsg(accntReader.Stat())
 		}
 	}
+	if len(corrupted) > 0 {
+		printMsg(verifySummaryMessage{Failed: corrupted})
+	}
+	if err = report.close(); err != nil {
+		errorIf(err.Trace(), "Unable to write report.")
+		retErr = exitStatus(globalErrorExitStatus)
+	}
 
 	return retErr
 }
@@ -478,6 +682,7 @@ func mainCopy(ctx *cli.Context) error {
 
 	// Additional command speific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Retry", color.New(color.FgYellow))
 
 	recursive := ctx.Bool("recursive")
 	olderThan := ctx.String("older-than")
@@ -487,16 +692,47 @@ func mainCopy(ctx *cli.Context) error {
 	if key := ctx.String("encrypt-key"); key != "" {
 		sseKeys = key
 	}
+	sseKMS := os.Getenv("MC_ENCRYPT_KMS")
+	if key := ctx.String("encrypt-kms"); key != "" {
+		sseKMS = key
+	}
 	sse := ctx.String("encrypt")
 
-	session := newSessionV8()
+	session := newSessionV9()
 	session.Header.CommandType = "cp"
 	session.Header.CommandBoolFlags["recursive"] = recursive
+	session.Header.CommandBoolFlags["verify"] = ctx.Bool("verify")
 	session.Header.CommandStringFlags["older-than"] = olderThan
 	session.Header.CommandStringFlags["newer-than"] = newerThan
 	session.Header.CommandStringFlags["storage-class"] = storageClass
 	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
+	session.Header.CommandStringFlags["encrypt-kms"] = sseKMS
 	session.Header.CommandStringFlags["encrypt"] = sse
+	session.Header.CommandStringFlags["encrypt-client"] = ctx.String("encrypt-client")
+	session.Header.CommandStringFlags["compress"] = ctx.String("compress")
+	session.Header.CommandStringFlags["version-id"] = ctx.String("version-id")
+	session.Header.CommandStringFlags["tags"] = ctx.String("tags")
+	session.Header.CommandIntFlags["parallel"] = ctx.Int("parallel")
+	session.Header.CommandIntFlags["max-parallel"] = ctx.Int("max-parallel")
+	session.Header.CommandIntFlags["retry-attempts"] = ctx.Int("retry-attempts")
+	session.Header.CommandStringFlags["retry-backoff"] = ctx.String("retry-backoff")
+	session.Header.CommandStringFlags["limit-upload"] = ctx.String("limit-upload")
+	session.Header.CommandStringFlags["limit-download"] = ctx.String("limit-download")
+	session.Header.CommandStringFlags["exclude-from"] = ctx.String("exclude-from")
+	session.Header.CommandStringFlags["report"] = ctx.String("report")
+	session.Header.CommandStringFlags["from-list"] = ctx.String("from-list")
+	if includes := ctx.StringSlice("include"); len(includes) > 0 {
+		includeJSON, e := json.Marshal(includes)
+		fatalIf(probe.NewError(e), "Unable to save include options.")
+		session.Header.CommandStringFlags["include"] = string(includeJSON)
+	}
+	// Save rewind as an absolute time, a resumed session has to
+	// copy the same versions as the interrupted one.
+	timeRef, err := parseRewind(ctx.String("rewind"))
+	fatalIf(err, "Unable to parse rewind `"+ctx.String("rewind")+"`.")
+	if !timeRef.IsZero() {
+		session.Header.CommandStringFlags["rewind"] = timeRef.Format(time.RFC3339Nano)
+	}
 	session.Header.UserMetaData = userMetaMap
 
 	var e error
@@ -18,29 +18,74 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
 )
 
 func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
-	if len(ctx.Args()) < 2 {
+	fromList := ctx.String("from-list")
+	if fromList != "" {
+		// Sources and targets are read from the list.
+		if ctx.Args().Present() {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--from-list cannot be used with source and target arguments.")
+		}
+	} else if len(ctx.Args()) < 2 {
 		cli.ShowCommandHelpAndExit(ctx, "cp", 1) // last argument is exit code.
 	}
 
 	// extract URLs.
 	URLs := ctx.Args()
-	if len(URLs) < 2 {
+	if len(URLs) < 2 && fromList == "" {
 		fatalIf(errDummy().Trace(ctx.Args()...), fmt.Sprintf("Unable to parse source and target arguments."))
 	}
 
-	srcURLs := URLs[:len(URLs)-1]
-	tgtURL := URLs[len(URLs)-1]
+	var srcURLs []string
+	var tgtURL string
+	if len(URLs) >= 2 {
+		srcURLs = URLs[:len(URLs)-1]
+		tgtURL = URLs[len(URLs)-1]
+	}
 	isRecursive := ctx.Bool("recursive")
+	versionID := ctx.String("version-id")
+	timeRef, err := parseRewind(ctx.String("rewind"))
+	fatalIf(err, "Unable to parse rewind `"+ctx.String("rewind")+"`.")
+
+	if versionID != "" && (len(srcURLs) != 1 || isRecursive) {
+		fatalIf(errInvalidArgument().Trace(srcURLs...), "--version-id requires exactly one source object and cannot be used with --recursive.")
+	}
+	if _, err = parseTags(ctx.String("tags")); err != nil {
+		fatalIf(err.Trace(srcURLs...), "Unable to parse tags.")
+	}
+	if err = checkCompression(ctx.String("compress")); err != nil {
+		fatalIf(err.Trace(srcURLs...), "Unable to compress objects.")
+	}
+	if ctx.Int("parallel") < 0 || ctx.Int("max-parallel") < 0 {
+		fatalIf(errInvalidArgument().Trace(srcURLs...), "--parallel and --max-parallel cannot be negative.")
+	}
+	if _, err = parseRetryOptions(ctx.Int("retry-attempts"), ctx.String("retry-backoff")); err != nil {
+		fatalIf(err.Trace(srcURLs...), "Unable to set retry options.")
+	}
+	for _, limit := range []string{ctx.String("limit-upload"), ctx.String("limit-download")} {
+		if err = checkBandwidthLimit(limit); err != nil {
+			fatalIf(err.Trace(srcURLs...), "Unable to set bandwidth limits.")
+		}
+	}
+	if versionID != "" && !timeRef.IsZero() {
+		fatalIf(errInvalidArgument().Trace(srcURLs...), "--version-id cannot be used with --rewind.")
+	}
+	if _, err = newExcludeRules(ctx.String("exclude-from"), ctx.StringSlice("include")); err != nil {
+		fatalIf(err.Trace(srcURLs...), "Unable to read exclude patterns.")
+	}
+	if fromList != "" {
+		checkCopyListSyntax(fromList, isRecursive)
+		return
+	}
 
 	// Verify if source(s) exists.
 	for _, srcURL := range srcURLs {
-		_, _, err := url2Stat(srcURL, false, encKeyDB)
+		_, _, err := url2StatVersion(srcURL, versionID, timeRef, encKeyDB)
 		if err != nil {
 			console.Fatalf("Unable to validate source %s\n", srcURL)
 		}
@@ -55,18 +100,18 @@ func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	}
 
 	// Guess CopyURLsType based on source and target URLs.
-	copyURLsType, err := guessCopyURLType(srcURLs, tgtURL, isRecursive, encKeyDB)
+	copyURLsType, err := guessCopyURLType(srcURLs, tgtURL, versionID, timeRef, isRecursive, encKeyDB)
 	if err != nil {
 		fatalIf(errInvalidArgument().Trace(), "Unable to guess the type of copy operation.")
 	}
 
 	switch copyURLsType {
 	case copyURLsTypeA: // File -> File.
-		checkCopySyntaxTypeA(srcURLs, tgtURL, encKeyDB)
+		checkCopySyntaxTypeA(srcURLs, tgtURL, versionID, timeRef, encKeyDB)
 	case copyURLsTypeB: // File -> Folder.
-		checkCopySyntaxTypeB(srcURLs, tgtURL, encKeyDB)
+		checkCopySyntaxTypeB(srcURLs, tgtURL, versionID, timeRef, encKeyDB)
 	case copyURLsTypeC: // Folder... -> Folder.
-		checkCopySyntaxTypeC(srcURLs, tgtURL, isRecursive, encKeyDB)
+		checkCopySyntaxTypeC(srcURLs, tgtURL, timeRef, isRecursive, encKeyDB)
 	case copyURLsTypeD: // File1...FileN -> Folder.
 		checkCopySyntaxTypeD(srcURLs, tgtURL, encKeyDB)
 	default:
@@ -75,13 +120,13 @@ func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 }
 
 // checkCopySyntaxTypeA verifies if the source and target are valid file arguments.
-func checkCopySyntaxTypeA(srcURLs []string, tgtURL string, keys map[string][]prefixSSEPair) {
+func checkCopySyntaxTypeA(srcURLs []string, tgtURL, versionID string, timeRef time.Time, keys map[string][]prefixSSEPair) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
 	}
 	srcURL := srcURLs[0]
-	_, srcContent, err := url2Stat(srcURL, false, keys)
+	_, srcContent, err := url2StatVersion(srcURL, versionID, timeRef, keys)
 	fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 
 	if !srcContent.Type.IsRegular() {
@@ -90,13 +135,13 @@ func checkCopySyntaxTypeA(srcURLs []string, tgtURL string, keys map[string][]pre
 }
 
 // checkCopySyntaxTypeB verifies if the source is a valid file and target is a valid folder.
-func checkCopySyntaxTypeB(srcURLs []string, tgtURL string, keys map[string][]prefixSSEPair) {
+func checkCopySyntaxTypeB(srcURLs []string, tgtURL, versionID string, timeRef time.Time, keys map[string][]prefixSSEPair) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
 	}
 	srcURL := srcURLs[0]
-	_, srcContent, err := url2Stat(srcURL, false, keys)
+	_, srcContent, err := url2StatVersion(srcURL, versionID, timeRef, keys)
 	fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 
 	if !srcContent.Type.IsRegular() {
@@ -112,7 +157,7 @@ func checkCopySyntaxTypeB(srcURLs []string, tgtURL string, keys map[string][]pre
 }
 
 // checkCopySyntaxTypeC verifies if the source is a valid recursive dir and target is a valid folder.
-func checkCopySyntaxTypeC(srcURLs []string, tgtURL string, isRecursive bool, keys map[string][]prefixSSEPair) {
+func checkCopySyntaxTypeC(srcURLs []string, tgtURL string, timeRef time.Time, isRecursive bool, keys map[string][]prefixSSEPair) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
@@ -126,11 +171,12 @@ func checkCopySyntaxTypeC(srcURLs []string, tgtURL string, isRecursive bool, key
 	}
 
 	for _, srcURL := range srcURLs {
-		c, srcContent, err := url2Stat(srcURL, false, keys)
+		c, srcContent, err := url2StatVersion(srcURL, "", timeRef, keys)
 		// incomplete uploads are not necessary for copy operation, no need to verify for them.
 		isIncomplete := false
 		if err != nil {
-			if !isURLPrefixExists(srcURL, isIncomplete) {
+			// A rewound source has to exist at the given time.
+			if !timeRef.IsZero() || !isURLPrefixExists(srcURL, isIncomplete) {
 				fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 			}
 			// No more check here, continue to the next source url
@@ -162,3 +208,14 @@ func checkCopySyntaxTypeD(srcURLs []string, tgtURL string, keys map[string][]pre
 		}
 	}
 }
+
+// checkCopyListSyntax verifies the options of a copy of the objects of a
+// list, every object of the list is checked when it is copied.
+func checkCopyListSyntax(fromList string, isRecursive bool) {
+	if isRecursive {
+		fatalIf(errInvalidArgument().Trace(fromList), "--from-list cannot be used with --recursive.")
+	}
+	list, err := openCopyList(fromList, true)
+	fatalIf(err.Trace(fromList), "Unable to read the list of objects to copy.")
+	list.close()
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 )
@@ -50,10 +51,10 @@ const (
 
 // guessCopyURLType guesses the type of clientURL. This approach all allows prepareURL
 // functions to accurately report failure causes.
-func guessCopyURLType(sourceURLs []string, targetURL string, isRecursive bool, keys map[string][]prefixSSEPair) (copyURLsType, *probe.Error) {
+func guessCopyURLType(sourceURLs []string, targetURL, versionID string, timeRef time.Time, isRecursive bool, keys map[string][]prefixSSEPair) (copyURLsType, *probe.Error) {
 	if len(sourceURLs) == 1 { // 1 Source, 1 Target
 		sourceURL := sourceURLs[0]
-		_, sourceContent, err := url2Stat(sourceURL, false, keys)
+		_, sourceContent, err := url2StatVersion(sourceURL, versionID, timeRef, keys)
 		if err != nil {
 			return copyURLsTypeInvalid, err
 		}
@@ -82,13 +83,13 @@ func guessCopyURLType(sourceURLs []string, targetURL string, isRecursive bool, k
 
 // SINGLE SOURCE - Type A: copy(f, f) -> copy(f, f)
 // prepareCopyURLsTypeA - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeA(sourceURL string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func prepareCopyURLsTypeA(sourceURL, versionID string, timeRef time.Time, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
 	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
 
-	_, sourceContent, err := url2Stat(sourceURL, false, encKeyDB)
+	_, sourceContent, err := url2StatVersion(sourceURL, versionID, timeRef, encKeyDB)
 	if err != nil {
 		// Source does not exist or insufficient privileges.
 		return URLs{Error: err.Trace(sourceURL)}
@@ -115,13 +116,13 @@ func makeCopyContentTypeA(sourceAlias string, sourceContent *clientContent, targ
 
 // SINGLE SOURCE - Type B: copy(f, d) -> copy(f, d/f) -> A
 // prepareCopyURLsTypeB - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeB(sourceURL string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func prepareCopyURLsTypeB(sourceURL, versionID string, timeRef time.Time, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
 	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
 
-	_, sourceContent, err := url2Stat(sourceURL, false, encKeyDB)
+	_, sourceContent, err := url2StatVersion(sourceURL, versionID, timeRef, encKeyDB)
 	if err != nil {
 		// Source does not exist or insufficient privileges.
 		return URLs{Error: err.Trace(sourceURL)}
@@ -149,7 +150,7 @@ func makeCopyContentTypeB(sourceAlias string, sourceContent *clientContent, targ
 
 // SINGLE SOURCE - Type C: copy(d1..., d2) -> []copy(d1/f, d1/d2/f) -> []A
 // prepareCopyRecursiveURLTypeC - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeC(sourceURL, targetURL string, timeRef time.Time, isRecursive bool, excludeRules *excludeRules, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
@@ -163,6 +164,7 @@ func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive bool, encKeyD
 			copyURLsCh <- URLs{Error: err.Trace(sourceURL)}
 			return
 		}
+		sourceClient = newExcludeClient(newRewindClient(sourceClient, timeRef), excludeRules)
 
 		isIncomplete := false
 		for sourceContent := range sourceClient.List(isRecursive, isIncomplete, DirNone) {
@@ -199,12 +201,12 @@ func makeCopyContentTypeC(sourceAlias string, sourceURL clientURL, sourceContent
 
 // MULTI-SOURCE - Type D: copy([](f|d...), d) -> []B
 // prepareCopyURLsTypeE - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, timeRef time.Time, isRecursive bool, excludeRules *excludeRules, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 		for _, sourceURL := range sourceURLs {
-			for cpURLs := range prepareCopyURLsTypeC(sourceURL, targetURL, isRecursive, encKeyDB) {
+			for cpURLs := range prepareCopyURLsTypeC(sourceURL, targetURL, timeRef, isRecursive, excludeRules, encKeyDB) {
 				copyURLsCh <- cpURLs
 			}
 		}
@@ -212,25 +214,37 @@ func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive boo
 	return copyURLsCh
 }
 
-// prepareCopyURLs - prepares target and source clientURLs for copying.
-func prepareCopyURLs(sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+// prepareCopyURLs - prepares target and source clientURLs for copying,
+// versionID selects a specific version of a single source object and
+// a non zero timeRef copies sources as they were at that time. Objects
+// of source folders matching excludeRules are skipped. If fromList is
+// set, the sources and targets are read from the copy list at fromList
+// instead.
+func prepareCopyURLs(sourceURLs []string, targetURL, versionID string, timeRef time.Time, isRecursive bool, excludeRules *excludeRules, fromList string, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs, encKeyDB map[string][]prefixSSEPair) {
 		defer close(copyURLsCh)
-		cpType, err := guessCopyURLType(sourceURLs, targetURL, isRecursive, encKeyDB)
+		if fromList != "" {
+			for cURLs := range prepareCopyURLsFromList(fromList, timeRef, encKeyDB) {
+				copyURLsCh <- cURLs
+			}
+			return
+		}
+
+		cpType, err := guessCopyURLType(sourceURLs, targetURL, versionID, timeRef, isRecursive, encKeyDB)
 		fatalIf(err.Trace(), "Unable to guess the type of copy operation.")
 
 		switch cpType {
 		case copyURLsTypeA:
-			copyURLsCh <- prepareCopyURLsTypeA(sourceURLs[0], targetURL, encKeyDB)
+			copyURLsCh <- prepareCopyURLsTypeA(sourceURLs[0], versionID, timeRef, targetURL, encKeyDB)
 		case copyURLsTypeB:
-			copyURLsCh <- prepareCopyURLsTypeB(sourceURLs[0], targetURL, encKeyDB)
+			copyURLsCh <- prepareCopyURLsTypeB(sourceURLs[0], versionID, timeRef, targetURL, encKeyDB)
 		case copyURLsTypeC:
-			for cURLs := range prepareCopyURLsTypeC(sourceURLs[0], targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeC(sourceURLs[0], targetURL, timeRef, isRecursive, excludeRules, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		case copyURLsTypeD:
-			for cURLs := range prepareCopyURLsTypeD(sourceURLs, targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeD(sourceURLs, targetURL, timeRef, isRecursive, excludeRules, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		default:
@@ -29,7 +29,12 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "checksum",
+			Usage: "compare objects of the same size by checksum instead of modification time",
+		},
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -50,12 +55,15 @@ FLAGS:
   {{end}}
 DESCRIPTION:
   Diff only calculates differences in object name, size and time.
-  It *DOES NOT* compare objects' contents.
+  It *DOES NOT* compare objects' contents, unless --checksum is given.
+  With --checksum objects of the same size are compared by their ETags
+  where these are checksums of the data, and by reading and hashing
+  the data otherwise, e.g. for encrypted objects.
 
 LEGEND:
     > - object is only in source.
     < - object is only in destination.
-    ! - newer object is in source.
+    ! - newer object is in source, or content differs with --checksum.
 
 EXAMPLES:
   1. Compare a local folder with a folder on Amazon S3 cloud storage.
@@ -63,6 +71,9 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      $ {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Compare a local folder with a folder on Amazon S3 cloud storage by content.
+     $ {{.HelpName}} --checksum ~/Photos s3/mybucket/Photos
 `,
 }
 
@@ -91,6 +102,8 @@ func (d diffMessage) String() string {
 		msg = console.Colorize("DiffSize", "! "+d.SecondURL)
 	case differInTime:
 		msg = console.Colorize("DiffTime", "! "+d.SecondURL)
+	case differInContent:
+		msg = console.Colorize("DiffContent", "! "+d.SecondURL)
 	default:
 		fatalIf(errDummy().Trace(d.FirstURL, d.SecondURL),
 			"Unhandled difference between `"+d.FirstURL+"` and `"+d.SecondURL+"`.")
@@ -104,7 +117,7 @@ func (d diffMessage) JSON() string {
 	d.Status = "success"
 	diffJSONBytes, e := json.MarshalIndent(d, "", " ")
 	fatalIf(probe.NewError(e),
-		"Unable to marshal diff message `"+d.FirstURL+"`, `"+d.SecondURL+"` and `"+string(d.Diff)+"`.")
+		"Unable to marshal diff message `"+d.FirstURL+"`, `"+d.SecondURL+"` and `"+d.Diff.String()+"`.")
 	return string(diffJSONBytes)
 }
 
@@ -146,8 +159,9 @@ func checkDiffSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	}
 }
 
-// doDiffMain runs the diff.
-func doDiffMain(firstURL, secondURL string) error {
+// doDiffMain runs the diff, with isChecksum objects of the same size
+// are compared by content.
+func doDiffMain(firstURL, secondURL string, isChecksum bool, encKeyDB map[string][]prefixSSEPair) error {
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -174,8 +188,13 @@ func doDiffMain(firstURL, secondURL string) error {
 			fmt.Sprintf("Failed to diff '%s' and '%s'", firstURL, secondURL))
 	}
 
+	var compare contentComparator
+	if isChecksum {
+		compare = newChecksumComparator(firstAlias, secondAlias, encKeyDB)
+	}
+
 	// Diff first and second urls.
-	for diffMsg := range objectDifference(firstClient, secondClient, firstURL, secondURL) {
+	for diffMsg := range objectDifference(firstClient, secondClient, firstURL, secondURL, compare) {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
@@ -203,10 +222,11 @@ func mainDiff(ctx *cli.Context) error {
 	console.SetColor("DiffType", color.New(color.FgMagenta))
 	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffTime", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffContent", color.New(color.FgYellow, color.Bold))
 
 	URLs := ctx.Args()
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
 
-	return doDiffMain(firstURL, secondURL)
+	return doDiffMain(firstURL, secondURL, ctx.Bool("checksum"), encKeyDB)
 }
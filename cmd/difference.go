@@ -29,12 +29,13 @@ import (
 type differType int
 
 const (
-	differInNone   differType = iota // does not differ
-	differInSize                     // differs in size
-	differInTime                     // differs in time
-	differInType                     // differs in type, exfile/directory
-	differInFirst                    // only in source (FIRST)
-	differInSecond                   // only in target (SECOND)
+	differInNone    differType = iota // does not differ
+	differInSize                      // differs in size
+	differInTime                      // differs in time
+	differInType                      // differs in type, exfile/directory
+	differInFirst                     // only in source (FIRST)
+	differInSecond                    // only in target (SECOND)
+	differInContent                   // differs in content
 )
 
 func (d differType) String() string {
@@ -51,21 +52,25 @@ func (d differType) String() string {
 		return "only-in-first"
 	case differInSecond:
 		return "only-in-second"
+	case differInContent:
+		return "content"
 	}
 	return "unknown"
 }
 
-func objectDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string) (diffCh chan diffMessage) {
-	return difference(sourceClnt, targetClnt, sourceURL, targetURL, true, false, DirNone)
+// objectDifference - an optional compare function tells regular
+// files of the same size apart by content instead of by time.
+func objectDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string, compare contentComparator) (diffCh chan diffMessage) {
+	return difference(sourceClnt, targetClnt, sourceURL, targetURL, true, false, DirNone, compare)
 }
 
 func dirDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string) (diffCh chan diffMessage) {
-	return difference(sourceClnt, targetClnt, sourceURL, targetURL, false, true, DirFirst)
+	return difference(sourceClnt, targetClnt, sourceURL, targetURL, false, true, DirFirst, nil)
 }
 
 // objectDifference function finds the difference between all objects
 // recursively in sorted order from source and target.
-func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRecursive, returnSimilar bool, dirOpt DirOpt) (diffCh chan diffMessage) {
+func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRecursive, returnSimilar bool, dirOpt DirOpt, compare contentComparator) (diffCh chan diffMessage) {
 	var (
 		srcEOF, tgtEOF       bool
 		srcOk, tgtOk         bool
@@ -178,6 +183,7 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 					}
 					continue
 				}
+				differs := true
 				if (srcType.IsRegular() && tgtType.IsRegular()) && srcSize != tgtSize {
 					// Regular files differing in size.
 					diffCh <- diffMessage{
@@ -187,6 +193,22 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 						firstContent:  srcCtnt,
 						secondContent: tgtCtnt,
 					}
+				} else if compare != nil && srcType.IsRegular() && tgtType.IsRegular() {
+					// Regular files of the same size, compare their content.
+					same, err := compare(srcCtnt, tgtCtnt)
+					if err != nil {
+						diffCh <- diffMessage{Error: err.Trace(srcCtnt.URL.String(), tgtCtnt.URL.String())}
+					} else if !same {
+						diffCh <- diffMessage{
+							FirstURL:      srcCtnt.URL.String(),
+							SecondURL:     tgtCtnt.URL.String(),
+							Diff:          differInContent,
+							firstContent:  srcCtnt,
+							secondContent: tgtCtnt,
+						}
+					} else {
+						differs = false
+					}
 				} else if srcTime.After(tgtTime) {
 					// Regular files differing in timestamp.
 					diffCh <- diffMessage{
@@ -196,9 +218,11 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 						firstContent:  srcCtnt,
 						secondContent: tgtCtnt,
 					}
+				} else {
+					differs = false
 				}
 				// No differ
-				if returnSimilar {
+				if returnSimilar && !differs {
 					diffCh <- diffMessage{
 						FirstURL:      srcCtnt.URL.String(),
 						SecondURL:     tgtCtnt.URL.String(),
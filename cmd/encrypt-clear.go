@@ -0,0 +1,91 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	encryptClearFlags = []cli.Flag{}
+)
+
+var encryptClearCmd = cli.Command{
+	Name:   "clear",
+	Usage:  "clear the default encryption of a bucket",
+	Action: mainEncryptClear,
+	Before: setGlobalsFromContext,
+	Flags:  append(encryptClearFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+  Objects already stored in TARGET remain encrypted.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Stop encrypting new objects of a bucket by default
+     $ {{.HelpName}} myminio/mybucket
+
+`,
+}
+
+// checkEncryptClearSyntax - validate all the passed arguments
+func checkEncryptClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+}
+
+// encryptClearMessage container
+type encryptClearMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+// JSON jsonified encrypt clear message.
+func (e encryptClearMessage) JSON() string {
+	e.Status = "success"
+	encryptClearMessageJSONBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+	return string(encryptClearMessageJSONBytes)
+}
+
+func (e encryptClearMessage) String() string {
+	return console.Colorize("Encrypt", "Default encryption of `"+e.URL+"` cleared.")
+}
+
+func mainEncryptClear(ctx *cli.Context) error {
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	checkEncryptClearSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	err := newEncryptClient(urlStr).SetBucketEncryption("", "")
+	fatalIf(err.Trace(urlStr), "Unable to clear the default encryption of `"+urlStr+"`.")
+
+	printMsg(encryptClearMessage{URL: urlStr})
+	return nil
+}
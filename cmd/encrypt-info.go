@@ -0,0 +1,99 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	encryptInfoFlags = []cli.Flag{}
+)
+
+var encryptInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show the default encryption of a bucket",
+	Action: mainEncryptInfo,
+	Before: setGlobalsFromContext,
+	Flags:  append(encryptInfoFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Show the default encryption of a bucket
+     $ {{.HelpName}} myminio/mybucket
+
+`,
+}
+
+// checkEncryptInfoSyntax - validate all the passed arguments
+func checkEncryptInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+// encryptInfoMessage container
+type encryptInfoMessage struct {
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"keyId,omitempty"`
+}
+
+// JSON jsonified encrypt info message.
+func (e encryptInfoMessage) JSON() string {
+	e.Status = "success"
+	encryptInfoMessageJSONBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+	return string(encryptInfoMessageJSONBytes)
+}
+
+func (e encryptInfoMessage) String() string {
+	if e.Algorithm == "" {
+		return console.Colorize("URL", e.URL+": ") + "No default encryption"
+	}
+	return console.Colorize("URL", e.URL+": ") + console.Colorize("Algorithm", sseAlgorithmString(e.Algorithm, e.KeyID))
+}
+
+func mainEncryptInfo(ctx *cli.Context) error {
+	console.SetColor("URL", color.New(color.Bold))
+	console.SetColor("Algorithm", color.New(color.FgCyan, color.Bold))
+
+	checkEncryptInfoSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	algorithm, keyID, err := newEncryptClient(urlStr).GetBucketEncryption()
+	fatalIf(err.Trace(urlStr), "Unable to get the default encryption of `"+urlStr+"`.")
+
+	printMsg(encryptInfoMessage{
+		URL:       urlStr,
+		Algorithm: algorithm,
+		KeyID:     keyID,
+	})
+	return nil
+}
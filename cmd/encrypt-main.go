@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var (
+	encryptFlags = []cli.Flag{}
+)
+
+var encryptCmd = cli.Command{
+	Name:            "encrypt",
+	Usage:           "manage default encryption of buckets and rotate SSE-C keys",
+	HideHelpCommand: true,
+	Action:          mainEncrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           append(encryptFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		encryptSetCmd,
+		encryptInfoCmd,
+		encryptClearCmd,
+		encryptRotateCmd,
+	},
+}
+
+// mainEncrypt is the handle for "mc encrypt" command.
+func mainEncrypt(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "info", "clear", "rotate" have their own main.
+}
+
+// newEncryptClient - returns the S3 client of the bucket at urlStr.
+func newEncryptClient(urlStr string) *s3Client {
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(urlStr), "The provided url doesn't point to a S3 server.")
+	}
+	if _, object := s3Client.url2BucketAndObject(); object != "" {
+		fatalIf(errInvalidArgument().Trace(urlStr), "Default encryption is set on buckets, not on objects.")
+	}
+	return s3Client
+}
+
+// sseAlgorithmString - printable form of a default encryption.
+func sseAlgorithmString(algorithm, keyID string) string {
+	switch algorithm {
+	case "":
+		return "none"
+	case sseAlgorithmAES256:
+		return "SSE-S3"
+	case sseAlgorithmKMS:
+		if keyID == "" {
+			return "SSE-KMS"
+		}
+		return "SSE-KMS (" + keyID + ")"
+	}
+	return algorithm
+}
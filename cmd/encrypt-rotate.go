@@ -0,0 +1,388 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+var (
+	encryptRotateFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "old-key",
+			Usage: "current SSE-C key of the objects, 32 bytes long",
+		},
+		cli.StringFlag{
+			Name:  "new-key",
+			Usage: "new SSE-C key of the objects, 32 bytes long",
+		},
+		cli.BoolFlag{
+			Name:  "fake",
+			Usage: "perform a fake rotation, only check that objects can be read with --old-key",
+		},
+	}
+)
+
+var encryptRotateCmd = cli.Command{
+	Name:   "rotate",
+	Usage:  "re-encrypt objects from one SSE-C key to another",
+	Action: mainEncryptRotate,
+	Before: setGlobalsFromContext,
+	Flags:  append(encryptRotateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --old-key KEY --new-key KEY TARGET
+
+  All objects under TARGET are re-encrypted on the server, their data
+  is not downloaded. On versioned buckets, each rotated object gets a
+  new version and older versions keep the old key. An interrupted
+  rotation can be resumed with "mc session resume".
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Rotate the SSE-C key of all objects under a prefix
+     $ {{.HelpName}} --old-key 32byteslongsecretkeymustbegiven1 --new-key 32byteslongsecretkeymustbegiven2 myminio/mybucket/finance/
+
+   2. Check that all objects under a prefix can be rotated, without rotating them
+     $ {{.HelpName}} --fake --old-key 32byteslongsecretkeymustbegiven1 --new-key 32byteslongsecretkeymustbegiven2 myminio/mybucket/finance/
+
+`,
+}
+
+// parseSSECKey - parse a SSE-C key given on command line.
+func parseSSECKey(key string) (encrypt.ServerSide, *probe.Error) {
+	if len(key) != 32 {
+		return nil, probe.NewError(errors.New("SSE-C key should be 32 bytes long"))
+	}
+	sse, e := encrypt.NewSSEC([]byte(key))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return sse, nil
+}
+
+// checkEncryptRotateSyntax - validate all the passed arguments
+func checkEncryptRotateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "rotate", 1) // last argument is exit code
+	}
+	oldKey, newKey := ctx.String("old-key"), ctx.String("new-key")
+	if oldKey == "" || newKey == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Both --old-key and --new-key are required.")
+	}
+	if oldKey == newKey {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--old-key and --new-key should be different.")
+	}
+	_, err := parseSSECKey(oldKey)
+	fatalIf(err, "Unable to parse --old-key.")
+	_, err = parseSSECKey(newKey)
+	fatalIf(err, "Unable to parse --new-key.")
+
+	urlStr := ctx.Args().First()
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+	if _, ok := client.(*s3Client); !ok {
+		fatalIf(errDummy().Trace(urlStr), "The provided url doesn't point to a S3 server.")
+	}
+}
+
+// encryptRotateMessage container
+type encryptRotateMessage struct {
+	Status     string `json:"status"`
+	URL        string `json:"url"`
+	Size       int64  `json:"size"`
+	TotalCount int64  `json:"totalCount,omitempty"`
+	TotalSize  int64  `json:"totalSize,omitempty"`
+	Fake       bool   `json:"fake,omitempty"`
+}
+
+// JSON jsonified encrypt rotate message.
+func (e encryptRotateMessage) JSON() string {
+	e.Status = "success"
+	encryptRotateMessageJSONBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+	return string(encryptRotateMessageJSONBytes)
+}
+
+func (e encryptRotateMessage) String() string {
+	if e.Fake {
+		return console.Colorize("Rotate", fmt.Sprintf("`%s` can be rotated.", e.URL))
+	}
+	return console.Colorize("Rotate", fmt.Sprintf("`%s` -> new key", e.URL))
+}
+
+// doPrepareRotateURLs - list the objects to rotate into the session data file.
+func doPrepareRotateURLs(session *sessionV9, trapCh <-chan bool) {
+	urlStr := session.Header.CommandArgs[0]
+	clnt, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+	alias, _, _ := mustExpandAlias(urlStr)
+
+	// Reset the session data file to store the listed URLs.
+	if err = session.ResetData(); err != nil {
+		session.Delete()
+		fatalIf(err.Trace(), "Unable to prepare session data.")
+	}
+
+	var scanBar scanBarFunc
+	if !globalQuiet && !globalJSON { // set up progress bar
+		scanBar = scanBarFactory()
+	}
+
+	var totalBytes int64
+	var totalObjects int64
+	for content := range clnt.List(true, false, DirNone) {
+		select {
+		case <-trapCh:
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			session.Delete() // If we are interrupted during the URL scanning, we drop the session.
+			os.Exit(0)
+		default:
+		}
+		if content.Err != nil {
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			errorIf(content.Err.Trace(urlStr), "Unable to list `"+urlStr+"`.")
+			continue
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		rotateURLs := URLs{
+			SourceAlias:   alias,
+			SourceContent: content,
+			TargetAlias:   alias,
+			TargetContent: content,
+		}
+		jsonData, e := json.Marshal(rotateURLs)
+		if e != nil {
+			session.Delete()
+			fatalIf(probe.NewError(e), "Unable to prepare URL for rotation. Error in JSON marshaling.")
+		}
+		if err := session.AddEntry(content.Size, jsonData); err != nil {
+			session.Delete()
+			fatalIf(err.Trace(), "Unable to prepare URL for rotation. Error in saving session data.")
+		}
+		if !globalQuiet && !globalJSON {
+			scanBar(content.URL.String())
+		}
+		totalBytes += content.Size
+		totalObjects++
+	}
+	session.Header.TotalBytes = totalBytes
+	session.Header.TotalObjects = totalObjects
+	session.Save()
+}
+
+// doRotate - re-encrypt a single object with a server side copy onto itself.
+func doRotate(rotateURLs URLs, pg ProgressReader, oldSSE, newSSE encrypt.ServerSide) URLs {
+	alias := rotateURLs.SourceAlias
+	objectURL := rotateURLs.SourceContent.URL
+	objectPath := filepath.ToSlash(filepath.Join(alias, objectURL.Path))
+
+	if progressReader, ok := pg.(*progressBar); ok {
+		progressReader.SetCaption(objectURL.String() + ": ")
+	} else {
+		printMsg(encryptRotateMessage{
+			URL:        objectPath,
+			Size:       rotateURLs.SourceContent.Size,
+			TotalCount: rotateURLs.TotalCount,
+			TotalSize:  rotateURLs.TotalSize,
+		})
+	}
+
+	clnt, err := newClientFromAlias(alias, objectURL.String())
+	if err != nil {
+		return rotateURLs.WithError(err.Trace(objectPath))
+	}
+	// Empty metadata keeps the metadata of the object as is.
+	err = clnt.Copy(filepath.ToSlash(objectURL.Path), rotateURLs.SourceContent.Size, pg, oldSSE, newSSE, nil)
+	if err != nil {
+		return rotateURLs.WithError(err.Trace(objectPath))
+	}
+	return rotateURLs
+}
+
+func doEncryptRotateSession(session *sessionV9) error {
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+
+	oldSSE, err := parseSSECKey(session.Header.CommandStringFlags["old-key"])
+	fatalIf(err, "Unable to parse --old-key.")
+	newSSE, err := parseSSECKey(session.Header.CommandStringFlags["new-key"])
+	fatalIf(err, "Unable to parse --new-key.")
+
+	if !session.HasData() {
+		doPrepareRotateURLs(session, trapCh)
+	}
+
+	var pg ProgressReader
+	if !globalQuiet && !globalJSON { // set up progress bar
+		pg = newProgressBar(session.Header.TotalBytes)
+	} else {
+		pg = newAccounter(session.Header.TotalBytes)
+	}
+	// Objects rotated before the session was resumed are not read again.
+	if progressReader, ok := pg.(*progressBar); ok {
+		progressReader.ProgressBar.Add64(session.Header.ResumeBytes)
+	}
+
+	statusCh := make(chan URLs)
+	go func() {
+		defer close(statusCh)
+		for entry := range session.Entries() {
+			if entry.Err != nil {
+				errorIf(entry.Err.Trace(), "Unable to read session data.")
+				return
+			}
+			var rotateURLs URLs
+			if e := json.Unmarshal(entry.Data, &rotateURLs); e != nil {
+				errorIf(probe.NewError(e), "Unable to unmarshal %s", string(entry.Data))
+				session.SetEntryStatus(entry.Index, sessionEntryFailed)
+				continue
+			}
+			rotateURLs.TotalCount = session.Header.TotalObjects
+			rotateURLs.TotalSize = session.Header.TotalBytes
+			rotateURLs.sessionEntry = entry.Index
+			// Objects rotated or failed before the session was
+			// interrupted are not rotated again.
+			if entry.Status != sessionEntryPending {
+				doCopyFake(rotateURLs, pg)
+				continue
+			}
+			statusCh <- doRotate(rotateURLs, pg, oldSSE, newSSE)
+		}
+	}()
+
+	var retErr error
+loop:
+	for {
+		select {
+		case <-trapCh:
+			// Receive interrupt notification.
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			session.CloseAndDie()
+		case rotateURLs, ok := <-statusCh:
+			if !ok {
+				break loop
+			}
+			if rotateURLs.Error == nil {
+				session.SetEntryStatus(rotateURLs.sessionEntry, sessionEntryDone)
+				continue
+			}
+			session.SetEntryStatus(rotateURLs.sessionEntry, sessionEntryFailed)
+			// Print in new line and adjust to top so that we
+			// don't print over the ongoing progress bar.
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			// Objects which cannot be decrypted with the old key are
+			// reported and skipped, the rotation goes on.
+			errorIf(rotateURLs.Error, fmt.Sprintf("Failed to rotate `%s`.", rotateURLs.SourceContent.URL.String()))
+			retErr = exitStatus(globalErrorExitStatus)
+		}
+	}
+
+	if progressReader, ok := pg.(*progressBar); ok {
+		if progressReader.ProgressBar.Get() > 0 {
+			progressReader.ProgressBar.Finish()
+		}
+	} else {
+		if accntReader, ok := pg.(*accounter); ok {
+			printMsg(accntReader.Stat())
+		}
+	}
+	return retErr
+}
+
+// doEncryptRotateFake - check that all objects can be read with the old key.
+func doEncryptRotateFake(urlStr string, oldSSE encrypt.ServerSide) error {
+	clnt, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+	alias, _, _ := mustExpandAlias(urlStr)
+
+	var cErr error
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(urlStr), "Unable to list `"+urlStr+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		objectPath := filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+		objectClnt, err := newClientFromAlias(alias, content.URL.String())
+		if err == nil {
+			_, err = objectClnt.Stat(false, true, oldSSE)
+		}
+		if err != nil {
+			errorIf(err.Trace(objectPath), "Unable to read `"+objectPath+"` with the old key.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(encryptRotateMessage{URL: objectPath, Size: content.Size, Fake: true})
+	}
+	return cErr
+}
+
+func mainEncryptRotate(ctx *cli.Context) error {
+	console.SetColor("Rotate", color.New(color.FgGreen, color.Bold))
+
+	checkEncryptRotateSyntax(ctx)
+
+	if ctx.Bool("fake") {
+		oldSSE, err := parseSSECKey(ctx.String("old-key"))
+		fatalIf(err, "Unable to parse --old-key.")
+		return doEncryptRotateFake(ctx.Args().First(), oldSSE)
+	}
+
+	session := newSessionV9()
+	session.Header.CommandType = "encrypt-rotate"
+	session.Header.CommandArgs = ctx.Args()
+	session.Header.CommandStringFlags["old-key"] = ctx.String("old-key")
+	session.Header.CommandStringFlags["new-key"] = ctx.String("new-key")
+
+	var e error
+	if session.Header.RootPath, e = os.Getwd(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to get current working folder.")
+	}
+
+	e = doEncryptRotateSession(session)
+	session.Delete()
+	return e
+}
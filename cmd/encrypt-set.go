@@ -0,0 +1,119 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	encryptSetFlags = []cli.Flag{}
+)
+
+var encryptSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set the default encryption of a bucket",
+	Action: mainEncryptSet,
+	Before: setGlobalsFromContext,
+	Flags:  append(encryptSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} sse-s3 TARGET
+  {{.HelpName}} sse-kms KEY-ID TARGET
+
+  Objects uploaded to TARGET without encryption headers are encrypted
+  with SSE-S3, or with SSE-KMS using the master key KEY-ID.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Encrypt new objects of a bucket with SSE-S3 by default
+     $ {{.HelpName}} sse-s3 myminio/mybucket
+
+   2. Encrypt new objects of a bucket with SSE-KMS by default
+     $ {{.HelpName}} sse-kms arn:aws:kms:us-east-1:xxx:key/xxx myminio/mybucket
+
+`,
+}
+
+// checkEncryptSetSyntax - validate all the passed arguments
+func checkEncryptSetSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	switch strings.ToLower(args.First()) {
+	case "sse-s3":
+		if len(args) == 2 {
+			return
+		}
+	case "sse-kms":
+		if len(args) == 3 {
+			return
+		}
+	}
+	cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+}
+
+// encryptSetMessage container
+type encryptSetMessage struct {
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"keyId,omitempty"`
+}
+
+// JSON jsonified encrypt set message.
+func (e encryptSetMessage) JSON() string {
+	e.Status = "success"
+	encryptSetMessageJSONBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+	return string(encryptSetMessageJSONBytes)
+}
+
+func (e encryptSetMessage) String() string {
+	return console.Colorize("Encrypt", "Default encryption of `"+e.URL+"` set to "+sseAlgorithmString(e.Algorithm, e.KeyID)+".")
+}
+
+func mainEncryptSet(ctx *cli.Context) error {
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	checkEncryptSetSyntax(ctx)
+
+	args := ctx.Args()
+	algorithm, keyID := sseAlgorithmAES256, ""
+	if strings.ToLower(args.First()) == "sse-kms" {
+		algorithm, keyID = sseAlgorithmKMS, args.Get(1)
+	}
+	urlStr := args.Get(len(args) - 1)
+
+	err := newEncryptClient(urlStr).SetBucketEncryption(algorithm, keyID)
+	fatalIf(err.Trace(urlStr), "Unable to set the default encryption of `"+urlStr+"`.")
+
+	printMsg(encryptSetMessage{
+		URL:       urlStr,
+		Algorithm: algorithm,
+		KeyID:     keyID,
+	})
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/ignore"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// mcIgnoreFile - file of a local folder holding gitignore style
+// patterns of the paths to exclude below the folder.
+const mcIgnoreFile = ".mcignore"
+
+// excludeRules - gitignore style rules excluding paths relative to a
+// source folder. Patterns of --exclude-from are overridden by the
+// .mcignore files of a local source folder, the deeper the folder the
+// higher the precedence, and --include patterns override them all.
+type excludeRules struct {
+	excludes *ignore.Matcher
+	includes *ignore.Matcher
+
+	// patterns of the .mcignore files of local folders, nil for
+	// folders without one
+	mutex sync.Mutex
+	dirs  map[string]*ignore.Matcher
+}
+
+// newExcludeRules - rules of the patterns in the excludeFrom file,
+// if any, and of the include patterns.
+func newExcludeRules(excludeFrom string, includes []string) (*excludeRules, *probe.Error) {
+	rules := &excludeRules{
+		excludes: ignore.New(),
+		includes: ignore.New(includes...),
+		dirs:     make(map[string]*ignore.Matcher),
+	}
+	if excludeFrom == "" {
+		return rules, nil
+	}
+	f, e := os.Open(excludeFrom)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(excludeFrom)
+	}
+	defer f.Close()
+	if rules.excludes, e = ignore.Read(f); e != nil {
+		return nil, probe.NewError(e).Trace(excludeFrom)
+	}
+	return rules, nil
+}
+
+// hasIncludes - tells whether paths inside an excluded folder may be
+// included again.
+func (r *excludeRules) hasIncludes() bool {
+	return r.includes.Len() > 0
+}
+
+// isExcluded - tells whether a path relative to the source folder is
+// excluded, either by itself or by one of its parent folders. root is
+// the local source folder whose .mcignore files apply, it is empty for
+// other sources. No rules exclude nothing.
+func (r *excludeRules) isExcluded(root, path string, isDir bool) bool {
+	if r == nil {
+		return false
+	}
+	path = strings.Trim(filepath.ToSlash(path), "/")
+	if path == "" {
+		return false
+	}
+	if r.includes.Ignored(path, isDir) {
+		return false
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && r.isExcludedSelf(root, path[:i], true) {
+			return true
+		}
+	}
+	return r.isExcludedSelf(root, path, isDir)
+}
+
+// isExcludedSelf - tells whether the path itself is excluded, the
+// last matching pattern of the most specific patterns decides.
+func (r *excludeRules) isExcludedSelf(root, path string, isDir bool) bool {
+	_, excluded := r.excludes.Match(path, isDir)
+	if root == "" {
+		return excluded
+	}
+	dir := ""
+	for {
+		if m := r.dirExcludes(root, dir); m != nil {
+			if matched, ignored := m.Match(strings.TrimPrefix(path, dir), isDir); matched {
+				excluded = ignored
+			}
+		}
+		i := strings.IndexByte(path[len(dir):], '/')
+		if i < 0 {
+			return excluded
+		}
+		dir = path[:len(dir)+i+1]
+	}
+}
+
+// dirExcludes - patterns of the .mcignore file of a folder relative to
+// root, which is read once. Unreadable files are skipped.
+func (r *excludeRules) dirExcludes(root, dir string) *ignore.Matcher {
+	dirPath := filepath.Join(root, filepath.FromSlash(dir))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	m, ok := r.dirs[dirPath]
+	if ok {
+		return m
+	}
+	if f, e := os.Open(filepath.Join(dirPath, mcIgnoreFile)); e == nil {
+		if m, e = ignore.Read(f); e != nil {
+			m = nil
+		}
+		f.Close()
+	}
+	r.dirs[dirPath] = m
+	return m
+}
+
+// forget - forget the patterns of the .mcignore file of a folder
+// relative to root, for them to be read again.
+func (r *excludeRules) forget(root, dir string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.dirs, filepath.Join(root, filepath.FromSlash(dir)))
+}
+
+// isEventExcluded - tells whether the object of a watch event of clnt
+// is excluded, eventPath is an absolute path for local folders and a
+// URL otherwise. Events about .mcignore files refresh their patterns.
+func (r *excludeRules) isEventExcluded(clnt Client, eventPath string) bool {
+	if r == nil {
+		return false
+	}
+	clntURL := clnt.GetURL()
+	if clntURL.Type == fileSystem {
+		root, e := filepath.Abs(clntURL.Path)
+		if e != nil {
+			return false
+		}
+		rel, e := filepath.Rel(root, eventPath)
+		if e != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return false
+		}
+		rel = filepath.ToSlash(rel)
+		if filepath.Base(rel) == mcIgnoreFile {
+			r.forget(root, filepath.Dir(rel))
+		}
+		return r.isExcluded(root, rel, false)
+	}
+	prefix := strings.TrimSuffix(clntURL.Path, string(clntURL.Separator)) + string(clntURL.Separator)
+	eventURL := newClientURL(eventPath)
+	if !strings.HasPrefix(eventURL.Path, prefix) {
+		return false
+	}
+	return r.isExcluded("", strings.TrimPrefix(eventURL.Path, prefix), false)
+}
+
+// excludeClient - presents the objects of a client which are not
+// excluded by the rules, relative to the listed folder. The exclusion
+// rules of local folders are applied while walking them instead.
+type excludeClient struct {
+	Client
+	rules *excludeRules
+}
+
+// newExcludeClient - wraps clnt to exclude objects by the rules.
+func newExcludeClient(clnt Client, rules *excludeRules) Client {
+	if rules == nil {
+		return clnt
+	}
+	if fsClnt, ok := clnt.(*fsClient); ok {
+		fsClnt.excludeRules = rules
+		return fsClnt
+	}
+	return &excludeClient{Client: clnt, rules: rules}
+}
+
+// List - list the objects which are not excluded.
+func (c *excludeClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	contentCh := make(chan *clientContent)
+	go func() {
+		defer close(contentCh)
+		clntURL := c.GetURL()
+		prefix := clntURL.Path
+		if separator := string(clntURL.Separator); !strings.HasSuffix(prefix, separator) {
+			prefix += separator
+		}
+		for content := range c.Client.List(isRecursive, isIncomplete, showDir) {
+			if content.Err == nil && strings.HasPrefix(content.URL.Path, prefix) &&
+				c.rules.isExcluded("", strings.TrimPrefix(content.URL.Path, prefix), content.Type.IsDir()) {
+				continue
+			}
+			contentCh <- content
+		}
+	}()
+	return contentCh
+}
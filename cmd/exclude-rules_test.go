@@ -0,0 +1,92 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExcludeRules(t *testing.T) {
+	root, e := ioutil.TempDir("", "mc-exclude-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(root)
+
+	excludeFrom := filepath.Join(root, "exclude.txt")
+	if e = ioutil.WriteFile(excludeFrom, []byte("*.log\n/logs/\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	if e = os.MkdirAll(filepath.Join(root, "src", "keep"), 0700); e != nil {
+		t.Fatal(e)
+	}
+	if e = ioutil.WriteFile(filepath.Join(root, "src", mcIgnoreFile), []byte("build/\n*.tmp\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	if e = ioutil.WriteFile(filepath.Join(root, "src", "keep", mcIgnoreFile), []byte("!*.log\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+
+	rules, err := newExcludeRules(excludeFrom, []string{"important.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := filepath.Join(root, "src")
+	testCases := []struct {
+		path     string
+		isDir    bool
+		excluded bool
+	}{
+		{"main.go", false, false},
+		{"x.log", false, true},
+		{"a/x.log", false, true},
+		{"keep/x.log", false, false},
+		{"important.log", false, false},
+		{"logs", true, true},
+		{"logs/a.txt", false, true},
+		{"a/logs/a.txt", false, false},
+		{"build/o.bin", false, true},
+		{"a/build/o.bin", false, true},
+		{"a/b.tmp", false, true},
+	}
+	for i, testCase := range testCases {
+		if excluded := rules.isExcluded(source, testCase.path, testCase.isDir); excluded != testCase.excluded {
+			t.Errorf("Test %d: %s expected to be excluded %t, got %t", i+1, testCase.path, testCase.excluded, excluded)
+		}
+	}
+
+	// Without a local root only the patterns of the flags apply.
+	if rules.isExcluded("", "a/b.tmp", false) {
+		t.Errorf("Expected a/b.tmp not to be excluded without .mcignore files")
+	}
+
+	// Changed .mcignore files are read again once forgotten.
+	if e = ioutil.WriteFile(filepath.Join(root, "src", mcIgnoreFile), []byte("*.go\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	rules.forget(source, ".")
+	if !rules.isExcluded(source, "main.go", false) || rules.isExcluded(source, "a/b.tmp", false) {
+		t.Errorf("Expected the changed .mcignore file to apply")
+	}
+
+	if _, err = newExcludeRules(filepath.Join(root, "missing"), nil); err == nil {
+		t.Errorf("Expected an error for a missing exclude file")
+	}
+}
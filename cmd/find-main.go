@@ -37,6 +37,14 @@ var (
 			Name:  "ignore",
 			Usage: "exclude objects matching the wildcard pattern",
 		},
+		cli.StringFlag{
+			Name:  "exclude-from",
+			Usage: "exclude objects matching gitignore style patterns read from a file",
+		},
+		cli.StringSliceFlag{
+			Name:  "include",
+			Usage: "include objects matching a gitignore style pattern, even if they are excluded",
+		},
 		cli.StringFlag{
 			Name:  "name",
 			Usage: "find object names matching wildcard pattern",
@@ -151,6 +159,9 @@ EXAMPLES:
    10. List all objects up to 3 levels sub-directory deep under "s3/bucket".
        $ {{.HelpName}} s3/bucket --maxdepth 3
 
+   11. Find all files under the current folder, skipping the paths listed in ".gitignore" and in ".mcignore" files.
+       $ {{.HelpName}} . --exclude-from .gitignore
+
 `,
 }
 
@@ -199,6 +210,7 @@ type findContext struct {
 	largerSize    uint64
 	smallerSize   uint64
 	watch         bool
+	excludeRules  *excludeRules
 
 	// Internal values
 	targetAlias   string
@@ -229,6 +241,10 @@ func mainFind(ctx *cli.Context) error {
 	clnt, err := newClient(args[0])
 	fatalIf(err.Trace(args...), "Unable to initialize `"+args[0]+"`.")
 
+	excludeRules, err := newExcludeRules(ctx.String("exclude-from"), ctx.StringSlice("include"))
+	fatalIf(err.Trace(args...), "Unable to read exclude patterns.")
+	clnt = newExcludeClient(clnt, excludeRules)
+
 	var olderThan, newerThan string
 
 	if ctx.String("older-than") != "" {
@@ -275,6 +291,7 @@ func mainFind(ctx *cli.Context) error {
 		largerSize:    largerSize,
 		smallerSize:   smallerSize,
 		watch:         ctx.Bool("watch"),
+		excludeRules:  excludeRules,
 		targetAlias:   targetAlias,
 		targetURL:     args[0],
 		targetFullURL: targetFullURL,
@@ -158,6 +158,10 @@ func watchFind(ctx *findContext) {
 				return
 			}
 
+			if ctx.excludeRules.isEventExcluded(ctx.clnt, event.Path) {
+				continue
+			}
+
 			time, e := time.Parse(time.RFC3339, event.Time)
 			if e != nil {
 				errorIf(probe.NewError(e).Trace(event.Time), "Unable to parse event time.")
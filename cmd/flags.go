@@ -64,6 +64,20 @@ var ioFlags = []cli.Flag{
 	},
 }
 
+// Flags limiting the bandwidth of transfer commands, pipe only uploads
+// and cat only downloads.
+var (
+	limitUploadFlag = cli.StringFlag{
+		Name:  "limit-upload",
+		Usage: "limit upload bandwidth to object storage, e.g. 10MiB/s, or @FILE to follow the limit written in FILE",
+	}
+	limitDownloadFlag = cli.StringFlag{
+		Name:  "limit-download",
+		Usage: "limit download bandwidth from object storage, e.g. 10MiB/s, or @FILE to follow the limit written in FILE",
+	}
+	limitFlags = []cli.Flag{limitUploadFlag, limitDownloadFlag}
+)
+
 // registerCmd registers a cli command
 func registerCmd(cmd cli.Command) {
 	commands = append(commands, cmd)
@@ -41,7 +41,9 @@ const (
 	// session config and shared urls related constants
 	globalSessionDir           = "session"
 	globalSharedURLsDataDir    = "share"
-	globalSessionConfigVersion = "8"
+	globalSyncDir              = "sync"
+	globalWatchDir             = "watch"
+	globalSessionConfigVersion = "9"
 
 	// Profile directory for dumping profiler outputs.
 	globalProfileDir = "profile"
@@ -66,6 +68,18 @@ var (
 
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
+
+	// Master key of client-side encryption, a nil value means
+	// objects are stored as they are
+	globalCSEKey cseKey
+	// Object storage targets encrypted with globalCSEKey, all
+	// objects are decrypted when empty
+	globalCSETargets []string
+
+	// Bandwidth limits of transfers to and from object storage,
+	// nil values mean unlimited
+	globalUploadLimiter   *bandwidthLimiter
+	globalDownloadLimiter *bandwidthLimiter
 )
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
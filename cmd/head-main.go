@@ -62,7 +62,8 @@ ENVIRONMENT VARIABLES:
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
 NOTE:
-   '{{.HelpName}}' automatically decompresses 'gzip', 'bzip2' compressed objects.
+   '{{.HelpName}}' automatically decompresses 'gzip', 'bzip2' compressed objects, and objects
+   uploaded with '--compress'.
 
 EXAMPLES:
    1. Display only first line from a 'gzip' compressed object on Amazon S3.
@@ -82,7 +83,7 @@ func headURL(sourceURL string, encKeyDB map[string][]prefixSSEPair, nlines int64
 	default:
 		var err *probe.Error
 		var metadata map[string]string
-		if reader, metadata, err = getSourceStreamMetadataFromURL(sourceURL, encKeyDB); err != nil {
+		if reader, metadata, err = getSourceStreamMetadataFromURL(sourceURL, "", encKeyDB); err != nil {
 			return err.Trace(sourceURL)
 		}
 		ctype := metadata["Content-Type"]
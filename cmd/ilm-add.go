@@ -0,0 +1,198 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	ilmAddFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "ID of the rule, an existing rule with the same ID is replaced",
+		},
+		cli.StringFlag{
+			Name:  "prefix",
+			Usage: "apply the rule to objects with this prefix",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "apply the rule to objects with these tags, e.g. \"key1=value1&key2=value2\"",
+		},
+		cli.IntFlag{
+			Name:  "expiry-days",
+			Usage: "remove objects this number of days after their creation",
+		},
+		cli.StringFlag{
+			Name:  "expiry-date",
+			Usage: "remove objects on this date, format YYYY-MM-DD",
+		},
+		cli.IntFlag{
+			Name:  "transition-days",
+			Usage: "move objects to --storage-class this number of days after their creation",
+		},
+		cli.StringFlag{
+			Name:  "transition-date",
+			Usage: "move objects to --storage-class on this date, format YYYY-MM-DD",
+		},
+		cli.StringFlag{
+			Name:  "storage-class",
+			Usage: "storage class objects are moved to",
+		},
+		cli.BoolFlag{
+			Name:  "disable",
+			Usage: "add the rule in disabled state",
+		},
+	}
+)
+
+var ilmAddCmd = cli.Command{
+	Name:   "add",
+	Usage:  "add a lifecycle rule to a bucket",
+	Action: mainILMAdd,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmAddFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Remove objects under a prefix 90 days after their creation
+     $ {{.HelpName}} --prefix "logs/" --expiry-days 90 myminio/mybucket
+
+   2. Move tagged objects to another storage class after 30 days and remove them after a year
+     $ {{.HelpName}} --id archive --tags "tier=cold" --transition-days 30 --storage-class GLACIER \
+         --expiry-days 365 myminio/mybucket
+
+   3. Remove all objects of a bucket on a given date
+     $ {{.HelpName}} --expiry-date 2020-01-01 myminio/mybucket
+
+`,
+}
+
+// checkILMAddSyntax - validate all the passed arguments
+func checkILMAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+	}
+	if !ctx.IsSet("expiry-days") && !ctx.IsSet("expiry-date") &&
+		!ctx.IsSet("transition-days") && !ctx.IsSet("transition-date") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "At least one of --expiry-days, --expiry-date, --transition-days or --transition-date is required.")
+	}
+	if ctx.IsSet("storage-class") != (ctx.IsSet("transition-days") || ctx.IsSet("transition-date")) {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--storage-class is required with, and only with, --transition-days or --transition-date.")
+	}
+}
+
+// ilmAddMessage container
+type ilmAddMessage struct {
+	Status string        `json:"status"`
+	URL    string        `json:"url"`
+	Rule   lifecycleRule `json:"rule"`
+}
+
+// JSON jsonified ilm add message.
+func (i ilmAddMessage) JSON() string {
+	i.Status = "success"
+	ilmAddMessageJSONBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(ilmAddMessageJSONBytes)
+}
+
+func (i ilmAddMessage) String() string {
+	return console.Colorize("ILM", "Lifecycle rule `"+i.Rule.ID+"` added to `"+i.URL+"`.")
+}
+
+// lifecycleRuleFromContext - build a lifecycle rule from the flags.
+func lifecycleRuleFromContext(ctx *cli.Context) (lifecycleRule, *probe.Error) {
+	rule := lifecycleRule{
+		ID:     ctx.String("id"),
+		Status: lifecycleEnabled,
+	}
+	if rule.ID == "" {
+		rule.ID = newRandomID(20)
+	}
+	if ctx.Bool("disable") {
+		rule.Status = lifecycleDisabled
+	}
+	tags, err := parseTags(ctx.String("tags"))
+	if err != nil {
+		return rule, err
+	}
+	rule.Filter = newLifecycleFilter(ctx.String("prefix"), tags)
+
+	if ctx.IsSet("expiry-days") || ctx.IsSet("expiry-date") {
+		rule.Expiration = &lifecycleExpiration{Days: ctx.Int("expiry-days")}
+		if ctx.IsSet("expiry-date") {
+			if rule.Expiration.Date, err = parseLifecycleDate(ctx.String("expiry-date")); err != nil {
+				return rule, err
+			}
+		}
+	}
+	if ctx.IsSet("transition-days") || ctx.IsSet("transition-date") {
+		rule.Transition = &lifecycleTransition{
+			Days:         ctx.Int("transition-days"),
+			StorageClass: ctx.String("storage-class"),
+		}
+		if ctx.IsSet("transition-date") {
+			if rule.Transition.Date, err = parseLifecycleDate(ctx.String("transition-date")); err != nil {
+				return rule, err
+			}
+		}
+	}
+	return rule, validateLifecycleRule(rule)
+}
+
+func mainILMAdd(ctx *cli.Context) error {
+	console.SetColor("ILM", color.New(color.FgGreen, color.Bold))
+
+	checkILMAddSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	rule, err := lifecycleRuleFromContext(ctx)
+	fatalIf(err.Trace(urlStr), "Unable to parse the lifecycle rule.")
+
+	clnt := newILMClient(urlStr)
+	config, err := clnt.GetLifecycle()
+	fatalIf(err.Trace(urlStr), "Unable to get the lifecycle configuration of `"+urlStr+"`.")
+
+	replaced := false
+	for i := range config.Rules {
+		if config.Rules[i].ID == rule.ID {
+			config.Rules[i] = rule
+			replaced = true
+		}
+	}
+	if !replaced {
+		config.Rules = append(config.Rules, rule)
+	}
+	err = clnt.SetLifecycle(config)
+	fatalIf(err.Trace(urlStr), "Unable to set the lifecycle configuration of `"+urlStr+"`.")
+
+	printMsg(ilmAddMessage{URL: urlStr, Rule: rule})
+	return nil
+}
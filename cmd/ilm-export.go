@@ -0,0 +1,79 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	ilmExportFlags = []cli.Flag{}
+)
+
+var ilmExportCmd = cli.Command{
+	Name:   "export",
+	Usage:  "export lifecycle rules of a bucket in JSON format",
+	Action: mainILMExport,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmExportFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Save the lifecycle rules of a bucket to a file
+     $ {{.HelpName}} myminio/mybucket > lifecycle.json
+
+   2. Copy the lifecycle rules of a bucket to another bucket
+     $ {{.HelpName}} myminio/mybucket | mc ilm import myminio/otherbucket
+
+`,
+}
+
+// checkILMExportSyntax - validate all the passed arguments
+func checkILMExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+	}
+}
+
+func mainILMExport(ctx *cli.Context) error {
+	checkILMExportSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	config, err := newILMClient(urlStr).GetLifecycle()
+	fatalIf(err.Trace(urlStr), "Unable to get the lifecycle configuration of `"+urlStr+"`.")
+	if config.Rules == nil {
+		config.Rules = []lifecycleRule{}
+	}
+
+	// The document is printed as is, --json makes no difference
+	// since ilm import expects exactly this format.
+	configJSONBytes, e := json.MarshalIndent(config, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	console.Println(string(configJSONBytes))
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	ilmImportFlags = []cli.Flag{}
+)
+
+var ilmImportCmd = cli.Command{
+	Name:   "import",
+	Usage:  "import lifecycle rules of a bucket from a JSON file",
+	Action: mainILMImport,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmImportFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [FILE]
+
+  All existing lifecycle rules of TARGET are replaced by the rules
+  of FILE, rules are read from standard input when FILE is omitted.
+  FILE uses the format of "mc ilm export", dates are written as
+  "2020-01-01T00:00:00Z".
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Import lifecycle rules from a file
+     $ {{.HelpName}} myminio/mybucket lifecycle.json
+
+   2. Import lifecycle rules from standard input
+     $ cat lifecycle.json | {{.HelpName}} myminio/mybucket
+
+`,
+}
+
+// checkILMImportSyntax - validate all the passed arguments
+func checkILMImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code
+	}
+}
+
+// ilmImportMessage container
+type ilmImportMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+	Rules  int    `json:"rules"`
+}
+
+// JSON jsonified ilm import message.
+func (i ilmImportMessage) JSON() string {
+	i.Status = "success"
+	ilmImportMessageJSONBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(ilmImportMessageJSONBytes)
+}
+
+func (i ilmImportMessage) String() string {
+	return console.Colorize("ILM", "Lifecycle configuration of `"+i.URL+"` replaced with "+strconv.Itoa(i.Rules)+" rules.")
+}
+
+// readLifecycleConfig - read and validate a lifecycle configuration
+// in the format of ilm export.
+func readLifecycleConfig(reader io.Reader) (lifecycleConfiguration, *probe.Error) {
+	config := lifecycleConfiguration{}
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if e := decoder.Decode(&config); e != nil {
+		return config, probe.NewError(e)
+	}
+	return config, validateLifecycleConfig(config)
+}
+
+func mainILMImport(ctx *cli.Context) error {
+	console.SetColor("ILM", color.New(color.FgGreen, color.Bold))
+
+	checkILMImportSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	reader := io.Reader(os.Stdin)
+	if len(ctx.Args()) == 2 {
+		file, e := os.Open(ctx.Args().Get(1))
+		fatalIf(probe.NewError(e), "Unable to open the lifecycle rules file.")
+		defer file.Close()
+		reader = file
+	}
+	config, err := readLifecycleConfig(reader)
+	fatalIf(err.Trace(urlStr), "Unable to read the lifecycle rules.")
+
+	err = newILMClient(urlStr).SetLifecycle(config)
+	fatalIf(err.Trace(urlStr), "Unable to set the lifecycle configuration of `"+urlStr+"`.")
+
+	printMsg(ilmImportMessage{URL: urlStr, Rules: len(config.Rules)})
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	ilmListFlags = []cli.Flag{}
+)
+
+var ilmListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list lifecycle rules of a bucket",
+	Action: mainILMList,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmListFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. List lifecycle rules of a bucket
+     $ {{.HelpName}} myminio/mybucket
+
+   2. List lifecycle rules of a bucket in JSON format
+     $ {{.HelpName}} --json myminio/mybucket
+
+`,
+}
+
+// checkILMListSyntax - validate all the passed arguments
+func checkILMListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+}
+
+// ilmListMessage container, one message per rule.
+type ilmListMessage struct {
+	Status string        `json:"status"`
+	URL    string        `json:"url"`
+	Rule   lifecycleRule `json:"rule"`
+}
+
+// JSON jsonified ilm list message.
+func (i ilmListMessage) JSON() string {
+	i.Status = "success"
+	ilmListMessageJSONBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(ilmListMessageJSONBytes)
+}
+
+func (i ilmListMessage) String() string {
+	return console.Colorize("ID", i.Rule.ID+": ") + i.Rule.Status
+}
+
+// lifecycleDaysOrDate - printable form of the days or date of an action.
+func lifecycleDaysOrDate(days int, date *time.Time) string {
+	if date != nil {
+		return "on " + date.Format(lifecycleDateLayout)
+	}
+	return "after " + strconv.Itoa(days) + " days"
+}
+
+// lifecycleRuleRow - table row of a rule, in the order of the header
+// of ilm list.
+func lifecycleRuleRow(rule lifecycleRule) []string {
+	expiration, transition := "-", "-"
+	if exp := rule.Expiration; exp != nil {
+		expiration = lifecycleDaysOrDate(exp.Days, exp.Date)
+	}
+	if tr := rule.Transition; tr != nil {
+		transition = tr.StorageClass + " " + lifecycleDaysOrDate(tr.Days, tr.Date)
+	}
+	prefix, tags := rule.Filter.prefix(), tagsToString(rule.Filter.tags())
+	if prefix == "" {
+		prefix = "-"
+	}
+	if tags == "" {
+		tags = "-"
+	}
+	return []string{rule.ID, prefix, tags, rule.Status, expiration, transition}
+}
+
+func mainILMList(ctx *cli.Context) error {
+	console.SetColor("ID", color.New(color.Bold))
+
+	checkILMListSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	config, err := newILMClient(urlStr).GetLifecycle()
+	fatalIf(err.Trace(urlStr), "Unable to get the lifecycle configuration of `"+urlStr+"`.")
+
+	if globalJSON {
+		for _, rule := range config.Rules {
+			printMsg(ilmListMessage{URL: urlStr, Rule: rule})
+		}
+		return nil
+	}
+	if len(config.Rules) == 0 {
+		console.Infoln("No lifecycle rules found for `" + urlStr + "`.")
+		return nil
+	}
+
+	rowColors := []*color.Color{color.New(color.Bold)}
+	rows := [][]string{{"ID", "Prefix", "Tags", "Status", "Expiration", "Transition"}}
+	for _, rule := range config.Rules {
+		rowColor := color.New(color.FgGreen)
+		if rule.Status == lifecycleDisabled {
+			rowColor = color.New(color.FgHiBlack)
+		}
+		rowColors = append(rowColors, rowColor)
+		rows = append(rows, lifecycleRuleRow(rule))
+	}
+	e := console.NewTable(rowColors, []bool{false, false, false, false, false, false}, 0).DisplayTable(rows)
+	fatalIf(probe.NewError(e), "Unable to display the lifecycle rules.")
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// lifecycleDateLayout - layout of expiration and transition dates,
+// lifecycle dates are always at midnight UTC.
+const lifecycleDateLayout = "2006-01-02"
+
+var (
+	ilmFlags = []cli.Flag{}
+)
+
+var ilmCmd = cli.Command{
+	Name:            "ilm",
+	Usage:           "manage bucket lifecycle rules",
+	HideHelpCommand: true,
+	Action:          mainILM,
+	Before:          setGlobalsFromContext,
+	Flags:           append(ilmFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		ilmAddCmd,
+		ilmListCmd,
+		ilmRemoveCmd,
+		ilmExportCmd,
+		ilmImportCmd,
+	},
+}
+
+// mainILM is the handle for "mc ilm" command.
+func mainILM(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "add", "list", "remove" have their own main.
+}
+
+// newILMClient - returns the S3 client of the bucket at urlStr.
+func newILMClient(urlStr string) *s3Client {
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(urlStr), "The provided url doesn't point to a S3 server.")
+	}
+	if _, object := s3Client.url2BucketAndObject(); object != "" {
+		fatalIf(errInvalidArgument().Trace(urlStr), "Lifecycle rules are set on buckets, use --prefix to select objects.")
+	}
+	return s3Client
+}
+
+// parseLifecycleDate - parse an expiration or transition date.
+func parseLifecycleDate(date string) (*time.Time, *probe.Error) {
+	t, e := time.Parse(lifecycleDateLayout, date)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return &t, nil
+}
+
+// validateLifecycleRule - check a rule before it is sent to the server,
+// to report mistakes with the ID of the offending rule.
+func validateLifecycleRule(rule lifecycleRule) *probe.Error {
+	switch {
+	case rule.ID == "":
+		return errInvalidLifecycleRule(rule.ID, "ID is empty")
+	case len(rule.ID) > 255:
+		return errInvalidLifecycleRule(rule.ID, "ID is longer than 255 characters")
+	case rule.Status != lifecycleEnabled && rule.Status != lifecycleDisabled:
+		return errInvalidLifecycleRule(rule.ID, "status should be "+lifecycleEnabled+" or "+lifecycleDisabled)
+	case rule.Expiration == nil && rule.Transition == nil:
+		return errInvalidLifecycleRule(rule.ID, "no expiration or transition")
+	case rule.Filter.And != nil && (rule.Filter.Prefix != "" || rule.Filter.Tag != nil):
+		return errInvalidLifecycleRule(rule.ID, "filter And cannot be combined with Prefix or Tag")
+	case rule.Filter.Prefix != "" && rule.Filter.Tag != nil:
+		return errInvalidLifecycleRule(rule.ID, "use filter And to combine a prefix with tags")
+	}
+	if exp := rule.Expiration; exp != nil {
+		if err := validateLifecycleTime(rule.ID, "expiration", exp.Days, exp.Date); err != nil {
+			return err
+		}
+	}
+	if tr := rule.Transition; tr != nil {
+		if tr.StorageClass == "" {
+			return errInvalidLifecycleRule(rule.ID, "transition has no storage class")
+		}
+		if err := validateLifecycleTime(rule.ID, "transition", tr.Days, tr.Date); err != nil {
+			return err
+		}
+	}
+	if exp, tr := rule.Expiration, rule.Transition; exp != nil && tr != nil {
+		if exp.Days > 0 && tr.Days > 0 && exp.Days <= tr.Days {
+			return errInvalidLifecycleRule(rule.ID, "expiration should be later than transition")
+		}
+		if exp.Date != nil && tr.Date != nil && !exp.Date.After(*tr.Date) {
+			return errInvalidLifecycleRule(rule.ID, "expiration should be later than transition")
+		}
+	}
+	return nil
+}
+
+// validateLifecycleTime - exactly one of days or date is expected,
+// dates should be at midnight UTC.
+func validateLifecycleTime(id, action string, days int, date *time.Time) *probe.Error {
+	switch {
+	case days < 0:
+		return errInvalidLifecycleRule(id, action+" days should be positive")
+	case days > 0 && date != nil:
+		return errInvalidLifecycleRule(id, action+" has both days and date")
+	case days == 0 && date == nil:
+		return errInvalidLifecycleRule(id, action+" has no days or date")
+	case date != nil && !date.Equal(date.UTC().Truncate(24*time.Hour)):
+		return errInvalidLifecycleRule(id, action+" date should be at midnight UTC")
+	}
+	return nil
+}
+
+// validateLifecycleConfig - validate all rules, IDs should be unique.
+func validateLifecycleConfig(config lifecycleConfiguration) *probe.Error {
+	ids := make(map[string]bool, len(config.Rules))
+	for _, rule := range config.Rules {
+		if err := validateLifecycleRule(rule); err != nil {
+			return err
+		}
+		if ids[rule.ID] {
+			return errInvalidLifecycleRule(rule.ID, "ID is not unique")
+		}
+		ids[rule.ID] = true
+	}
+	return nil
+}
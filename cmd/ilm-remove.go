@@ -0,0 +1,133 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	ilmRemoveFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "ID of the rule to remove",
+		},
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "remove all lifecycle rules of the bucket",
+		},
+	}
+)
+
+var ilmRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove lifecycle rules of a bucket",
+	Action: mainILMRemove,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Remove the lifecycle rule with ID "archive"
+     $ {{.HelpName}} --id archive myminio/mybucket
+
+   2. Remove all lifecycle rules of a bucket
+     $ {{.HelpName}} --all myminio/mybucket
+
+`,
+}
+
+// checkILMRemoveSyntax - validate all the passed arguments
+func checkILMRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+	if ctx.String("id") == "" && !ctx.Bool("all") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Either --id or --all is required.")
+	}
+	if ctx.String("id") != "" && ctx.Bool("all") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--id cannot be used with --all.")
+	}
+}
+
+// ilmRemoveMessage container
+type ilmRemoveMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+	ID     string `json:"id,omitempty"`
+	All    bool   `json:"all,omitempty"`
+}
+
+// JSON jsonified ilm remove message.
+func (i ilmRemoveMessage) JSON() string {
+	i.Status = "success"
+	ilmRemoveMessageJSONBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(ilmRemoveMessageJSONBytes)
+}
+
+func (i ilmRemoveMessage) String() string {
+	if i.All {
+		return console.Colorize("ILM", "All lifecycle rules of `"+i.URL+"` removed.")
+	}
+	return console.Colorize("ILM", "Lifecycle rule `"+i.ID+"` removed from `"+i.URL+"`.")
+}
+
+func mainILMRemove(ctx *cli.Context) error {
+	console.SetColor("ILM", color.New(color.FgGreen, color.Bold))
+
+	checkILMRemoveSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	clnt := newILMClient(urlStr)
+	if ctx.Bool("all") {
+		err := clnt.SetLifecycle(lifecycleConfiguration{})
+		fatalIf(err.Trace(urlStr), "Unable to remove the lifecycle configuration of `"+urlStr+"`.")
+		printMsg(ilmRemoveMessage{URL: urlStr, All: true})
+		return nil
+	}
+
+	id := ctx.String("id")
+	config, err := clnt.GetLifecycle()
+	fatalIf(err.Trace(urlStr), "Unable to get the lifecycle configuration of `"+urlStr+"`.")
+	rules := config.Rules[:0]
+	for _, rule := range config.Rules {
+		if rule.ID != id {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(config.Rules) {
+		fatalIf(errLifecycleRuleNotFound(id).Trace(urlStr), "Unable to remove the lifecycle rule.")
+	}
+	config.Rules = rules
+	err = clnt.SetLifecycle(config)
+	fatalIf(err.Trace(urlStr), "Unable to set the lifecycle configuration of `"+urlStr+"`.")
+
+	printMsg(ilmRemoveMessage{URL: urlStr, ID: id})
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// parse list of comma separated alias/prefix=kms-key-id values entered on
+// command line, a key ID may be followed by an encryption context such as
+// alias/prefix=kms-key-id?key1=value1&key2=value2.
+func parseKMSKeys(sseKMS string) (encMap map[string][]prefixSSEPair, err *probe.Error) {
+	encMap = make(map[string][]prefixSSEPair)
+	if sseKMS == "" {
+		return encMap, nil
+	}
+	for _, kmsKey := range strings.Split(sseKMS, ",") {
+		i := strings.Index(kmsKey, "=")
+		if i == -1 {
+			return nil, probe.NewError(errors.New("SSE-KMS prefix should be of the form prefix1=key-id1,... "))
+		}
+		prefix, keyID := kmsKey[:i], kmsKey[i+1:]
+		var context interface{}
+		if j := strings.Index(keyID, "?"); j != -1 {
+			values, e := url.ParseQuery(keyID[j+1:])
+			if e != nil {
+				return nil, probe.NewError(errors.New("SSE-KMS context of " + prefix + " should be of the form key1=value1&key2=value2"))
+			}
+			kmsContext := make(map[string]string, len(values))
+			for k := range values {
+				kmsContext[k] = values.Get(k)
+			}
+			keyID, context = keyID[:j], kmsContext
+		}
+		if prefix == "" || keyID == "" {
+			return nil, probe.NewError(errors.New("SSE-KMS prefix and key ID should not be empty"))
+		}
+		sse, e := encrypt.NewSSEKMS(keyID, context)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		alias, _ := url2Alias(prefix)
+		encMap[alias] = append(encMap[alias], prefixSSEPair{
+			Prefix: prefix,
+			SSE:    sse,
+		})
+	}
+	return encMap, nil
+}
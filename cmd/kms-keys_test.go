@@ -0,0 +1,84 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+func TestParseKMSKeys(t *testing.T) {
+	kmsKey, err := encrypt.NewSSEKMS("my-key-id", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kmsARN, err := encrypt.NewSSEKMS("arn:aws:kms:us-east-1:123456789012:key/abcd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kmsContext, err := encrypt.NewSSEKMS("my-key-id", map[string]string{"department": "finance", "project": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		kmsKeys        string
+		expectedEncMap map[string][]prefixSSEPair
+		success        bool
+	}{
+		{kmsKeys: "", expectedEncMap: map[string][]prefixSSEPair{}, success: true},
+		{
+			kmsKeys: "s3/finance=my-key-id",
+			expectedEncMap: map[string][]prefixSSEPair{"s3": {{
+				Prefix: "s3/finance",
+				SSE:    kmsKey,
+			}}},
+			success: true,
+		},
+		{
+			kmsKeys: "s3/finance=my-key-id?department=finance&project=x,myminio/hr=arn:aws:kms:us-east-1:123456789012:key/abcd",
+			expectedEncMap: map[string][]prefixSSEPair{
+				"s3": {{
+					Prefix: "s3/finance",
+					SSE:    kmsContext,
+				}},
+				"myminio": {{
+					Prefix: "myminio/hr",
+					SSE:    kmsARN,
+				}},
+			},
+			success: true,
+		},
+		{kmsKeys: "s3/finance", success: false},
+		{kmsKeys: "s3/finance=", success: false},
+		{kmsKeys: "=my-key-id", success: false},
+		{kmsKeys: "s3/finance=my-key-id?department=%zz", success: false},
+	}
+	for i, testCase := range testCases {
+		encMap, err := parseKMSKeys(testCase.kmsKeys)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: Expected success, got %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: Expected error, got success", i+1)
+		}
+		if testCase.success && !reflect.DeepEqual(encMap, testCase.expectedEncMap) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expectedEncMap, encMap)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	legalHoldInfoFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "show legal hold status of all objects under the prefix",
+		},
+	}
+)
+
+var legalHoldInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show legal hold status of objects",
+	Action: mainLegalHoldInfo,
+	Before: setGlobalsFromContext,
+	Flags:  append(legalHoldInfoFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Show legal hold status of an object
+     $ {{.HelpName}} myminio/mybucket/myobject.csv
+
+   2. Show legal hold status of all objects under a prefix
+     $ {{.HelpName}} --recursive myminio/mybucket/case-1234/
+
+`,
+}
+
+// legalHoldInfoMessage container
+type legalHoldInfoMessage struct {
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	LegalHold string `json:"legalHold"`
+}
+
+// JSON jsonified legal hold info message.
+func (l legalHoldInfoMessage) JSON() string {
+	l.Status = "success"
+	legalHoldInfoMessageJSONBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(legalHoldInfoMessageJSONBytes)
+}
+
+func (l legalHoldInfoMessage) String() string {
+	return console.Colorize("URL", l.URL+": ") + console.Colorize("LegalHold", l.LegalHold)
+}
+
+func mainLegalHoldInfo(ctx *cli.Context) error {
+	console.SetColor("URL", color.New(color.Bold))
+	console.SetColor("LegalHold", color.New(color.FgCyan, color.Bold))
+
+	checkLegalHoldSyntax(ctx, "info")
+
+	return forEachLockObject(ctx.Args().First(), ctx.Bool("recursive"), "Unable to get legal hold of", func(clnt *s3Client, objectURL string) *probe.Error {
+		status, err := clnt.GetObjectLegalHold()
+		if err != nil {
+			return err
+		}
+		printMsg(legalHoldInfoMessage{
+			URL:       objectURL,
+			LegalHold: status,
+		})
+		return nil
+	})
+}
@@ -0,0 +1,44 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "github.com/minio/cli"
+
+var (
+	legalHoldFlags = []cli.Flag{}
+)
+
+var legalHoldCmd = cli.Command{
+	Name:            "legalhold",
+	Usage:           "manage legal holds of objects",
+	HideHelpCommand: true,
+	Action:          mainLegalHold,
+	Before:          setGlobalsFromContext,
+	Flags:           append(legalHoldFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		legalHoldOnCmd,
+		legalHoldOffCmd,
+		legalHoldInfoCmd,
+	},
+}
+
+// mainLegalHold is the handle for "mc legalhold" command.
+func mainLegalHold(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "on", "off", "info" have their own main.
+}
@@ -0,0 +1,65 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var (
+	legalHoldOffFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "release the legal hold of all objects under the prefix",
+		},
+	}
+)
+
+var legalHoldOffCmd = cli.Command{
+	Name:   "off",
+	Usage:  "release the legal hold of objects",
+	Action: mainLegalHoldOff,
+	Before: setGlobalsFromContext,
+	Flags:  append(legalHoldOffFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Release the legal hold of an object
+     $ {{.HelpName}} myminio/mybucket/myobject.csv
+
+   2. Release the legal hold of all objects under a prefix
+     $ {{.HelpName}} --recursive myminio/mybucket/case-1234/
+
+`,
+}
+
+func mainLegalHoldOff(ctx *cli.Context) error {
+	console.SetColor("LegalHold", color.New(color.FgGreen, color.Bold))
+
+	checkLegalHoldSyntax(ctx, "off")
+
+	return setLegalHold(ctx.Args().First(), ctx.Bool("recursive"), legalHoldOff)
+}
@@ -0,0 +1,111 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	legalHoldOnFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "place a legal hold on all objects under the prefix",
+		},
+	}
+)
+
+var legalHoldOnCmd = cli.Command{
+	Name:   "on",
+	Usage:  "place a legal hold on objects",
+	Action: mainLegalHoldOn,
+	Before: setGlobalsFromContext,
+	Flags:  append(legalHoldOnFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Place a legal hold on an object
+     $ {{.HelpName}} myminio/mybucket/myobject.csv
+
+   2. Place a legal hold on all objects under a prefix
+     $ {{.HelpName}} --recursive myminio/mybucket/case-1234/
+
+`,
+}
+
+// checkLegalHoldSyntax - validate all the passed arguments
+func checkLegalHoldSyntax(ctx *cli.Context, cmdName string) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, cmdName, 1) // last argument is exit code
+	}
+}
+
+// legalHoldMessage container
+type legalHoldMessage struct {
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	LegalHold string `json:"legalHold"`
+}
+
+// JSON jsonified legal hold message.
+func (l legalHoldMessage) JSON() string {
+	l.Status = "success"
+	legalHoldMessageJSONBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(legalHoldMessageJSONBytes)
+}
+
+func (l legalHoldMessage) String() string {
+	if l.LegalHold == legalHoldOn {
+		return console.Colorize("LegalHold", "Successfully placed a legal hold on `"+l.URL+"`.")
+	}
+	return console.Colorize("LegalHold", "Successfully released the legal hold of `"+l.URL+"`.")
+}
+
+// setLegalHold - set legal hold status of the object at urlStr, or
+// of all objects under urlStr when isRecursive is set.
+func setLegalHold(urlStr string, isRecursive bool, status string) error {
+	return forEachLockObject(urlStr, isRecursive, "Unable to set legal hold of", func(clnt *s3Client, objectURL string) *probe.Error {
+		if err := clnt.SetObjectLegalHold(status); err != nil {
+			return err
+		}
+		printMsg(legalHoldMessage{
+			URL:       objectURL,
+			LegalHold: status,
+		})
+		return nil
+	})
+}
+
+func mainLegalHoldOn(ctx *cli.Context) error {
+	console.SetColor("LegalHold", color.New(color.FgGreen, color.Bold))
+
+	checkLegalHoldSyntax(ctx, "on")
+
+	return setLegalHold(ctx.Args().First(), ctx.Bool("recursive"), legalHoldOn)
+}
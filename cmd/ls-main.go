@@ -35,6 +35,14 @@ var (
 			Name:  "incomplete, I",
 			Usage: "list incomplete uploads",
 		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "list all versions of objects, including delete markers",
+		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "list objects as they were at a date or a duration ago, e.g. 2019-05-21T18:00:00Z or 7d10h",
+		},
 	}
 )
 
@@ -73,6 +81,12 @@ EXAMPLES:
    6. List incomplete (previously failed) uploads of objects on Amazon S3.
       $ {{.HelpName}} --incomplete s3/mybucket
 
+   7. List all versions of objects, including delete markers, in a versioned bucket.
+      $ {{.HelpName}} --versions s3/mybucket/
+
+   8. List objects of a versioned bucket as they were 10 days ago.
+      $ {{.HelpName}} --rewind 10d s3/mybucket/
+
 `,
 }
 
@@ -90,6 +104,26 @@ func checkListSyntax(ctx *cli.Context) {
 	// extract URLs.
 	URLs := ctx.Args()
 	isIncomplete := ctx.Bool("incomplete")
+	if ctx.Bool("versions") {
+		if isIncomplete {
+			fatalIf(errInvalidArgument().Trace(args...), "--versions cannot be used with --incomplete.")
+		}
+		if ctx.String("rewind") != "" {
+			fatalIf(errInvalidArgument().Trace(args...), "--versions cannot be used with --rewind.")
+		}
+		// Objects which only have older versions or delete
+		// markers left cannot be stat'ed, skip the check.
+		return
+	}
+	if ctx.String("rewind") != "" {
+		if isIncomplete {
+			fatalIf(errInvalidArgument().Trace(args...), "--rewind cannot be used with --incomplete.")
+		}
+		_, err := parseRewind(ctx.String("rewind"))
+		fatalIf(err, "Unable to parse rewind `"+ctx.String("rewind")+"`.")
+		// Objects listed at an earlier time may not exist anymore.
+		return
+	}
 
 	for _, url := range URLs {
 		_, _, err := url2Stat(url, false, nil)
@@ -111,6 +145,8 @@ func mainList(ctx *cli.Context) error {
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("VersionID", color.New(color.FgHiBlue))
+	console.SetColor("DeleteMarker", color.New(color.FgRed, color.Bold))
 
 	// check 'ls' cli arguments.
 	checkListSyntax(ctx)
@@ -118,6 +154,9 @@ func mainList(ctx *cli.Context) error {
 	// Set command flags from context.
 	isRecursive := ctx.Bool("recursive")
 	isIncomplete := ctx.Bool("incomplete")
+	withVersions := ctx.Bool("versions")
+	timeRef, err := parseRewind(ctx.String("rewind"))
+	fatalIf(err, "Unable to parse rewind `"+ctx.String("rewind")+"`.")
 
 	args := ctx.Args()
 	// mimic operating system tool behavior.
@@ -129,6 +168,7 @@ func mainList(ctx *cli.Context) error {
 	for _, targetURL := range args {
 		clnt, err := newClient(targetURL)
 		fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+		clnt = newRewindClient(clnt, timeRef)
 
 		if !strings.HasSuffix(targetURL, string(clnt.GetURL().Separator)) {
 			var st *clientContent
@@ -137,10 +177,11 @@ func mainList(ctx *cli.Context) error {
 				targetURL = targetURL + string(clnt.GetURL().Separator)
 				clnt, err = newClient(targetURL)
 				fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+				clnt = newRewindClient(clnt, timeRef)
 			}
 		}
 
-		if e := doList(clnt, isRecursive, isIncomplete); e != nil {
+		if e := doList(clnt, isRecursive, isIncomplete, withVersions); e != nil {
 			cErr = e
 		}
 	}
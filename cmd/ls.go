@@ -42,18 +42,33 @@ type contentMessage struct {
 	Size     int64     `json:"size"`
 	Key      string    `json:"key"`
 	ETag     string    `json:"etag"`
+
+	VersionID      string `json:"versionId,omitempty"`
+	IsLatest       bool   `json:"isLatest,omitempty"`
+	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
 }
 
 // String colorized string message.
 func (c contentMessage) String() string {
 	message := console.Colorize("Time", fmt.Sprintf("[%s] ", c.Time.Format(printDate)))
-	message = message + console.Colorize("Size", fmt.Sprintf("%7s ", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
+	if c.IsDeleteMarker {
+		// Delete markers have no content, display them distinctly.
+		message = message + console.Colorize("DeleteMarker", fmt.Sprintf("%7s ", "DEL"))
+	} else {
+		message = message + console.Colorize("Size", fmt.Sprintf("%7s ", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
+	}
 	message = func() string {
 		if c.Filetype == "folder" {
 			return message + console.Colorize("Dir", c.Key)
 		}
 		return message + console.Colorize("File", c.Key)
 	}()
+	if c.VersionID != "" {
+		message = message + console.Colorize("VersionID", " "+c.VersionID)
+		if c.IsLatest {
+			message = message + console.Colorize("VersionID", " (latest)")
+		}
+	}
 	return message
 }
 
@@ -85,6 +100,9 @@ func parseContent(c *clientContent) contentMessage {
 	content.ETag = md5sum
 	// Convert OS Type to match console file printing style.
 	content.Key = getKey(c)
+	content.VersionID = c.VersionID
+	content.IsLatest = c.IsLatest
+	content.IsDeleteMarker = c.IsDeleteMarker
 	return content
 }
 
@@ -104,15 +122,22 @@ func getKey(c *clientContent) string {
 	return c.URL.Path
 }
 
-// doList - list all entities inside a folder.
-func doList(clnt Client, isRecursive, isIncomplete bool) error {
+// doList - list all entities inside a folder, all versions
+// of the objects are listed when withVersions is set.
+func doList(clnt Client, isRecursive, isIncomplete, withVersions bool) error {
 	prefixPath := clnt.GetURL().Path
 	separator := string(clnt.GetURL().Separator)
 	if !strings.HasSuffix(prefixPath, separator) {
 		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
 	}
+	var contentCh <-chan *clientContent
+	if withVersions {
+		contentCh = clnt.ListVersions(isRecursive)
+	} else {
+		contentCh = clnt.List(isRecursive, isIncomplete, DirNone)
+	}
 	var cErr error
-	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -293,12 +293,19 @@ var appCmds = []cli.Command{
 	shareCmd,
 	cpCmd,
 	mirrorCmd,
+	syncCmd,
 	findCmd,
 	sqlCmd,
 	statCmd,
 	diffCmd,
+	checksumCmd,
 	rmCmd,
 	eventCmd,
+	tagCmd,
+	retentionCmd,
+	legalHoldCmd,
+	ilmCmd,
+	encryptCmd,
 	watchCmd,
 	policyCmd,
 	adminCmd,
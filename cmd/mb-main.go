@@ -35,6 +35,10 @@ var (
 			Name:  "ignore-existing, p",
 			Usage: "ignore if bucket/directory already exists",
 		},
+		cli.BoolFlag{
+			Name:  "with-lock, l",
+			Usage: "enable object lock on the bucket, objects can then be protected by retention settings and legal holds",
+		},
 	}
 )
 
@@ -70,6 +74,9 @@ EXAMPLES:
    6. Create multiple directories including its missing parents (behavior similar to 'mkdir -p').
       $ {{.HelpName}} /mnt/sdb/mydisk /mnt/sdc/mydisk /mnt/sdd/mydisk
 
+   7. Create a new bucket with object lock enabled on MinIO cloud storage.
+      $ {{.HelpName}} --with-lock myminio/mylockedbucket
+
 `,
 }
 
@@ -112,6 +119,7 @@ func mainMakeBucket(ctx *cli.Context) error {
 	// Save region.
 	region := ctx.String("region")
 	ignoreExisting := ctx.Bool("p")
+	withLock := ctx.Bool("with-lock")
 
 	var cErr error
 	for _, targetURL := range ctx.Args() {
@@ -124,7 +132,7 @@ func mainMakeBucket(ctx *cli.Context) error {
 		}
 
 		// Make bucket.
-		err = clnt.MakeBucket(region, ignoreExisting)
+		err = clnt.MakeBucket(region, ignoreExisting, withLock)
 		if err != nil {
 			switch err.ToGoError().(type) {
 			case BucketNameEmpty:
@@ -25,7 +25,9 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -54,10 +56,26 @@ var (
 			Name:  "watch, w",
 			Usage: "watch and synchronize changes",
 		},
+		cli.StringFlag{
+			Name:  "watch-delay",
+			Usage: "wait until a watched object is unchanged for a duration before mirroring it, e.g. 5s",
+		},
 		cli.BoolFlag{
 			Name:  "remove",
 			Usage: "remove extraneous object(s) on target",
 		},
+		cli.IntFlag{
+			Name:  "max-delete",
+			Usage: "abort if more than N extraneous object(s) would be removed from a target, or within an hour while watching",
+		},
+		cli.IntFlag{
+			Name:  "max-delete-percent",
+			Usage: "abort if more than P percent of the object(s) of a target would be removed, or within an hour while watching",
+		},
+		cli.StringFlag{
+			Name:  "remove-to",
+			Usage: "move extraneous object(s) to a quarantine location instead of removing them, e.g. s3/trash/photos",
+		},
 		cli.StringFlag{
 			Name:  "region",
 			Usage: "specify region when creating new bucket(s) on target",
@@ -71,6 +89,18 @@ var (
 			Name:  "exclude",
 			Usage: "exclude object(s) that match specified object name pattern",
 		},
+		cli.StringFlag{
+			Name:  "exclude-from",
+			Usage: "exclude object(s) that match gitignore style patterns read from a file",
+		},
+		cli.StringSliceFlag{
+			Name:  "include",
+			Usage: "include object(s) that match a gitignore style pattern, even if they are excluded",
+		},
+		cli.StringFlag{
+			Name:  "report",
+			Usage: "record the outcome of every object in a JSON report file, or CSV if its name ends with .csv",
+		},
 		cli.StringFlag{
 			Name:  "older-than",
 			Usage: "filter object(s) older than L days, M hours and N minutes",
@@ -87,6 +117,51 @@ var (
 			Name:  "encrypt",
 			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
 		},
+		cli.StringFlag{
+			Name:  "encrypt-kms",
+			Usage: "encrypt objects using server-side encryption with KMS managed keys, e.g. \"s3/finance=my-key-id\"",
+		},
+		cli.StringFlag{
+			Name:  "encrypt-client",
+			Usage: "encrypt/decrypt objects on the client with the 32 byte key in KEYFILE",
+		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress objects on upload to object storage with gzip or zstd",
+		},
+		cli.BoolFlag{
+			Name:  "verify",
+			Usage: "verify mirrored object(s) against a checksum of the uploaded data, and mirror them again on mismatch",
+		},
+		cli.BoolFlag{
+			Name:  "checksum",
+			Usage: "compare object(s) of the same size by checksum instead of modification time",
+		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "mirror objects as they were at a date or a duration ago, e.g. 2019-05-21T18:00:00Z or 7d10h",
+		},
+		cli.StringFlag{
+			Name:  "tags",
+			Usage: "set tags on mirrored objects, e.g. \"key1=value1&key2=value2\"",
+		},
+		cli.IntFlag{
+			Name:  "parallel",
+			Usage: "mirror with a fixed number of parallel workers, instead of adapting their number to the transfers",
+		},
+		cli.IntFlag{
+			Name:  "max-parallel",
+			Usage: "maximum number of parallel workers when adapting their number to the transfers",
+			Value: maxParallelWorkers,
+		},
+		cli.IntFlag{
+			Name:  "retry-attempts",
+			Usage: "attempts of transfers failing for transient reasons, overrides the retry policy of aliases (default: 3)",
+		},
+		cli.StringFlag{
+			Name:  "retry-backoff",
+			Usage: "delay before retrying a failed transfer, doubled on every retry, e.g. 2s (default: 1s)",
+		},
 	}
 )
 
@@ -96,12 +171,12 @@ var mirrorCmd = cli.Command{
 	Usage:  "synchronize object(s) to a remote site",
 	Action: mainMirror,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(mirrorFlags, ioFlags...), limitFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] SOURCE TARGET
+  {{.HelpName}} [FLAGS] SOURCE TARGET [TARGET...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -109,6 +184,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefixes
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+   MC_ENCRYPT_KMS:  list of comma delimited prefix=key-id values, key-id may end with ?key=value encryption context
 
 EXAMPLES:
    1. Mirror a bucket recursively from MinIO cloud storage to a bucket on Amazon S3 cloud storage.
@@ -146,9 +222,76 @@ EXAMPLES:
 
   11. Mirror server encrypted objects from MinIO cloud storage to a bucket on Amazon S3 cloud storage
       $ {{.HelpName}} --encrypt-key "minio/photos=32byteslongsecretkeymustbegiven1,s3/archive=32byteslongsecretkeymustbegiven2" minio/photos/ s3/archive/
+
+  12. Mirror a versioned bucket to a local folder as it was 2 days ago.
+      $ {{.HelpName}} --rewind 2d s3/photos ~/photos-restored
+
+  13. Mirror a local folder to MinIO cloud storage and tag the mirrored objects.
+      $ {{.HelpName}} --tags "project=mc&cost-center=eng" backup/ play/archive
+
+  14. Mirror a local folder to Amazon S3 cloud storage, encrypting objects with a KMS managed key.
+      $ {{.HelpName}} --encrypt-kms "s3/finance=arn:aws:kms:us-east-1:xxx:key/xxx" ledgers/ s3/finance/ledgers
+
+  15. Mirror a local folder to Amazon S3 cloud storage, encrypting objects on the client before upload.
+      $ {{.HelpName}} --encrypt-client ~/.mc/backup.key ledgers/ s3/backups/ledgers
+
+  16. Mirror a local folder of logs to MinIO cloud storage, compressing objects on upload.
+      $ {{.HelpName}} --compress gzip /var/log/nginx/ play/logs/nginx
+
+  17. Mirror a local folder to MinIO cloud storage, overwriting objects whose content differs from the local files.
+      $ {{.HelpName}} --checksum --overwrite backup/ play/archive
+
+  18. Mirror a local folder to MinIO cloud storage and verify the content of the mirrored objects.
+      $ {{.HelpName}} --verify backup/ play/archive
+
+  19. Mirror a local folder to MinIO cloud storage and Amazon S3 cloud storage, listing the local folder only once.
+      $ {{.HelpName}} backup/ play/archive s3/archive
+
+  20. Continuously mirror a local folder to Amazon S3 cloud storage, following the upload bandwidth limit
+      written in a file, which can be changed while mirroring, e.g. "10MiB/s" during office hours.
+      $ {{.HelpName}} --watch --limit-upload @/etc/mc/upload-limit /var/lib/backups s3/backups
+
+  21. Mirror a local folder to Amazon S3 cloud storage with at most 16 parallel workers.
+      $ {{.HelpName}} --max-parallel 16 backup/ s3/archive
+
+  22. Mirror a local folder to Amazon S3 cloud storage, retrying transient failures up to 10 times.
+      $ {{.HelpName}} --retry-attempts 10 backup/ s3/archive
+
+  23. Mirror a large bucket to Amazon S3 cloud storage, an interrupted mirror can be continued with 'mc session resume'.
+      $ {{.HelpName}} --remove --overwrite play/photos s3/backup-photos
+
+  24. Mirror a bucket to Amazon S3 cloud storage and remove extraneous objects, aborting without any removal if
+      more than 100 objects or more than 5 percent of the objects of the target would be removed.
+      $ {{.HelpName}} --remove --max-delete 100 --max-delete-percent 5 play/photos s3/backup-photos
+
+  25. Mirror a bucket to Amazon S3 cloud storage, moving extraneous objects to a quarantine bucket instead of
+      removing them.
+      $ {{.HelpName}} --remove --remove-to s3/quarantine/photos play/photos s3/backup-photos
+
+  26. Continuously mirror a local folder to MinIO cloud storage as a service. Changes made while mc was stopped
+      are caught up on start, and an object written several times within 10 seconds is mirrored once.
+      $ {{.HelpName}} --watch --watch-delay 10s /var/lib/backups play/backups
+
+  27. Mirror a local folder to MinIO cloud storage, skipping the paths listed in gitignore style in ~/.backupignore
+      and in the '.mcignore' files of the folder, but always mirroring '*.conf' files.
+      $ {{.HelpName}} --exclude-from ~/.backupignore --include "*.conf" /etc play/etc
+
+  28. Mirror a bucket to Amazon S3 cloud storage and record the outcome of every object in a JSON report.
+      $ {{.HelpName}} --report /var/log/mc/backup-report.json play/photos s3/backup-photos
 `,
 }
 
+// mirrorStallTimeout - how long a target may hold up the others
+// before it is left behind.
+const mirrorStallTimeout = 5 * time.Minute
+
+// Period the removals of a watching mirror are counted against the
+// deletion limits for.
+const mirrorDeleteLimitWindow = time.Hour
+
+// Number of watch events queued for every target.
+const mirrorWatchQueueSize = 1000
+
 type mirrorJob struct {
 
 	// the channel to trap SIGKILL signals
@@ -165,8 +308,16 @@ type mirrorJob struct {
 	// Hold operation status information
 	status Status
 
-	queueCh  chan func() URLs
-	parallel *ParallelManager
+	// targets of the mirror, each one with its own workers
+	targets []*mirrorTarget
+
+	// closed when the mirror is interrupted or aborted
+	stopCh chan struct{}
+
+	// closed when the mirror is aborted, as a target exceeds the
+	// deletion limits
+	abortCh   chan struct{}
+	abortOnce sync.Once
 
 	// channel for status messages
 	statusCh chan URLs
@@ -175,14 +326,227 @@ type mirrorJob struct {
 	TotalBytes   int64
 
 	sourceURL string
-	targetURL string
 
 	isFake, isRemove, isOverwrite, isWatch bool
+	isChecksum, isVerify                   bool
 	olderThan, newerThan                   string
-	storageClass                           string
+	storageClass, compression              string
+	tags                                   map[string]string
+
+	// objects are mirrored as they were at timeRef, if set
+	timeRef time.Time
 
 	excludeOptions []string
+	excludeRules   *excludeRules
 	encKeyDB       map[string][]prefixSSEPair
+
+	// outcome of every object, if requested
+	report *transferReport
+
+	// limits of the extraneous objects removed from a target,
+	// negative limits are not enforced
+	maxDelete, maxDeletePercent int
+
+	// extraneous objects are moved below removeTo, if set
+	removeTo string
+
+	// watch events of a path are coalesced until it is unchanged
+	// for watchDelay, and journaled until they are mirrored
+	watchDelay    time.Duration
+	journal       *watchJournal
+	journalEvents []watchJournalEvent
+
+	// session holding the delta of a mirror which is not
+	// watching, so that it can be resumed when interrupted
+	session *sessionV9
+
+	// session data the delta is saved in as the targets are
+	// compared, a watching mirror only saves the removals held
+	// back until they are checked against the deletion limits
+	sessionData *sessionV9
+}
+
+// mirrorTarget - a target of a mirror job. Every target is compared
+// to the source on its own and has its own workers and error
+// accounting, so that a slow target does not hold up the others.
+type mirrorTarget struct {
+	url string
+
+	queueCh  chan func() URLs
+	parallel *ParallelManager
+
+	// watch events which are yet to be mirrored to this target
+	watchCh chan mirrorWatchEvent
+
+	// once the target does not take watch events for
+	// stallTimeout it is stalled, and the latest event of
+	// every path is kept in missed until it caught up
+	stallTimeout time.Duration
+	mu           sync.Mutex
+	missed       map[string]mirrorWatchEvent
+
+	// objects found on the target once it is compared to the
+	// source, and objects removed from it since removalsSince, the
+	// removals of the comparison and of watch events count against
+	// the deletion limits alike
+	objects       int64
+	removals      int64
+	removalsSince time.Time
+
+	errors int64
+}
+
+// mirrorWatchEvent - a watch event of a path to be mirrored to a
+// target, finish is called once it is mirrored or superseded by a
+// later event of the path. Events without mirror only wake up the
+// target to catch up.
+type mirrorWatchEvent struct {
+	path   string
+	mirror func()
+	finish func()
+}
+
+// queueWatchEvent - queue a watch event for the target, a stalled
+// target keeps the event until it caught up. Returns true if the
+// target stalled on this event, and false for ok once the mirror
+// is interrupted.
+func (t *mirrorTarget) queueWatchEvent(event mirrorWatchEvent, stopCh <-chan struct{}) (stalled, ok bool) {
+	if t.keepMissed(event, false) {
+		return false, true
+	}
+	select {
+	case t.watchCh <- event:
+		return false, true
+	case <-time.After(t.stallTimeout):
+		t.keepMissed(event, true)
+		return true, true
+	case <-stopCh:
+		return false, false
+	}
+}
+
+// keepMissed - keep the event if the target is stalled, or is
+// stalling, superseding an earlier event of its path. Returns false
+// if the target is not stalled.
+func (t *mirrorTarget) keepMissed(event mirrorWatchEvent, isStalling bool) bool {
+	t.mu.Lock()
+	if t.missed == nil {
+		if !isStalling {
+			t.mu.Unlock()
+			return false
+		}
+		t.missed = make(map[string]mirrorWatchEvent)
+	}
+	superseded, ok := t.missed[event.path]
+	t.missed[event.path] = event
+	t.mu.Unlock()
+	if ok {
+		superseded.finish()
+	}
+	// Wake up the target in case its queue is empty already.
+	select {
+	case t.watchCh <- mirrorWatchEvent{}:
+	default:
+	}
+	return true
+}
+
+// mirrorWatchEvents - mirror the watch events of the target until
+// they end. Once the queue is empty, the events missed while the
+// target was stalled are mirrored to catch up.
+func (t *mirrorTarget) mirrorWatchEvents() {
+	for event := range t.watchCh {
+		if event.mirror != nil {
+			event.mirror()
+			event.finish()
+		}
+		if len(t.watchCh) > 0 {
+			continue
+		}
+		t.mu.Lock()
+		missed := t.missed
+		t.missed = nil
+		t.mu.Unlock()
+		for _, event := range missed {
+			event.mirror()
+			event.finish()
+		}
+	}
+}
+
+// account - count the failure of a mirroring action on the target.
+func (t *mirrorTarget) account(sURLs URLs) URLs {
+	if isMirrorFailure(sURLs) {
+		atomic.AddInt64(&t.errors, 1)
+	}
+	return sURLs
+}
+
+// isMirrorFailure - tells whether a mirroring action failed, errors
+// which are ignored for the copy of an object are no failures.
+func isMirrorFailure(sURLs URLs) bool {
+	return sURLs.Error != nil && (sURLs.SourceContent == nil || !isErrIgnored(sURLs.Error))
+}
+
+// checkDeleteLimits - returns an error if removing removals out of
+// the targetObjects found on a target exceeds maxDelete objects or
+// maxDeletePercent percent of them, negative limits are not enforced.
+func checkDeleteLimits(removals, targetObjects int64, maxDelete, maxDeletePercent int) *probe.Error {
+	if maxDelete >= 0 && removals > int64(maxDelete) {
+		return errDeleteLimitExceeded(removals, targetObjects)
+	}
+	if maxDeletePercent >= 0 && removals*100 > int64(maxDeletePercent)*targetObjects {
+		return errDeleteLimitExceeded(removals, targetObjects)
+	}
+	return nil
+}
+
+// reserveRemovals - count n removals from the target against the
+// deletion limits before they are done, nothing is counted if they
+// would exceed the limits. Until the target is compared no objects
+// are known, a percentage limit refuses any removal. While watching
+// removals are counted per mirrorDeleteLimitWindow, the objects
+// removed before are gone from the target by then.
+func (t *mirrorTarget) reserveRemovals(n int64, maxDelete, maxDeletePercent int, now time.Time) *probe.Error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Sub(t.removalsSince) >= mirrorDeleteLimitWindow {
+		t.objects -= t.removals
+		t.removals = 0
+		t.removalsSince = now
+	}
+	if err := checkDeleteLimits(t.removals+n, t.objects, maxDelete, maxDeletePercent); err != nil {
+		return err
+	}
+	t.removals += n
+	return nil
+}
+
+// mirrorTargetMessage container for the outcome of mirroring to one of several targets
+type mirrorTargetMessage struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+	Errors int64  `json:"errors"`
+}
+
+// String colorized mirror target message
+func (m mirrorTargetMessage) String() string {
+	if m.Errors == 0 {
+		return console.Colorize("Mirror", fmt.Sprintf("Mirroring to `%s` completed.", m.Target))
+	}
+	return console.Colorize("MirrorFailed", fmt.Sprintf("Mirroring to `%s` completed with %d error(s).", m.Target, m.Errors))
+}
+
+// JSON jsonified mirror target message
+func (m mirrorTargetMessage) JSON() string {
+	m.Status = "success"
+	if m.Errors > 0 {
+		m.Status = "error"
+	}
+	mirrorMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(mirrorMessageBytes)
 }
 
 // mirrorMessage container for file mirror messages
@@ -209,12 +573,19 @@ func (m mirrorMessage) JSON() string {
 	return string(mirrorMessageBytes)
 }
 
-// doRemove - removes files on target.
-func (mj *mirrorJob) doRemove(sURLs URLs) URLs {
+// doRemove - removes files on target, they are moved to the
+// quarantine location first if there is one.
+func (mj *mirrorJob) doRemove(ctx context.Context, target *mirrorTarget, sURLs URLs) URLs {
 	if mj.isFake {
 		return sURLs.WithError(nil)
 	}
 
+	if mj.removeTo != "" {
+		if pErr := mj.quarantine(ctx, target, sURLs); pErr != nil {
+			return sURLs.WithError(pErr)
+		}
+	}
+
 	// Construct proper path with alias.
 	targetWithAlias := filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)
 	clnt, pErr := newClient(targetWithAlias)
@@ -234,6 +605,14 @@ func (mj *mirrorJob) doRemove(sURLs URLs) URLs {
 				// Ignore Permission error.
 				continue
 			}
+			// Coalesced or journaled watch events may remove
+			// an object which is already gone.
+			if os.IsNotExist(pErr.ToGoError()) {
+				continue
+			}
+			if isErrThrottled(pErr) {
+				target.parallel.reportThrottled()
+			}
 			return sURLs.WithError(pErr)
 		}
 	}
@@ -241,8 +620,49 @@ func (mj *mirrorJob) doRemove(sURLs URLs) URLs {
 	return sURLs.WithError(nil)
 }
 
+// quarantine - copy an extraneous object of the target below the
+// quarantine location, at the same path relative to the target.
+func (mj *mirrorJob) quarantine(ctx context.Context, target *mirrorTarget, sURLs URLs) *probe.Error {
+	targetURL := target.url
+	if separator := string(newClientURL(targetURL).Separator); !strings.HasSuffix(targetURL, separator) {
+		targetURL = targetURL + separator
+	}
+	_, targetURL, _ = mustExpandAlias(targetURL)
+	targetSuffix := strings.TrimPrefix(sURLs.TargetContent.URL.String(), targetURL)
+	removeToAlias, removeToURL, _ := mustExpandAlias(urlJoinPath(mj.removeTo, targetSuffix))
+
+	// Removals noticed by watching the source do not know the size
+	// of the extraneous object yet.
+	sourceContent := *sURLs.TargetContent
+	if sourceContent.Size == 0 {
+		targetPath := filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
+		targetClnt, err := newClientFromAlias(sURLs.TargetAlias, sURLs.TargetContent.URL.String())
+		if err != nil {
+			return err.Trace(targetPath)
+		}
+		content, err := targetClnt.Stat(false, false, getSSE(targetPath, mj.encKeyDB[sURLs.TargetAlias]))
+		if err != nil {
+			switch err.ToGoError().(type) {
+			case PathNotFound, ObjectMissing:
+				// Nothing to keep, the object is already gone.
+				return nil
+			}
+			return err.Trace(targetPath)
+		}
+		sourceContent.Size = content.Size
+	}
+
+	moveURLs := URLs{
+		SourceAlias:   sURLs.TargetAlias,
+		SourceContent: &sourceContent,
+		TargetAlias:   removeToAlias,
+		TargetContent: &clientContent{URL: *newClientURL(removeToURL)},
+	}
+	return retryUploadSourceToTargetURL(ctx, moveURLs, nil, mj.encKeyDB, false, mj.status.PrintMsg, target.parallel.reportThrottled).Error
+}
+
 // doMirror - Mirror an object to multiple destination. URLs status contains a copy of sURLs and error if any.
-func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFunc, sURLs URLs) URLs {
+func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFunc, target *mirrorTarget, sURLs URLs) URLs {
 
 	if sURLs.Error != nil { // Erroneous sURLs passed.
 		return sURLs.WithError(sURLs.Error.Trace())
@@ -270,6 +690,17 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 		sURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = mj.storageClass
 	}
 
+	if mj.compression != "" {
+		if sURLs.TargetContent.Metadata == nil {
+			sURLs.TargetContent.Metadata = make(map[string]string)
+		}
+		sURLs.TargetContent.Metadata[compressionMeta] = mj.compression
+	}
+
+	if len(mj.tags) != 0 {
+		sURLs.TargetContent.Tags = mj.tags
+	}
+
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
 	mj.status.PrintMsg(mirrorMessage{
@@ -279,7 +710,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 		TotalCount: sURLs.TotalCount,
 		TotalSize:  sURLs.TotalSize,
 	})
-	return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.encKeyDB)
+	return retryUploadSourceToTargetURL(ctx, sURLs, mj.status, mj.encKeyDB, mj.isVerify, mj.status.PrintMsg, target.parallel.reportThrottled)
 }
 
 // Update progress status
@@ -288,9 +719,42 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 	mj.status.Start()
 	defer mj.status.Finish()
 
+	// Objects failing verification are summarized at the end, as
+	// well as the errors of every target of a multi target mirror.
+	var corrupted []string
+	defer func() {
+		if len(corrupted) > 0 {
+			mj.status.PrintMsg(verifySummaryMessage{Failed: corrupted})
+		}
+		if len(mj.targets) > 1 && !mj.isStopped() {
+			for _, target := range mj.targets {
+				mj.status.PrintMsg(mirrorTargetMessage{
+					Target: target.url,
+					Errors: atomic.LoadInt64(&target.errors),
+				})
+			}
+		}
+	}()
+
 	for sURLs := range mj.statusCh {
+		switch {
+		case sURLs.Error == nil && sURLs.SourceContent != nil:
+			mj.report.recordURLs(sURLs, reportCopied, "")
+		case sURLs.Error == nil:
+			mj.report.recordURLs(sURLs, reportRemoved, "")
+		case sURLs.SourceContent != nil && isErrIgnored(sURLs.Error):
+			mj.report.recordURLs(sURLs, reportSkipped, "")
+		default:
+			mj.report.recordURLs(sURLs, reportFailed, "")
+		}
+
 		if sURLs.Error != nil {
 			switch {
+			case sURLs.SourceContent != nil && isErrCorrupted(sURLs.Error):
+				errorIf(sURLs.Error.Trace(sURLs.SourceContent.URL.String()),
+					fmt.Sprintf("Failed to copy `%s`.", sURLs.SourceContent.URL.String()))
+				corrupted = append(corrupted, sURLs.SourceContent.URL.String())
+				errDuringMirror = true
 			case sURLs.SourceContent != nil:
 				if !isErrIgnored(sURLs.Error) {
 					errorIf(sURLs.Error.Trace(sURLs.SourceContent.URL.String()),
@@ -299,8 +763,12 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 				}
 			case sURLs.TargetContent != nil:
 				// When sURLs.SourceContent is nil, we know that we have an error related to removing
+				msg := "Failed to remove `%s`."
+				if _, ok := sURLs.Error.ToGoError().(ObjectLocked); ok {
+					msg = "Failed to remove `%s`, it is protected by object lock."
+				}
 				errorIf(sURLs.Error.Trace(sURLs.TargetContent.URL.String()),
-					fmt.Sprintf("Failed to remove `%s`.", sURLs.TargetContent.URL.String()))
+					fmt.Sprintf(msg, sURLs.TargetContent.URL.String()))
 				errDuringMirror = true
 			default:
 				errorIf(sURLs.Error.Trace(), "Failed to perform mirroring action.")
@@ -315,145 +783,94 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 			size := sURLs.TargetContent.Size
 			mj.status.PrintMsg(rmMessage{Key: targetPath, Size: size})
 		}
+
+		// Errors of actions cut short by an interruption do not
+		// complete the action, a resumed session retries it.
+		if mj.session != nil && sURLs.TargetContent != nil && (sURLs.Error == nil || !mj.isStopped()) {
+			status := sessionEntryDone
+			if sURLs.Error != nil {
+				status = sessionEntryFailed
+			}
+			mj.session.SetEntryStatus(sURLs.sessionEntry, status)
+		}
 	}
 
 	return
 }
 
-// this goroutine will watch for notifications, and add modified objects to the queue
+// watchPending - the coalesced events of a path which are yet to be
+// mirrored, only the latest event is mirrored.
+type watchPending struct {
+	event      EventInfo
+	seqs       []int64
+	generation int64
+}
+
+// watchReady - a path whose latest event was not followed by another
+// one within the watch delay.
+type watchReady struct {
+	path       string
+	generation int64
+}
+
+// this goroutine will watch for notifications, and add modified objects to the queue.
+// Events are journaled until they are mirrored, and the events of a path which follow
+// each other within the watch delay are coalesced into one.
 func (mj *mirrorJob) watchMirror(ctx context.Context, cancelMirror context.CancelFunc) {
+	pending := make(map[string]*watchPending)
+	readyCh := make(chan watchReady)
+	schedule := func(event EventInfo, seq int64) bool {
+		if mj.watchDelay <= 0 {
+			return mj.watchMirrorEvent(ctx, cancelMirror, event, []int64{seq})
+		}
+		p, ok := pending[event.Path]
+		if !ok {
+			p = &watchPending{}
+			pending[event.Path] = p
+		}
+		p.event = event
+		p.seqs = append(p.seqs, seq)
+		p.generation++
+		ready := watchReady{path: event.Path, generation: p.generation}
+		time.AfterFunc(mj.watchDelay, func() {
+			select {
+			case readyCh <- ready:
+			case <-mj.stopCh:
+			}
+		})
+		return true
+	}
+
+	// Events pending when mc was last stopped are mirrored first.
+	for _, journaled := range mj.journalEvents {
+		if !schedule(journaled.Event, journaled.Seq) {
+			return
+		}
+	}
+
 	for {
 		select {
 		case event, ok := <-mj.watcher.Events():
 			if !ok {
 				return
 			}
-
-			// It will change the expanded alias back to the alias
-			// again, by replacing the sourceUrlFull with the sourceAlias.
-			// This url will be used to mirror.
-			sourceAlias, sourceURLFull, _ := mustExpandAlias(mj.sourceURL)
-
-			// If the passed source URL points to fs, fetch the absolute src path
-			// to correctly calculate targetPath
-			if sourceAlias == "" {
-				tmpSrcURL, err := filepath.Abs(sourceURLFull)
-				if err == nil {
-					sourceURLFull = tmpSrcURL
-				}
+			seq, err := mj.journal.add(event)
+			if err != nil {
+				mj.statusCh <- URLs{Error: err.Trace(event.Path)}
 			}
-			eventPath := event.Path
-
-			if runtime.GOOS == "darwin" {
-				// Strip the prefixes in the event path. Happens in darwin OS only
-				eventPath = eventPath[strings.Index(eventPath, sourceURLFull):]
+			if !schedule(event, seq) {
+				return
 			}
-
-			sourceURL := newClientURL(eventPath)
-			// trim trailing slash from source url
-			sourceURLStr := strings.TrimSuffix(sourceURLFull, string(sourceURL.Separator))
-			aliasedPath := strings.Replace(eventPath, sourceURLStr, mj.sourceURL, -1)
-
-			// build target path, it is the relative of the eventPath with the sourceUrl
-			// joined to the targetURL.
-			sourceSuffix := strings.TrimPrefix(eventPath, sourceURLFull)
-			//Skip the object, if it matches the Exclude options provided
-			if matchExcludeOptions(mj.excludeOptions, sourceSuffix) {
+		case ready := <-readyCh:
+			p, ok := pending[ready.path]
+			if !ok || p.generation != ready.generation {
+				// A later event of the path is not ready yet.
 				continue
 			}
-
-			targetPath := urlJoinPath(mj.targetURL, sourceSuffix)
-
-			// newClient needs the unexpanded  path, newCLientURL needs the expanded path
-			targetAlias, expandedTargetPath, _ := mustExpandAlias(targetPath)
-			targetURL := newClientURL(expandedTargetPath)
-			sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
-			srcSSE := getSSE(sourcePath, mj.encKeyDB[sourceAlias])
-			tgtSSE := getSSE(targetPath, mj.encKeyDB[targetAlias])
-
-			if event.Type == EventCreate {
-				// we are checking if a destination file exists now, and if we only
-				// overwrite it when force is enabled.
-				mirrorURL := URLs{
-					SourceAlias:   sourceAlias,
-					SourceContent: &clientContent{URL: *sourceURL},
-					TargetAlias:   targetAlias,
-					TargetContent: &clientContent{URL: *targetURL},
-					encKeyDB:      mj.encKeyDB,
-				}
-				if event.Size == 0 {
-					sourceClient, err := newClient(aliasedPath)
-					if err != nil {
-						// cannot create sourceclient
-						mj.statusCh <- mirrorURL.WithError(err)
-						continue
-					}
-					sourceContent, err := sourceClient.Stat(false, false, srcSSE)
-					if err != nil {
-						// source doesn't exist anymore
-						mj.statusCh <- mirrorURL.WithError(err)
-						continue
-					}
-					targetClient, err := newClient(targetPath)
-					if err != nil {
-						// cannot create targetclient
-						mj.statusCh <- mirrorURL.WithError(err)
-						return
-					}
-					shouldQueue := false
-					if !mj.isOverwrite {
-						_, err = targetClient.Stat(false, false, tgtSSE)
-						if err == nil {
-							continue
-						} // doesn't exist
-						shouldQueue = true
-					}
-					if shouldQueue || mj.isOverwrite {
-						mirrorURL.TotalCount = mj.TotalObjects
-						mirrorURL.TotalSize = mj.TotalBytes
-						// adjust total, because we want to show progress of the item still queued to be copied.
-						mj.status.SetTotal(mj.status.Total() + sourceContent.Size).Update()
-						mj.statusCh <- mj.doMirror(ctx, cancelMirror, mirrorURL)
-					}
-					continue
-				}
-				shouldQueue := false
-				if !mj.isOverwrite {
-					targetClient, err := newClient(targetPath)
-					if err != nil {
-						// cannot create targetclient
-						mj.statusCh <- mirrorURL.WithError(err)
-						return
-					}
-					_, err = targetClient.Stat(false, false, tgtSSE)
-					if err == nil {
-						continue
-					} // doesn't exist
-					shouldQueue = true
-				}
-				if shouldQueue || mj.isOverwrite {
-					mirrorURL.SourceContent.Size = event.Size
-					mirrorURL.TotalCount = mj.TotalObjects
-					mirrorURL.TotalSize = mj.TotalBytes
-					// adjust total, because we want to show progress of the itemj stiil queued to be copied.
-					mj.status.SetTotal(mj.status.Total() + event.Size).Update()
-					mj.statusCh <- mj.doMirror(ctx, cancelMirror, mirrorURL)
-				}
-			} else if event.Type == EventRemove {
-				mirrorURL := URLs{
-					SourceAlias:   sourceAlias,
-					SourceContent: nil,
-					TargetAlias:   targetAlias,
-					TargetContent: &clientContent{URL: *targetURL},
-					encKeyDB:      mj.encKeyDB,
-				}
-				mirrorURL.TotalCount = mj.TotalObjects
-				mirrorURL.TotalSize = mj.TotalBytes
-				if mirrorURL.TargetContent != nil && mj.isRemove {
-					mj.statusCh <- mj.doRemove(mirrorURL)
-				}
+			delete(pending, ready.path)
+			if !mj.watchMirrorEvent(ctx, cancelMirror, p.event, p.seqs) {
+				return
 			}
-
 		case err := <-mj.watcher.Errors():
 			switch err.ToGoError().(type) {
 			case APINotImplemented:
@@ -462,77 +879,531 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, cancelMirror context.Cance
 			}
 			mj.statusCh <- URLs{Error: err}
 			return
-		case <-mj.trapCh:
+		case <-mj.stopCh:
 			return
 		}
 	}
 }
 
+// watchMirrorEvent - hand an event over to all targets, the journaled
+// events seqs are done once all targets mirrored the event, failed
+// events stay pending. Returns false once the mirror is interrupted.
+func (mj *mirrorJob) watchMirrorEvent(ctx context.Context, cancelMirror context.CancelFunc, event EventInfo, seqs []int64) bool {
+	done := func() {
+		if err := mj.journal.done(seqs...); err != nil {
+			mj.statusCh <- URLs{Error: err.Trace(event.Path)}
+		}
+	}
+
+	// It will change the expanded alias back to the alias
+	// again, by replacing the sourceUrlFull with the sourceAlias.
+	// This url will be used to mirror.
+	sourceAlias, sourceURLFull, _ := mustExpandAlias(mj.sourceURL)
+
+	// If the passed source URL points to fs, fetch the absolute src path
+	// to correctly calculate targetPath
+	if sourceAlias == "" {
+		tmpSrcURL, err := filepath.Abs(sourceURLFull)
+		if err == nil {
+			sourceURLFull = tmpSrcURL
+		}
+	}
+	eventPath := event.Path
+
+	if runtime.GOOS == "darwin" {
+		// Strip the prefixes in the event path. Happens in darwin OS only
+		eventPath = eventPath[strings.Index(eventPath, sourceURLFull):]
+	}
+
+	sourceURL := newClientURL(eventPath)
+	// trim trailing slash from source url
+	sourceURLStr := strings.TrimSuffix(sourceURLFull, string(sourceURL.Separator))
+	aliasedPath := strings.Replace(eventPath, sourceURLStr, mj.sourceURL, -1)
+
+	// build target path, it is the relative of the eventPath with the sourceUrl
+	// joined to the targetURL.
+	sourceSuffix := strings.TrimPrefix(eventPath, sourceURLFull)
+	var excludeRoot string
+	if sourceAlias == "" {
+		excludeRoot = sourceURLFull
+		// Changed exclusion patterns apply to the following events.
+		if path.Base(sourceSuffix) == mcIgnoreFile {
+			mj.excludeRules.forget(excludeRoot, path.Dir(filepath.ToSlash(sourceSuffix)))
+		}
+	}
+	//Skip the object, if it matches the Exclude options provided
+	if matchExcludeOptions(mj.excludeOptions, sourceSuffix) || mj.excludeRules.isExcluded(excludeRoot, sourceSuffix, false) {
+		done()
+		return true
+	}
+
+	// Every target mirrors the event on its own, the event stays
+	// in the journal until all targets mirrored it, and for good
+	// if one of them failed to. A target which does not keep up
+	// does not hold up the others.
+	remaining := int32(len(mj.targets))
+	var failed int32
+	for _, target := range mj.targets {
+		target := target
+		mirrorEvent := mirrorWatchEvent{
+			path: event.Path,
+			mirror: func() {
+				if !mj.watchMirrorTarget(ctx, cancelMirror, target, event, sourceAlias, sourceURL, aliasedPath, sourceSuffix) {
+					atomic.StoreInt32(&failed, 1)
+				}
+			},
+			finish: func() {
+				if atomic.AddInt32(&remaining, -1) == 0 && atomic.LoadInt32(&failed) == 0 {
+					done()
+				}
+			},
+		}
+		stalled, ok := target.queueWatchEvent(mirrorEvent, mj.stopCh)
+		if !ok {
+			return false
+		}
+		if stalled {
+			mj.statusCh <- target.account(URLs{Error: errTargetWatchStalled(target.stallTimeout).Trace(target.url, aliasedPath)})
+		}
+	}
+	return true
+}
+
+// watchMirrorTarget - mirror a single watch event to a target,
+// returns false if it failed.
+func (mj *mirrorJob) watchMirrorTarget(ctx context.Context, cancelMirror context.CancelFunc, target *mirrorTarget, event EventInfo, sourceAlias string, sourceURL *clientURL, aliasedPath, sourceSuffix string) bool {
+	targetPath := urlJoinPath(target.url, sourceSuffix)
+
+	// newClient needs the unexpanded  path, newCLientURL needs the expanded path
+	targetAlias, expandedTargetPath, _ := mustExpandAlias(targetPath)
+	targetURL := newClientURL(expandedTargetPath)
+	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
+	srcSSE := getSSE(sourcePath, mj.encKeyDB[sourceAlias])
+	tgtSSE := getSSE(targetPath, mj.encKeyDB[targetAlias])
+
+	if event.Type == EventCreate {
+		// we are checking if a destination file exists now, and if we only
+		// overwrite it when force is enabled.
+		mirrorURL := URLs{
+			SourceAlias:   sourceAlias,
+			SourceContent: &clientContent{URL: *sourceURL},
+			TargetAlias:   targetAlias,
+			TargetContent: &clientContent{URL: *targetURL},
+			encKeyDB:      mj.encKeyDB,
+		}
+		size := event.Size
+		if size == 0 {
+			sourceClient, err := newClient(aliasedPath)
+			if err != nil {
+				// cannot create sourceclient
+				return mj.reportWatchResult(target, mirrorURL.WithError(err))
+			}
+			sourceContent, err := sourceClient.Stat(false, false, srcSSE)
+			if err != nil {
+				// source doesn't exist anymore
+				return mj.reportWatchResult(target, mirrorURL.WithError(err))
+			}
+			size = sourceContent.Size
+		} else {
+			mirrorURL.SourceContent.Size = size
+		}
+		if !mj.isOverwrite {
+			targetClient, err := newClient(targetPath)
+			if err != nil {
+				// cannot create targetclient
+				return mj.reportWatchResult(target, mirrorURL.WithError(err))
+			}
+			if _, err = targetClient.Stat(false, false, tgtSSE); err == nil {
+				return true
+			} // doesn't exist
+		}
+		mirrorURL.TotalCount = atomic.LoadInt64(&mj.TotalObjects)
+		mirrorURL.TotalSize = atomic.LoadInt64(&mj.TotalBytes)
+		// adjust total, because we want to show progress of the item still queued to be copied.
+		mj.status.SetTotal(mj.status.Total() + size).Update()
+		return mj.reportWatchResult(target, mj.doMirror(ctx, cancelMirror, target, mirrorURL))
+	} else if event.Type == EventRemove {
+		mirrorURL := URLs{
+			SourceAlias:   sourceAlias,
+			SourceContent: nil,
+			TargetAlias:   targetAlias,
+			TargetContent: &clientContent{URL: *targetURL},
+			encKeyDB:      mj.encKeyDB,
+		}
+		mirrorURL.TotalCount = atomic.LoadInt64(&mj.TotalObjects)
+		mirrorURL.TotalSize = atomic.LoadInt64(&mj.TotalBytes)
+		if mirrorURL.TargetContent != nil && mj.isRemove {
+			if err := target.reserveRemovals(1, mj.maxDelete, mj.maxDeletePercent, UTCNow()); err != nil {
+				// Like the comparison, the mirror is aborted
+				// without the removal.
+				ok := mj.reportWatchResult(target, mirrorURL.WithError(err.Trace(target.url)))
+				mj.abort()
+				return ok
+			}
+			return mj.reportWatchResult(target, mj.doRemove(ctx, target, mirrorURL))
+		}
+	}
+	return true
+}
+
+// reportWatchResult - report the result of mirroring a watch event
+// to the target, returns false if mirroring the event failed.
+func (mj *mirrorJob) reportWatchResult(target *mirrorTarget, sURLs URLs) bool {
+	mj.statusCh <- target.account(sURLs)
+	return !isMirrorFailure(sURLs)
+}
+
 func (mj *mirrorJob) watchURL(sourceClient Client) *probe.Error {
 	return mj.watcher.Join(sourceClient, true)
 }
 
-// Fetch urls that need to be mirrored
+// Fetch urls that need to be mirrored, a resumed session mirrors the
+// urls saved in it instead.
 func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.CancelFunc) {
-	var totalBytes int64
-	var totalObjects int64
+	if mj.session != nil && mj.session.HasData() {
+		mj.resumeMirrorSession(ctx, cancelMirror)
+		return
+	}
+
+	// A session interrupted before all targets were compared is
+	// compared again once resumed, the objects mirrored already are
+	// up to date by then.
+	if mj.session != nil {
+		if err := mj.session.ResetData(); err != nil {
+			mj.session.Delete()
+			mj.status.fatalIf(err.Trace(), "Unable to prepare session data.")
+		}
+	}
+
+	URLsChs, targetObjects := prepareMirrorURLs(mj.sourceURL, mj.targetURLs(), mj.timeRef, mj.isFake, mj.isOverwrite, mj.isRemove, mj.isChecksum, mj.excludeOptions, mj.excludeRules, mj.report, mj.encKeyDB)
 
-	stopParallel := func() {
-		close(mj.queueCh)
-		mj.parallel.wait()
+	var wg sync.WaitGroup
+	removals := make([]int64, len(mj.targets))
+	compared := make([]bool, len(mj.targets))
+	for i, target := range mj.targets {
+		wg.Add(1)
+		go func(i int, target *mirrorTarget, URLsCh <-chan URLs) {
+			defer wg.Done()
+			removals[i], compared[i] = mj.startMirrorTarget(ctx, cancelMirror, i, target, URLsCh)
+		}(i, target, URLsChs[i])
+	}
+	wg.Wait()
+
+	if mj.isStopped() {
+		mj.stopTargets()
+		cancelMirror()
+		return
+	}
+	if !mj.releaseRemovals(ctx, cancelMirror, removals, targetObjects, compared) {
+		mj.stopTargets()
+		if !mj.isAborted() {
+			cancelMirror()
+		}
+		return
 	}
 
-	URLsCh := prepareMirrorURLs(mj.sourceURL, mj.targetURL, mj.isFake, mj.isOverwrite, mj.isRemove, mj.excludeOptions, mj.encKeyDB)
+	// All actions are saved, once resumed the session does not
+	// compare the targets again.
+	if mj.session != nil {
+		mj.session.Header.TotalBytes = atomic.LoadInt64(&mj.TotalBytes)
+		mj.session.Header.TotalObjects = atomic.LoadInt64(&mj.TotalObjects)
+		if err := mj.session.Save(); err != nil {
+			errorIf(err.Trace(), "Unable to save session.")
+		}
+	}
+	mj.stopTargets()
+}
 
+// Queue urls that need to be mirrored to a target as it is compared,
+// the actions are saved in the session first. With deletion limits
+// removals are held back in the session data until all targets are
+// compared. A target stops at its first error. Returns the removals
+// from the target, and whether it was compared.
+func (mj *mirrorJob) startMirrorTarget(ctx context.Context, cancelMirror context.CancelFunc, index int, target *mirrorTarget, URLsCh <-chan URLs) (removals int64, compared bool) {
+	isLimited := mj.maxDelete >= 0 || mj.maxDeletePercent >= 0
 	for {
 		select {
 		case sURLs, ok := <-URLsCh:
 			if !ok {
-				stopParallel()
-				return
+				return removals, true
 			}
 			if sURLs.Error != nil {
-				stopParallel()
-				mj.statusCh <- sURLs
-				return
+				mj.statusCh <- target.account(sURLs)
+				// Let the comparison end, so that the other
+				// targets are not held up.
+				go func() {
+					for range URLsCh {
+					}
+				}()
+				return removals, false
 			}
 
+			isRemoval := false
 			if sURLs.SourceContent != nil {
 				if mj.olderThan != "" && isOlder(sURLs.SourceContent.Time, mj.olderThan) {
+					mj.report.recordURLs(sURLs, reportSkipped, "not older than "+mj.olderThan)
 					continue
 				}
 				if mj.newerThan != "" && isNewer(sURLs.SourceContent.Time, mj.newerThan) {
+					mj.report.recordURLs(sURLs, reportSkipped, "not newer than "+mj.newerThan)
 					continue
 				}
-				// copy
-				totalBytes += sURLs.SourceContent.Size
+			} else if sURLs.TargetContent == nil || !mj.isRemove {
+				continue
+			} else {
+				removals++
+				isRemoval = true
 			}
 
-			totalObjects++
-			mj.TotalBytes = totalBytes
-			mj.TotalObjects = totalObjects
-			mj.status.SetTotal(totalBytes)
+			if isRemoval && isLimited {
+				mj.saveEntry(index, sURLs, true)
+				continue
+			}
+			if mj.session != nil {
+				sURLs.sessionEntry = mj.saveEntry(index, sURLs, false)
+			}
+			if !mj.queueAction(ctx, cancelMirror, target, sURLs) {
+				return removals, false
+			}
+		case <-mj.stopCh:
+			return removals, false
+		}
+	}
+}
 
-			// Save total count.
-			sURLs.TotalCount = mj.TotalObjects
-			// Save totalSize.
-			sURLs.TotalSize = mj.TotalBytes
+// releaseRemovals - once all targets are compared, check the removals
+// from every target against the deletion limits and queue the
+// removals held back in the session data. If any target exceeds the
+// limits the mirror is aborted without a single removal. Returns
+// false once the mirror is interrupted or aborted.
+func (mj *mirrorJob) releaseRemovals(ctx context.Context, cancelMirror context.CancelFunc, removals, targetObjects []int64, compared []bool) bool {
+	isLimited := mj.maxDelete >= 0 || mj.maxDeletePercent >= 0
+	isExceeded := false
+	for i, target := range mj.targets {
+		// The objects of a target are only known once it is compared.
+		if !compared[i] {
+			continue
+		}
+		target.mu.Lock()
+		target.objects = targetObjects[i]
+		target.removals = removals[i]
+		target.removalsSince = UTCNow()
+		target.mu.Unlock()
+		if !isLimited {
+			continue
+		}
+		if err := checkDeleteLimits(removals[i], targetObjects[i], mj.maxDelete, mj.maxDeletePercent); err != nil {
+			mj.statusCh <- target.account(URLs{Error: err.Trace(target.url)})
+			isExceeded = true
+		}
+	}
+	if !isLimited {
+		return true
+	}
 
-			if sURLs.SourceContent != nil {
-				mj.queueCh <- func() URLs {
-					return mj.doMirror(ctx, cancelMirror, sURLs)
-				}
-			} else if sURLs.TargetContent != nil && mj.isRemove {
-				mj.queueCh <- func() URLs {
-					return mj.doRemove(sURLs)
-				}
-			}
-		case <-mj.trapCh:
-			stopParallel()
+	for heldEntry := range mj.sessionData.HeldEntries() {
+		if heldEntry.Err != nil {
+			mj.sessionData.Delete()
+			mj.status.fatalIf(heldEntry.Err.Trace(), "Unable to read session data.")
+		}
+		var entry mirrorSessionEntry
+		if e := json.Unmarshal(heldEntry.Data, &entry); e != nil {
+			mj.sessionData.Delete()
+			mj.status.fatalIf(probe.NewError(e).Trace(string(heldEntry.Data)), "Unable to unmarshal session data.")
+		}
+		// Removals from a target which failed are dropped.
+		if !compared[entry.Target] {
+			mj.sessionData.DropEntry(heldEntry.Index)
+			continue
+		}
+		if isExceeded {
+			mj.sessionData.DropEntry(heldEntry.Index)
+			mj.report.recordURLs(entry.URLs, reportSkipped, "deletion limits exceeded")
+			continue
+		}
+		if err := mj.sessionData.ReleaseEntry(heldEntry.Index); err != nil {
+			mj.sessionData.Delete()
+			mj.status.fatalIf(err.Trace(), "Unable to save session data.")
+		}
+		sURLs := entry.URLs
+		sURLs.sessionEntry = heldEntry.Index
+		if !mj.queueAction(ctx, cancelMirror, mj.targets[entry.Target], sURLs) {
+			return false
+		}
+	}
+	if isExceeded {
+		mj.abort()
+		return false
+	}
+	return true
+}
+
+// saveEntry - save the action of sURLs on the target of the given
+// index in the session data, held back if isHeld. Returns the index
+// of the entry.
+func (mj *mirrorJob) saveEntry(target int, sURLs URLs, isHeld bool) int64 {
+	var size int64
+	if sURLs.SourceContent != nil {
+		size = sURLs.SourceContent.Size
+	}
+	jsonData, e := json.Marshal(mirrorSessionEntry{Target: target, URLs: sURLs})
+	if e != nil {
+		mj.sessionData.Delete()
+		mj.status.fatalIf(probe.NewError(e), "Unable to prepare URL for mirroring. Error in JSON marshaling.")
+	}
+	var index int64
+	var err *probe.Error
+	if isHeld {
+		index, err = mj.sessionData.HoldEntry(size, jsonData)
+	} else {
+		index, err = mj.sessionData.StartEntry(size, jsonData)
+	}
+	if err != nil {
+		mj.sessionData.Delete()
+		mj.status.fatalIf(err.Trace(), "Unable to prepare URL for mirroring. Error in saving session data.")
+	}
+	return index
+}
+
+// queueAction - queue the action of sURLs on the target, returns
+// false once the mirror is interrupted.
+func (mj *mirrorJob) queueAction(ctx context.Context, cancelMirror context.CancelFunc, target *mirrorTarget, sURLs URLs) bool {
+	var size int64
+	if sURLs.SourceContent != nil {
+		size = sURLs.SourceContent.Size
+	}
+
+	// Totals are shared by all targets.
+	totalBytes := atomic.AddInt64(&mj.TotalBytes, size)
+	totalObjects := atomic.AddInt64(&mj.TotalObjects, 1)
+	mj.status.SetTotal(totalBytes)
+
+	// Save total count.
+	sURLs.TotalCount = totalObjects
+	// Save totalSize.
+	sURLs.TotalSize = totalBytes
+
+	select {
+	case target.queueCh <- mj.mirrorAction(ctx, cancelMirror, target, sURLs):
+		return true
+	case <-mj.stopCh:
+		return false
+	}
+}
+
+// mirrorAction - the copy or the removal of sURLs on the target.
+func (mj *mirrorJob) mirrorAction(ctx context.Context, cancelMirror context.CancelFunc, target *mirrorTarget, sURLs URLs) func() URLs {
+	if sURLs.SourceContent != nil {
+		return func() URLs {
+			return target.account(mj.doMirror(ctx, cancelMirror, target, sURLs))
+		}
+	}
+	return func() URLs {
+		return target.account(mj.doRemove(ctx, target, sURLs))
+	}
+}
+
+// stopTargets - wait until the actions queued for all targets are done.
+func (mj *mirrorJob) stopTargets() {
+	for _, target := range mj.targets {
+		close(target.queueCh)
+	}
+	for _, target := range mj.targets {
+		target.parallel.wait()
+	}
+}
+
+// mirrorSessionEntry - an action of a mirror session, the copy or
+// the removal of an object on one of the targets.
+type mirrorSessionEntry struct {
+	Target int  `json:"target"`
+	URLs   URLs `json:"urls"`
+}
+
+// targetURLs - urls of all targets.
+func (mj *mirrorJob) targetURLs() []string {
+	targetURLs := make([]string, len(mj.targets))
+	for i, target := range mj.targets {
+		targetURLs[i] = target.url
+	}
+	return targetURLs
+}
+
+// isStopped - returns true once the mirror is interrupted or aborted.
+func (mj *mirrorJob) isStopped() bool {
+	select {
+	case <-mj.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// abort - stop the mirror because a target exceeds the deletion
+// limits, unlike an interrupted mirror it cannot be resumed.
+func (mj *mirrorJob) abort() {
+	mj.abortOnce.Do(func() {
+		close(mj.abortCh)
+	})
+}
+
+// isAborted - returns true once the mirror is aborted.
+func (mj *mirrorJob) isAborted() bool {
+	select {
+	case <-mj.abortCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// resumeMirrorSession - queue the actions saved in a resumed session.
+func (mj *mirrorJob) resumeMirrorSession(ctx context.Context, cancelMirror context.CancelFunc) {
+	mj.TotalBytes = mj.session.Header.TotalBytes
+	mj.TotalObjects = mj.session.Header.TotalObjects
+	mj.status.SetTotal(mj.TotalBytes)
+	// Actions completed before the session was resumed are not read again.
+	mj.status.Add(mj.session.Header.ResumeBytes)
+
+	for sessionEntry := range mj.session.Entries() {
+		if sessionEntry.Err != nil {
+			errorIf(sessionEntry.Err.Trace(), "Unable to read session data.")
+			break
+		}
+		var entry mirrorSessionEntry
+		if e := json.Unmarshal(sessionEntry.Data, &entry); e != nil {
+			errorIf(probe.NewError(e), "Unable to unmarshal %s", string(sessionEntry.Data))
+			mj.session.SetEntryStatus(sessionEntry.Index, sessionEntryFailed)
+			continue
+		}
+		if entry.Target < 0 || entry.Target >= len(mj.targets) {
+			errorIf(errInvalidArgument().Trace(string(sessionEntry.Data)), "Unable to find the target of a session entry.")
+			mj.session.SetEntryStatus(sessionEntry.Index, sessionEntryFailed)
+			continue
+		}
+		sURLs := entry.URLs
+		sURLs.TotalCount = mj.TotalObjects
+		sURLs.TotalSize = mj.TotalBytes
+		sURLs.sessionEntry = sessionEntry.Index
+
+		// Actions completed or failed before the session was
+		// interrupted are not mirrored again, neither are the
+		// removals held back when a target exceeded the limits.
+		if sessionEntry.Status != sessionEntryPending {
+			mj.status.Add(sessionEntry.Size)
+			continue
+		}
+
+		target := mj.targets[entry.Target]
+		select {
+		case target.queueCh <- mj.mirrorAction(ctx, cancelMirror, target, sURLs):
+		case <-mj.stopCh:
+			mj.stopTargets()
 			cancelMirror()
 			return
 		}
 	}
+	mj.stopTargets()
 }
 
 // when using a struct for copying, we could save a lot of passing of variables
@@ -540,12 +1411,34 @@ func (mj *mirrorJob) mirror(ctx context.Context, cancelMirror context.CancelFunc
 
 	var wg sync.WaitGroup
 
+	// Stop all targets once interrupted or aborted.
+	go func() {
+		select {
+		case <-mj.trapCh:
+		case <-mj.abortCh:
+		}
+		close(mj.stopCh)
+	}()
+
 	// Starts watcher loop for watching for new events.
 	if mj.isWatch {
+		var watchWg sync.WaitGroup
+		for _, target := range mj.targets {
+			watchWg.Add(1)
+			go func(target *mirrorTarget) {
+				defer watchWg.Done()
+				target.mirrorWatchEvents()
+			}(target)
+		}
+
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			mj.watchMirror(ctx, cancelMirror)
+			for _, target := range mj.targets {
+				close(target.watchCh)
+			}
+			watchWg.Wait()
 		}()
 	}
 
@@ -565,36 +1458,52 @@ func (mj *mirrorJob) mirror(ctx context.Context, cancelMirror context.CancelFunc
 	return mj.monitorMirrorStatus()
 }
 
-func newMirrorJob(srcURL, dstURL string, isFake, isRemove, isOverwrite, isWatch bool, excludeOptions []string, olderThan, newerThan string, storageClass string, encKeyDB map[string][]prefixSSEPair) *mirrorJob {
+func newMirrorJob(srcURL string, tgtURLs []string, isFake, isRemove, isOverwrite, isWatch, isChecksum, isVerify bool, excludeOptions []string, olderThan, newerThan string, storageClass, compression string, tags map[string]string, parallel, maxParallel int, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) *mirrorJob {
 	mj := mirrorJob{
-		trapCh: signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL),
-		m:      new(sync.Mutex),
+		trapCh:  signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL),
+		m:       new(sync.Mutex),
+		stopCh:  make(chan struct{}),
+		abortCh: make(chan struct{}),
 
 		sourceURL: srcURL,
-		targetURL: dstURL,
 
 		isFake:         isFake,
 		isRemove:       isRemove,
 		isOverwrite:    isOverwrite,
 		isWatch:        isWatch,
+		isChecksum:     isChecksum,
+		isVerify:       isVerify,
 		excludeOptions: excludeOptions,
 		olderThan:      olderThan,
 		newerThan:      newerThan,
 		storageClass:   storageClass,
+		compression:    compression,
+		tags:           tags,
+		timeRef:        timeRef,
 		encKeyDB:       encKeyDB,
 		statusCh:       make(chan URLs),
 		watcher:        NewWatcher(UTCNow()),
 	}
 
-	mj.parallel, mj.queueCh = newParallelManager(mj.statusCh)
+	var parallels parallelManagers
+	for _, tgtURL := range tgtURLs {
+		target := &mirrorTarget{
+			url:          tgtURL,
+			watchCh:      make(chan mirrorWatchEvent, mirrorWatchQueueSize),
+			stallTimeout: mirrorStallTimeout,
+		}
+		target.parallel, target.queueCh = newParallelManager(mj.statusCh, parallel, maxParallel)
+		parallels = append(parallels, target.parallel)
+		mj.targets = append(mj.targets, target)
+	}
 
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
-	var status = NewProgressStatus(mj.parallel)
+	var status = NewProgressStatus(parallels)
 	if globalQuiet {
-		status = NewQuietStatus(mj.parallel)
+		status = NewQuietStatus(parallels)
 	} else if globalJSON {
-		status = NewDummyStatus(mj.parallel)
+		status = NewDummyStatus(parallels)
 	}
 	mj.status = status
 
@@ -626,39 +1535,101 @@ func copyBucketPolicies(srcClt, dstClt Client, isOverwrite bool) *probe.Error {
 }
 
 // runMirror - mirrors all buckets to another S3 server
-func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
-	// This is kept for backward compatibility, `--force` means
-	// --overwrite.
-	isOverwrite := ctx.Bool("force")
-	if !isOverwrite {
-		isOverwrite = ctx.Bool("overwrite")
+func runMirror(srcURL string, dstURLs []string, session *sessionV9, encKeyDB map[string][]prefixSSEPair) bool {
+	isOverwrite := session.Header.CommandBoolFlags["overwrite"]
+
+	timeRef, err := parseRewind(session.Header.CommandStringFlags["rewind"])
+	fatalIf(err, "Unable to parse rewind.")
+
+	tags, err := parseTags(session.Header.CommandStringFlags["tags"])
+	fatalIf(err, "Unable to parse tags.")
+
+	var excludeOptions, includes []string
+	if exclude := session.Header.CommandStringFlags["exclude"]; exclude != "" {
+		e := json.Unmarshal([]byte(exclude), &excludeOptions)
+		fatalIf(probe.NewError(e), "Unable to parse exclude options.")
+	}
+	if include := session.Header.CommandStringFlags["include"]; include != "" {
+		e := json.Unmarshal([]byte(include), &includes)
+		fatalIf(probe.NewError(e), "Unable to parse include options.")
 	}
+	excludeRules, err := newExcludeRules(session.Header.CommandStringFlags["exclude-from"], includes)
+	fatalIf(err, "Unable to read exclude patterns.")
 
 	// Create a new mirror job and execute it
-	mj := newMirrorJob(srcURL, dstURL,
-		ctx.Bool("fake"),
-		ctx.Bool("remove"),
+	mj := newMirrorJob(srcURL, dstURLs,
+		session.Header.CommandBoolFlags["fake"],
+		session.Header.CommandBoolFlags["remove"],
 		isOverwrite,
-		ctx.Bool("watch"),
-		ctx.StringSlice("exclude"),
-		ctx.String("older-than"),
-		ctx.String("newer-than"),
-		ctx.String("storage-class"),
+		session.Header.CommandBoolFlags["watch"],
+		session.Header.CommandBoolFlags["checksum"],
+		session.Header.CommandBoolFlags["verify"],
+		excludeOptions,
+		session.Header.CommandStringFlags["older-than"],
+		session.Header.CommandStringFlags["newer-than"],
+		session.Header.CommandStringFlags["storage-class"],
+		session.Header.CommandStringFlags["compress"],
+		tags,
+		session.Header.CommandIntFlags["parallel"],
+		session.Header.CommandIntFlags["max-parallel"],
+		timeRef,
 		encKeyDB)
 
+	mj.maxDelete, mj.maxDeletePercent = -1, -1
+	if maxDelete, ok := session.Header.CommandIntFlags["max-delete"]; ok {
+		mj.maxDelete = maxDelete
+	}
+	if maxDeletePercent, ok := session.Header.CommandIntFlags["max-delete-percent"]; ok {
+		mj.maxDeletePercent = maxDeletePercent
+	}
+	mj.removeTo = session.Header.CommandStringFlags["remove-to"]
+	mj.excludeRules = excludeRules
+	// A resumed session adds to the report of the interrupted run.
+	mj.report, err = newTransferReport(session.Header.CommandStringFlags["report"], session.Header.CommandType, session.Header.CommandArgs, session.HasData())
+	fatalIf(err, "Unable to create report.")
+
+	// A watching mirror never ends, only the others can be resumed.
+	// It journals the events it receives instead, to mirror the
+	// events which are pending when it is stopped once restarted.
+	mj.sessionData = session
+	if !mj.isWatch {
+		mj.session = session
+	} else {
+		mj.watchDelay, err = parseWatchDelay(session.Header.CommandStringFlags["watch-delay"])
+		fatalIf(err, "Unable to parse watch delay.")
+		mj.journal, mj.journalEvents, err = openWatchJournal(srcURL, dstURLs)
+		fatalIf(err, "Unable to open watch journal.")
+		defer mj.journal.close()
+	}
+
 	srcClt, err := newClient(srcURL)
 	fatalIf(err, "Unable to initialize `"+srcURL+"`.")
 
-	dstClt, err := newClient(dstURL)
-	fatalIf(err, "Unable to initialize `"+srcURL+"`.")
+	for _, dstURL := range dstURLs {
+		dstClt, err := newClient(dstURL)
+		fatalIf(err, "Unable to initialize `"+dstURL+"`.")
 
-	if ctx.Bool("a") && (srcClt.GetURL().Type != objectStorage || dstClt.GetURL().Type != objectStorage) {
-		fatalIf(errDummy(), "Synchronizing bucket policies is only possible when both source & target point to S3 servers.")
+		if session.Header.CommandBoolFlags["a"] && (srcClt.GetURL().Type != objectStorage || dstClt.GetURL().Type != objectStorage) {
+			fatalIf(errDummy(), "Synchronizing bucket policies is only possible when both source & target point to S3 servers.")
+		}
 	}
 
+	// All buckets can only be mirrored to a single target.
+	dstURL := dstURLs[0]
+	dstClt, err := newClient(dstURL)
+	fatalIf(err, "Unable to initialize `"+dstURL+"`.")
+
 	mirrorAllBuckets := (srcClt.GetURL().Type == objectStorage && srcClt.GetURL().Path == "/") ||
 		(dstClt.GetURL().Type == objectStorage && dstClt.GetURL().Path == "/")
 
+	if mirrorAllBuckets && len(dstURLs) > 1 {
+		fatalIf(errInvalidArgument().Trace(append([]string{srcURL}, dstURLs...)...), "Mirroring all buckets is only possible to a single target.")
+	}
+
+	if mirrorAllBuckets && !timeRef.IsZero() {
+		fatalIf(errInvalidArgument().Trace(srcURL, dstURL), "--rewind requires a bucket or a folder as source and target.")
+	}
+
 	if mirrorAllBuckets {
 		// Synchronize buckets using dirDifference function
 		for d := range dirDifference(srcClt, dstClt, srcURL, dstURL) {
@@ -679,12 +1650,12 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 
 			if d.Diff == differInFirst {
 				// Bucket only exists in the source, create the same bucket in the destination
-				if err := newDstClt.MakeBucket(ctx.String("region"), false); err != nil {
+				if err := newDstClt.MakeBucket(session.Header.CommandStringFlags["region"], false, false); err != nil {
 					errorIf(err, "Cannot created bucket in `"+newTgtURL+"`.")
 					continue
 				}
 				// Copy policy rules from source to dest if flag is activated
-				if ctx.Bool("a") {
+				if session.Header.CommandBoolFlags["a"] {
 					if err := copyBucketPolicies(srcClt, dstClt, isOverwrite); err != nil {
 						errorIf(err, "Cannot copy bucket policies to `"+newDstClt.GetURL().String()+"`.")
 					}
@@ -713,7 +1684,38 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 	defer cancelMirror()
 
 	// Start mirroring job
-	return mj.mirror(ctxt, cancelMirror)
+	errorDetected := mj.mirror(ctxt, cancelMirror)
+	if err = mj.report.close(); err != nil {
+		errorIf(err.Trace(), "Unable to write report.")
+		errorDetected = true
+	}
+	// An aborted session is deleted, it cannot be resumed.
+	if mj.session != nil && mj.isStopped() && !mj.isAborted() {
+		// Receive interrupt notification.
+		mj.session.CloseAndDie()
+	}
+	return errorDetected
+}
+
+// doMirrorSession - mirror with the options saved in the session.
+func doMirrorSession(session *sessionV9, encKeyDB map[string][]prefixSSEPair) error {
+	// Additional command specific theme customization.
+	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	console.SetColor("MirrorFailed", color.New(color.FgRed, color.Bold))
+	console.SetColor("Retry", color.New(color.FgYellow))
+
+	args := session.Header.CommandArgs
+	keyFile := session.Header.CommandStringFlags["encrypt-client"]
+	fatalIf(setCSEKey(keyFile, args[1:]...), "Unable to load client-side encryption key `"+keyFile+"`.")
+	fatalIf(setBandwidthLimits(session.Header.CommandStringFlags["limit-upload"], session.Header.CommandStringFlags["limit-download"]),
+		"Unable to set bandwidth limits.")
+	fatalIf(setRetryOptions(session.Header.CommandIntFlags["retry-attempts"], session.Header.CommandStringFlags["retry-backoff"]),
+		"Unable to set retry options.")
+
+	if errorDetected := runMirror(args[0], args[1:], session, encKeyDB); errorDetected {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
 }
 
 // Main entry point for mirror command.
@@ -725,17 +1727,67 @@ func mainMirror(ctx *cli.Context) error {
 	// check 'mirror' cli arguments.
 	checkMirrorSyntax(ctx, encKeyDB)
 
-	// Additional command specific theme customization.
-	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
-
-	args := ctx.Args()
+	sseKeys := os.Getenv("MC_ENCRYPT_KEY")
+	if key := ctx.String("encrypt-key"); key != "" {
+		sseKeys = key
+	}
+	sseKMS := os.Getenv("MC_ENCRYPT_KMS")
+	if key := ctx.String("encrypt-kms"); key != "" {
+		sseKMS = key
+	}
 
-	srcURL := args[0]
-	tgtURL := args[1]
+	session := newSessionV9()
+	session.Header.CommandType = "mirror"
+	// This is kept for backward compatibility, `--force` means
+	// --overwrite.
+	session.Header.CommandBoolFlags["overwrite"] = ctx.Bool("force") || ctx.Bool("overwrite")
+	for _, flag := range []string{"fake", "remove", "watch", "checksum", "verify", "a"} {
+		session.Header.CommandBoolFlags[flag] = ctx.Bool(flag)
+	}
+	for _, flag := range []string{"region", "older-than", "newer-than", "storage-class", "compress", "tags",
+		"encrypt-client", "limit-upload", "limit-download", "retry-backoff", "remove-to", "watch-delay", "exclude-from", "report"} {
+		session.Header.CommandStringFlags[flag] = ctx.String(flag)
+	}
+	for _, flag := range []string{"parallel", "max-parallel", "retry-attempts"} {
+		session.Header.CommandIntFlags[flag] = ctx.Int(flag)
+	}
+	// Deletion limits are only saved when given, zero is a limit.
+	for _, flag := range []string{"max-delete", "max-delete-percent"} {
+		if ctx.IsSet(flag) {
+			session.Header.CommandIntFlags[flag] = ctx.Int(flag)
+		}
+	}
+	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
+	session.Header.CommandStringFlags["encrypt-kms"] = sseKMS
+	session.Header.CommandStringFlags["encrypt"] = ctx.String("encrypt")
+	if excludeOptions := ctx.StringSlice("exclude"); len(excludeOptions) > 0 {
+		excludeJSON, e := json.Marshal(excludeOptions)
+		fatalIf(probe.NewError(e), "Unable to save exclude options.")
+		session.Header.CommandStringFlags["exclude"] = string(excludeJSON)
+	}
+	if includes := ctx.StringSlice("include"); len(includes) > 0 {
+		includeJSON, e := json.Marshal(includes)
+		fatalIf(probe.NewError(e), "Unable to save include options.")
+		session.Header.CommandStringFlags["include"] = string(includeJSON)
+	}
+	// Save rewind as an absolute time, a resumed session has to
+	// mirror the same versions as the interrupted one.
+	timeRef, err := parseRewind(ctx.String("rewind"))
+	fatalIf(err, "Unable to parse rewind `"+ctx.String("rewind")+"`.")
+	if !timeRef.IsZero() {
+		session.Header.CommandStringFlags["rewind"] = timeRef.Format(time.RFC3339Nano)
+	}
 
-	if errorDetected := runMirror(srcURL, tgtURL, ctx, encKeyDB); errorDetected {
-		return exitStatus(globalErrorExitStatus)
+	var e error
+	if session.Header.RootPath, e = os.Getwd(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to get current working folder.")
 	}
 
-	return nil
+	// extract URLs.
+	session.Header.CommandArgs = ctx.Args()
+	e = doMirrorSession(session, encKeyDB)
+	session.Delete()
+
+	return e
 }
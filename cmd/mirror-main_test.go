@@ -0,0 +1,130 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMirrorTargetStall(t *testing.T) {
+	target := &mirrorTarget{
+		watchCh:      make(chan mirrorWatchEvent, 1),
+		stallTimeout: 10 * time.Millisecond,
+	}
+	stopCh := make(chan struct{})
+	var mirrored []string
+	var finished int
+	event := func(path string) mirrorWatchEvent {
+		return mirrorWatchEvent{
+			path:   path,
+			mirror: func() { mirrored = append(mirrored, path) },
+			finish: func() { finished++ },
+		}
+	}
+
+	// The first event fills the queue, the target stalls on the second one.
+	if stalled, ok := target.queueWatchEvent(event("a"), stopCh); stalled || !ok {
+		t.Fatalf("Expected the event to be queued, got stalled %t, ok %t", stalled, ok)
+	}
+	if stalled, ok := target.queueWatchEvent(event("b"), stopCh); !stalled || !ok {
+		t.Fatalf("Expected the target to stall, got stalled %t, ok %t", stalled, ok)
+	}
+	// Events of a stalled target are kept, later events of a path
+	// supersede earlier ones.
+	for _, path := range []string{"c", "b"} {
+		if stalled, ok := target.queueWatchEvent(event(path), stopCh); stalled || !ok {
+			t.Fatalf("Expected the event to be kept, got stalled %t, ok %t", stalled, ok)
+		}
+	}
+	if finished != 1 {
+		t.Fatalf("Expected the superseded event to be finished, got %d finished events", finished)
+	}
+
+	// Missed events are mirrored once the queue is empty.
+	close(target.watchCh)
+	target.mirrorWatchEvents()
+	sort.Strings(mirrored[1:])
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(mirrored, expected) {
+		t.Fatalf("Expected %v to be mirrored, got %v", expected, mirrored)
+	}
+	if finished != 4 {
+		t.Fatalf("Expected 4 finished events, got %d", finished)
+	}
+}
+
+func TestMirrorTargetReserveRemovals(t *testing.T) {
+	target := &mirrorTarget{}
+	now := UTCNow()
+	// No objects are known before the target is compared.
+	if err := target.reserveRemovals(1, -1, 50, now); err == nil {
+		t.Fatal("Expected a removal to be refused before the comparison")
+	}
+
+	target.objects = 10
+	testCases := []struct {
+		n        int64
+		elapsed  time.Duration
+		removals int64
+		exceeded bool
+	}{
+		{4, 0, 4, false},
+		{2, 0, 4, true},
+		{1, time.Minute, 5, false},
+		{1, time.Minute, 5, true},
+		// Removals are counted again once the window elapsed,
+		// out of the objects left on the target.
+		{3, time.Hour, 0, true},
+		{2, time.Hour, 2, false},
+	}
+	for i, testCase := range testCases {
+		err := target.reserveRemovals(testCase.n, -1, 50, now.Add(testCase.elapsed))
+		if exceeded := err != nil; exceeded != testCase.exceeded {
+			t.Fatalf("Test %d: Expected exceeded %t, got %t", i+1, testCase.exceeded, exceeded)
+		}
+		if target.removals != testCase.removals {
+			t.Fatalf("Test %d: Expected %d removals, got %d", i+1, testCase.removals, target.removals)
+		}
+	}
+}
+
+func TestCheckDeleteLimits(t *testing.T) {
+	testCases := []struct {
+		removals, targetObjects     int64
+		maxDelete, maxDeletePercent int
+		exceeded                    bool
+	}{
+		{10, 100, -1, -1, false},
+		{10, 100, 10, -1, false},
+		{11, 100, 10, -1, true},
+		{1, 100, 0, -1, true},
+		{0, 100, 0, 0, false},
+		{10, 100, -1, 10, false},
+		{11, 100, -1, 10, true},
+		{1, 3, -1, 33, true},
+		{1, 3, -1, 34, false},
+		{5, 5, 10, 100, false},
+		{5, 100, 10, 1, true},
+	}
+	for i, testCase := range testCases {
+		err := checkDeleteLimits(testCase.removals, testCase.targetObjects, testCase.maxDelete, testCase.maxDeletePercent)
+		if exceeded := err != nil; exceeded != testCase.exceeded {
+			t.Fatalf("Test %d: Expected exceeded %t, got %t", i+1, testCase.exceeded, exceeded)
+		}
+	}
+}
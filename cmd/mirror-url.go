@@ -19,8 +19,10 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/wildcard"
 )
 
@@ -31,14 +33,14 @@ import (
 
 // checkMirrorSyntax(URLs []string)
 func checkMirrorSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
-	if len(ctx.Args()) != 2 {
+	if len(ctx.Args()) < 2 {
 		cli.ShowCommandHelpAndExit(ctx, "mirror", 1) // last argument is exit code.
 	}
 
 	// extract URLs.
 	URLs := ctx.Args()
 	srcURL := URLs[0]
-	tgtURL := URLs[1]
+	tgtURLs := URLs[1:]
 
 	if ctx.Bool("force") && ctx.Bool("remove") {
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated please use `--overwrite` instead with `--remove` for the same functionality.")
@@ -46,19 +48,74 @@ func checkMirrorSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated please use `--overwrite` instead for the same functionality.")
 	}
 
-	tgtClientURL := newClientURL(tgtURL)
-	if tgtClientURL.Host != "" {
-		if tgtClientURL.Path == string(tgtClientURL.Separator) {
+	seenTargets := make(map[string]bool)
+	for _, tgtURL := range tgtURLs {
+		tgtClientURL := newClientURL(tgtURL)
+		if tgtClientURL.Host != "" {
+			if tgtClientURL.Path == string(tgtClientURL.Separator) {
+				fatalIf(errInvalidArgument().Trace(tgtURL),
+					fmt.Sprintf("Target `%s` does not contain bucket name.", tgtURL))
+			}
+		}
+		key := strings.TrimSuffix(tgtURL, string(tgtClientURL.Separator))
+		if seenTargets[key] {
 			fatalIf(errInvalidArgument().Trace(tgtURL),
-				fmt.Sprintf("Target `%s` does not contain bucket name.", tgtURL))
+				fmt.Sprintf("Target `%s` is given more than once.", tgtURL))
+		}
+		seenTargets[key] = true
+	}
+
+	timeRef, err := parseRewind(ctx.String("rewind"))
+	fatalIf(err, "Unable to parse rewind `"+ctx.String("rewind")+"`.")
+	if _, err = parseTags(ctx.String("tags")); err != nil {
+		fatalIf(err.Trace(URLs...), "Unable to parse tags.")
+	}
+	if err = checkCompression(ctx.String("compress")); err != nil {
+		fatalIf(err.Trace(URLs...), "Unable to compress objects.")
+	}
+	if ctx.Int("parallel") < 0 || ctx.Int("max-parallel") < 0 {
+		fatalIf(errInvalidArgument().Trace(URLs...), "--parallel and --max-parallel cannot be negative.")
+	}
+	if !timeRef.IsZero() && ctx.Bool("watch") {
+		fatalIf(errInvalidArgument().Trace(URLs...), "--rewind cannot be used with --watch.")
+	}
+	if _, err = parseWatchDelay(ctx.String("watch-delay")); err != nil {
+		fatalIf(err.Trace(URLs...), "Unable to parse watch delay.")
+	}
+	if _, err = newExcludeRules(ctx.String("exclude-from"), ctx.StringSlice("include")); err != nil {
+		fatalIf(err.Trace(URLs...), "Unable to read exclude patterns.")
+	}
+	if !ctx.Bool("remove") && (ctx.IsSet("max-delete") || ctx.IsSet("max-delete-percent") || ctx.String("remove-to") != "") {
+		fatalIf(errInvalidArgument().Trace(URLs...), "--max-delete, --max-delete-percent and --remove-to require --remove.")
+	}
+	if ctx.Int("max-delete") < 0 || ctx.Int("max-delete-percent") < 0 || ctx.Int("max-delete-percent") > 100 {
+		fatalIf(errInvalidArgument().Trace(URLs...), "--max-delete cannot be negative and --max-delete-percent should be between 0 and 100.")
+	}
+	if removeTo := ctx.String("remove-to"); removeTo != "" {
+		// Extraneous objects of several targets would overwrite each
+		// other, and moving them into a mirrored folder would keep
+		// them extraneous.
+		if len(tgtURLs) > 1 {
+			fatalIf(errInvalidArgument().Trace(URLs...), "--remove-to can only be used with a single target.")
+		}
+		separator := string(newClientURL(removeTo).Separator)
+		for _, mirroredURL := range URLs {
+			if isURLContains(mirroredURL, removeTo, separator) || isURLContains(removeTo, mirroredURL, separator) {
+				fatalIf(errInvalidArgument().Trace(removeTo, mirroredURL),
+					fmt.Sprintf("--remove-to `%s` cannot overlap with `%s`.", removeTo, mirroredURL))
+			}
 		}
 	}
 
 	/****** Generic rules *******/
 	if !ctx.Bool("watch") {
-		c, srcContent, err := url2Stat(srcURL, false, encKeyDB)
+		c, srcContent, err := url2StatVersion(srcURL, "", timeRef, encKeyDB)
 		// incomplete uploads are not necessary for copy operation, no need to verify for them.
 		isIncomplete := false
+		if err != nil && !timeRef.IsZero() {
+			// A rewound source has to exist at the given time.
+			fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
+		}
 		if err != nil && !isURLPrefixExists(srcURL, isIncomplete) {
 			errorIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 		}
@@ -69,14 +126,30 @@ func checkMirrorSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 			}
 
 			// Disallow mirroring a directory to itself
-			if isURLContains(srcURL, tgtURL, string(c.GetURL().Separator)) {
-				fatalIf(errInvalidArgument().Trace(), "Mirroring a folder into itself is not allowed.")
+			for _, tgtURL := range tgtURLs {
+				if isURLContains(srcURL, tgtURL, string(c.GetURL().Separator)) {
+					fatalIf(errInvalidArgument().Trace(), "Mirroring a folder into itself is not allowed.")
+				}
 			}
 		}
 	}
 
 }
 
+// parseWatchDelay - parse the delay after which a watched object,
+// which is no longer changed, is mirrored. No delay mirrors every
+// change right away.
+func parseWatchDelay(delay string) (time.Duration, *probe.Error) {
+	if delay == "" {
+		return 0, nil
+	}
+	d, e := time.ParseDuration(delay)
+	if e != nil || d < 0 {
+		return 0, errInvalidArgument().Trace(delay)
+	}
+	return d, nil
+}
+
 func matchExcludeOptions(excludeOptions []string, srcSuffix string) bool {
 	for _, pattern := range excludeOptions {
 		if wildcard.Match(pattern, srcSuffix) {
@@ -86,37 +159,43 @@ func matchExcludeOptions(excludeOptions []string, srcSuffix string) bool {
 	return false
 }
 
-func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemove bool, excludeOptions []string, URLsCh chan<- URLs, encKeyDB map[string][]prefixSSEPair) {
-	// source and targets are always directories
-	sourceSeparator := string(newClientURL(sourceURL).Separator)
-	if !strings.HasSuffix(sourceURL, sourceSeparator) {
-		sourceURL = sourceURL + sourceSeparator
-	}
+// deltaSourceTarget - compares the source with a target and sends the
+// urls to mirror through URLsCh, objects found on the target are
+// counted in targetObjects.
+func deltaSourceTarget(sourceAlias, sourceURL string, sourceClnt Client, targetURL string, isFake, isOverwrite, isRemove, isChecksum bool, excludeOptions []string, excludeRules *excludeRules, report *transferReport, URLsCh chan<- URLs, targetObjects *int64, encKeyDB map[string][]prefixSSEPair) {
+	// targets are always directories
 	targetSeparator := string(newClientURL(targetURL).Separator)
 	if !strings.HasSuffix(targetURL, targetSeparator) {
 		targetURL = targetURL + targetSeparator
 	}
 
 	// Extract alias and expanded URL
-	sourceAlias, sourceURL, _ := mustExpandAlias(sourceURL)
 	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
 
 	defer close(URLsCh)
 
-	sourceClnt, err := newClientFromAlias(sourceAlias, sourceURL)
-	if err != nil {
-		URLsCh <- URLs{Error: err.Trace(sourceAlias, sourceURL)}
-		return
-	}
-
 	targetClnt, err := newClientFromAlias(targetAlias, targetURL)
 	if err != nil {
 		URLsCh <- URLs{Error: err.Trace(targetAlias, targetURL)}
 		return
 	}
 
+	// The .mcignore files of a local source also protect the
+	// objects of the target they exclude.
+	var excludeRoot string
+	if sourceAlias == "" {
+		excludeRoot = sourceURL
+	}
+
+	var compare contentComparator
+	if isChecksum {
+		compare = newChecksumComparator(sourceAlias, targetAlias, encKeyDB)
+	}
+
 	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(sourceClnt, targetClnt, sourceURL, targetURL) {
+	// Similar objects are returned as well, to count all objects of the target.
+	isRecursive, returnSimilar := true, true
+	for diffMsg := range difference(sourceClnt, targetClnt, sourceURL, targetURL, isRecursive, returnSimilar, DirNone, compare) {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error}
@@ -131,18 +210,35 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 
 		tgtSuffix := strings.TrimPrefix(diffMsg.SecondURL, targetURL)
 		//Skip the target object if it matches the Exclude options provided
-		if matchExcludeOptions(excludeOptions, tgtSuffix) {
+		if matchExcludeOptions(excludeOptions, tgtSuffix) || excludeRules.isExcluded(excludeRoot, tgtSuffix, false) {
 			continue
 		}
 
+		if diffMsg.secondContent != nil {
+			*targetObjects++
+		}
+
+		unchanged := URLs{
+			SourceAlias:   sourceAlias,
+			SourceContent: diffMsg.firstContent,
+			TargetAlias:   targetAlias,
+			TargetContent: diffMsg.secondContent,
+		}
 		switch diffMsg.Diff {
 		case differInNone:
 			// No difference, continue.
+			report.recordURLs(unchanged, reportSkipped, "unchanged")
 		case differInType:
 			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
-		case differInSize, differInTime:
+		case differInSize, differInTime, differInContent:
+			// Objects compressed by mc differ in their stored size only.
+			if diffMsg.Diff == differInSize && !diffMsg.firstContent.Time.After(diffMsg.secondContent.Time) &&
+				isSameUncompressedSize(sourceAlias, diffMsg.firstContent, targetAlias, diffMsg.secondContent) {
+				report.recordURLs(unchanged, reportSkipped, "unchanged")
+				continue
+			}
 			if !isOverwrite && !isFake {
-				// Size or time differs but --overwrite not set.
+				// Size, time or content differs but --overwrite not set.
 				URLsCh <- URLs{Error: errOverWriteNotAllowed(diffMsg.SecondURL)}
 				continue
 			}
@@ -186,9 +282,56 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 	}
 }
 
-// Prepares urls that need to be copied or removed based on requested options.
-func prepareMirrorURLs(sourceURL string, targetURL string, isFake, isOverwrite, isRemove bool, excludeOptions []string, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
-	URLsCh := make(chan URLs)
-	go deltaSourceTarget(sourceURL, targetURL, isFake, isOverwrite, isRemove, excludeOptions, URLsCh, encKeyDB)
-	return URLsCh
+// Prepares urls that need to be copied or removed based on requested options,
+// a non zero timeRef mirrors the source as it was at that time,
+// with isChecksum objects of the same size are compared by content.
+// The source is listed once for all targets, every target gets its
+// own channel of urls. The number of objects found on every target is
+// returned as well, it is complete once the channel of the target is
+// closed.
+func prepareMirrorURLs(sourceURL string, targetURLs []string, timeRef time.Time, isFake, isOverwrite, isRemove, isChecksum bool, excludeOptions []string, excludeRules *excludeRules, report *transferReport, encKeyDB map[string][]prefixSSEPair) ([]<-chan URLs, []int64) {
+	URLsChs := make([]chan URLs, len(targetURLs))
+	resultChs := make([]<-chan URLs, len(targetURLs))
+	targetObjects := make([]int64, len(targetURLs))
+	for i := range targetURLs {
+		URLsChs[i] = make(chan URLs)
+		resultChs[i] = URLsChs[i]
+	}
+
+	go func() {
+		// source is always a directory
+		sourceSeparator := string(newClientURL(sourceURL).Separator)
+		if !strings.HasSuffix(sourceURL, sourceSeparator) {
+			sourceURL = sourceURL + sourceSeparator
+		}
+
+		// Extract alias and expanded URL
+		sourceAlias, sourceURL, _ := mustExpandAlias(sourceURL)
+
+		sourceClnt, err := newClientFromAlias(sourceAlias, sourceURL)
+		if err != nil {
+			for _, URLsCh := range URLsChs {
+				URLsCh <- URLs{Error: err.Trace(sourceAlias, sourceURL)}
+				close(URLsCh)
+			}
+			return
+		}
+		sourceClnt = newExcludeClient(newRewindClient(sourceClnt, timeRef), excludeRules)
+
+		if len(targetURLs) == 1 {
+			deltaSourceTarget(sourceAlias, sourceURL, sourceClnt, targetURLs[0], isFake, isOverwrite, isRemove, isChecksum, excludeOptions, excludeRules, report, URLsChs[0], &targetObjects[0], encKeyDB)
+			return
+		}
+
+		// Share a single listing of the source between all targets.
+		sourceClnts, tee := newTeeClients(sourceClnt, len(targetURLs), true, false, DirNone, mirrorStallTimeout)
+		for i, targetURL := range targetURLs {
+			go func(i int, targetURL string) {
+				defer tee.release(i)
+				deltaSourceTarget(sourceAlias, sourceURL, sourceClnts[i], targetURL, isFake, isOverwrite, isRemove, isChecksum, excludeOptions, excludeRules, report, URLsChs[i], &targetObjects[i], encKeyDB)
+			}(i, targetURL)
+		}
+	}()
+
+	return resultChs, targetObjects
 }
@@ -17,21 +17,39 @@
 package cmd
 
 import (
+	"context"
+	"net"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
 )
 
 const (
 	// Maximum number of parallel workers
 	maxParallelWorkers = 128
 
-	// Monitor tick to decide to add new workers
+	// Monitor tick to decide to add or remove workers
 	monitorPeriod = 4 * time.Second
 
 	// Number of workers added per bandwidth monitoring.
 	defaultWorkerFactor = 2
+
+	// Number of monitor ticks without any improvement after
+	// which no more workers are added.
+	maxStaleTicks = 2
+
+	// Throughput has to grow by more than this ratio to count
+	// as an improvement.
+	improvementRatio = 1.05
+
+	// Average task latency growing by more than this ratio over
+	// the latency of the best throughput means the workers are
+	// queuing up behind each other.
+	latencyRatio = 1.5
 )
 
 // ParallelManager - helps manage parallel workers to run tasks
@@ -42,20 +60,33 @@ type ParallelManager struct {
 	// Current threads number
 	workersNum uint32
 
+	// Maximum threads number
+	maxWorkers uint32
+
 	// Calculate sent bytes.
 	sentBytes int64
 
+	// Tasks done, their total duration in nanoseconds, and the
+	// attempts of tasks which failed because the server was
+	// throttling or the requests were timing out.
+	doneTasks      int64
+	taskDuration   int64
+	throttledTasks int64
+
 	// Channel to receive tasks to run
 	queueCh chan func() URLs
 	// Channel to send back results
 	resultCh chan URLs
 
+	// Channel to ask idle workers to quit
+	quitWorkerCh chan struct{}
+
 	stopMonitorCh chan struct{}
 }
 
 // addWorker creates a new worker to process tasks
 func (p *ParallelManager) addWorker() {
-	if atomic.LoadUint32(&p.workersNum) >= maxParallelWorkers {
+	if atomic.LoadUint32(&p.workersNum) >= p.maxWorkers {
 		// Number of maximum workers is reached, no need to
 		// to create a new one.
 		return
@@ -67,37 +98,119 @@ func (p *ParallelManager) addWorker() {
 	// Start a new worker
 	p.wg.Add(1)
 	go func() {
+		defer p.wg.Done()
 		for {
 			// Wait for jobs
-			fn, ok := <-p.queueCh
-			if !ok {
-				// No more tasks, quit
-				p.wg.Done()
+			select {
+			case fn, ok := <-p.queueCh:
+				if !ok {
+					// No more tasks, quit
+					return
+				}
+				// Execute the task and send the result
+				// to result channel.
+				start := time.Now()
+				result := fn()
+				result.duration = time.Since(start)
+				atomic.AddInt64(&p.taskDuration, int64(result.duration))
+				atomic.AddInt64(&p.doneTasks, 1)
+				p.resultCh <- result
+			case <-p.quitWorkerCh:
 				return
 			}
-			// Execute the task and send the result
-			// to result channel.
-			p.resultCh <- fn()
 		}
 	}()
 }
 
+// removeWorkers asks n idle workers to quit, at least one worker is kept.
+func (p *ParallelManager) removeWorkers(n uint32) {
+	for ; n > 0; n-- {
+		workersNum := atomic.LoadUint32(&p.workersNum)
+		if workersNum <= 1 {
+			return
+		}
+		if !atomic.CompareAndSwapUint32(&p.workersNum, workersNum, workersNum-1) {
+			continue
+		}
+		p.quitWorkerCh <- struct{}{}
+	}
+}
+
+// reportThrottled - count an attempt of a task which failed because the
+// server was throttling or the request timed out. Tasks report each
+// such attempt themselves, whether it is retried or not.
+func (p *ParallelManager) reportThrottled() {
+	atomic.AddInt64(&p.throttledTasks, 1)
+}
+
 func (p *ParallelManager) Read(b []byte) (n int, err error) {
 	atomic.AddInt64(&p.sentBytes, int64(len(b)))
 	return len(b), nil
 }
 
-// monitorProgress monitors realtime transfer speed of data
-// and increases threads until it reaches a maximum number of
-// threads or notice there is no apparent enhancement of
-// transfer speed.
+// parallelSample - activity of the workers during a monitor tick.
+type parallelSample struct {
+	workers   uint32
+	bytes     int64
+	tasks     int64
+	throttled int64
+	latency   time.Duration
+}
+
+// improves - returns true if the sample has a better throughput than
+// other, either in bytes or in tasks, as small objects are limited
+// by the number of requests rather than by the bandwidth.
+func (s parallelSample) improves(other parallelSample) bool {
+	return float64(s.bytes) > float64(other.bytes)*improvementRatio ||
+		float64(s.tasks) > float64(other.tasks)*improvementRatio
+}
+
+// parallelController - decides the number of workers from the
+// activity of the workers, workers are added while the throughput
+// grows, halved when the server throttles or requests time out, and
+// brought back to the best known number when the latency of tasks
+// grows without any gain in throughput.
+type parallelController struct {
+	best  parallelSample
+	stale int
+}
+
+// next - returns the change in the number of workers.
+func (c *parallelController) next(s parallelSample) int {
+	switch {
+	case s.throttled > 0:
+		// Back off and start over from the reduced number.
+		c.best = parallelSample{}
+		c.stale = maxStaleTicks
+		return -int(s.workers / 2)
+	case s.improves(c.best):
+		c.best = s
+		c.stale = 0
+		return defaultWorkerFactor
+	case s.workers > c.best.workers && c.best.latency > 0 &&
+		float64(s.latency) > float64(c.best.latency)*latencyRatio:
+		// More workers only made each task slower.
+		c.stale = maxStaleTicks
+		return -int(s.workers - c.best.workers)
+	case c.stale < maxStaleTicks:
+		// We still want to add more workers until we are sure
+		// that it is not useful to add more of them.
+		c.stale++
+		return defaultWorkerFactor
+	}
+	return 0
+}
+
+// monitorProgress monitors realtime transfer speed of data and the
+// latency of tasks, and adjusts the number of workers accordingly.
 func (p *ParallelManager) monitorProgress() {
 	go func() {
 		ticker := time.NewTicker(monitorPeriod)
 		defer ticker.Stop()
 
-		var prevSentBytes, maxBandwidth int64
-		var retry int
+		var prev parallelSample
+		var prevDuration int64
+		var controller parallelController
 
 		for {
 			select {
@@ -105,27 +218,31 @@ func (p *ParallelManager) monitorProgress() {
 				// Ordered to quit immediately
 				return
 			case <-ticker.C:
-				// Compute new bandwidth from counted sent bytes
-				sentBytes := atomic.LoadInt64(&p.sentBytes)
-				bandwidth := sentBytes - prevSentBytes
-				prevSentBytes = sentBytes
-
-				if bandwidth <= maxBandwidth {
-					retry++
-					// We still want to add more workers
-					// until we are sure that it is not
-					// useful to add more of them.
-					if retry > 2 {
-						return
-					}
-				} else {
-					retry = 0
-					maxBandwidth = bandwidth
+				cur := parallelSample{
+					workers:   atomic.LoadUint32(&p.workersNum),
+					bytes:     atomic.LoadInt64(&p.sentBytes),
+					tasks:     atomic.LoadInt64(&p.doneTasks),
+					throttled: atomic.LoadInt64(&p.throttledTasks),
 				}
+				duration := atomic.LoadInt64(&p.taskDuration)
+				sample := parallelSample{
+					workers:   cur.workers,
+					bytes:     cur.bytes - prev.bytes,
+					tasks:     cur.tasks - prev.tasks,
+					throttled: cur.throttled - prev.throttled,
+				}
+				if sample.tasks > 0 {
+					sample.latency = time.Duration((duration - prevDuration) / sample.tasks)
+				}
+				prev, prevDuration = cur, duration
 
-				for i := 0; i < defaultWorkerFactor; i++ {
+				change := controller.next(sample)
+				for i := 0; i < change; i++ {
 					p.addWorker()
 				}
+				if change < 0 {
+					p.removeWorkers(uint32(-change))
+				}
 			}
 		}
 	}()
@@ -137,14 +254,32 @@ func (p *ParallelManager) wait() {
 	close(p.stopMonitorCh)
 }
 
-// newParallelManager starts new workers waiting for executing tasks
-func newParallelManager(resultCh chan URLs) (*ParallelManager, chan func() URLs) {
+// newParallelManager starts new workers waiting for executing tasks,
+// a non zero parallel runs a fixed number of workers, otherwise the
+// number of workers adapts up to maxParallel, or maxParallelWorkers
+// if maxParallel is zero.
+func newParallelManager(resultCh chan URLs, parallel, maxParallel int) (*ParallelManager, chan func() URLs) {
+	if maxParallel <= 0 {
+		maxParallel = maxParallelWorkers
+	}
+	if parallel > 0 {
+		maxParallel = parallel
+	}
 	p := &ParallelManager{
 		wg:            &sync.WaitGroup{},
 		workersNum:    0,
+		maxWorkers:    uint32(maxParallel),
 		stopMonitorCh: make(chan struct{}),
 		queueCh:       make(chan func() URLs),
 		resultCh:      resultCh,
+		quitWorkerCh:  make(chan struct{}, maxParallel),
+	}
+
+	if parallel > 0 {
+		for i := 0; i < parallel; i++ {
+			p.addWorker()
+		}
+		return p, p.queueCh
 	}
 
 	// Start with runtime.NumCPU().
@@ -157,3 +292,33 @@ func newParallelManager(resultCh chan URLs) (*ParallelManager, chan func() URLs)
 
 	return p, p.queueCh
 }
+
+// isErrThrottled - returns true if the error means the server asks
+// to slow down or the request timed out.
+func isErrThrottled(err *probe.Error) bool {
+	e := err.ToGoError()
+	if e == context.DeadlineExceeded {
+		return true
+	}
+	if netErr, ok := e.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+	errResp := minio.ToErrorResponse(e)
+	switch errResp.Code {
+	case "SlowDown", "RequestTimeout", "ServiceUnavailable", "Throttling", "ThrottlingException", "RequestThrottled", "TooManyRequests":
+		return true
+	}
+	return errResp.StatusCode == 503 || errResp.StatusCode == 429
+}
+
+// parallelManagers - lets several parallel managers monitor the
+// same transfers, which makes each of them scale on the total
+// bandwidth.
+type parallelManagers []*ParallelManager
+
+func (p parallelManagers) Read(b []byte) (n int, err error) {
+	for _, m := range p {
+		m.Read(b)
+	}
+	return len(b), nil
+}
@@ -0,0 +1,99 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestParallelController(t *testing.T) {
+	testCases := []struct {
+		samples []parallelSample
+		changes []int
+	}{
+		// Throughput grows, then stalls: workers are added until
+		// no improvement is seen for a while.
+		{
+			samples: []parallelSample{
+				{workers: 4, bytes: 100},
+				{workers: 6, bytes: 200},
+				{workers: 8, bytes: 201},
+				{workers: 10, bytes: 199},
+				{workers: 12, bytes: 200},
+			},
+			changes: []int{defaultWorkerFactor, defaultWorkerFactor, defaultWorkerFactor, defaultWorkerFactor, 0},
+		},
+		// Small objects, the number of tasks grows while bytes do not.
+		{
+			samples: []parallelSample{
+				{workers: 4, bytes: 100, tasks: 10},
+				{workers: 6, bytes: 100, tasks: 20},
+				{workers: 8, bytes: 100, tasks: 40},
+			},
+			changes: []int{defaultWorkerFactor, defaultWorkerFactor, defaultWorkerFactor},
+		},
+		// The server throttles, workers are halved.
+		{
+			samples: []parallelSample{
+				{workers: 16, bytes: 100},
+				{workers: 18, bytes: 50, throttled: 3},
+				{workers: 9, bytes: 80},
+			},
+			changes: []int{defaultWorkerFactor, -9, defaultWorkerFactor},
+		},
+		// Latency rises without throughput gain, workers are
+		// brought back to the best known number.
+		{
+			samples: []parallelSample{
+				{workers: 4, bytes: 100, tasks: 10, latency: time.Second},
+				{workers: 6, bytes: 100, tasks: 10, latency: 2 * time.Second},
+				{workers: 4, bytes: 100, tasks: 10, latency: time.Second},
+			},
+			changes: []int{defaultWorkerFactor, -2, 0},
+		},
+	}
+	for i, testCase := range testCases {
+		var controller parallelController
+		for j, sample := range testCase.samples {
+			if change := controller.next(sample); change != testCase.changes[j] {
+				t.Fatalf("Test %d, sample %d: Expected change %d, got %d", i+1, j+1, testCase.changes[j], change)
+			}
+		}
+	}
+}
+
+func TestIsErrThrottled(t *testing.T) {
+	testCases := []struct {
+		err       error
+		throttled bool
+	}{
+		{minio.ErrorResponse{Code: "SlowDown", StatusCode: 503}, true},
+		{minio.ErrorResponse{Code: "InternalError", StatusCode: 503}, true},
+		{minio.ErrorResponse{Code: "RequestTimeout", StatusCode: 400}, true},
+		{minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}, false},
+		{errors.New("unexpected EOF"), false},
+	}
+	for i, testCase := range testCases {
+		if throttled := isErrThrottled(probe.NewError(testCase.err)); throttled != testCase.throttled {
+			t.Fatalf("Test %d: Expected %t, got %t", i+1, testCase.throttled, throttled)
+		}
+	}
+}
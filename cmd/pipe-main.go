@@ -30,6 +30,18 @@ var (
 			Name:  "encrypt",
 			Usage: "encrypt objects (using server-side encryption with server managed keys)",
 		},
+		cli.StringFlag{
+			Name:  "encrypt-kms",
+			Usage: "encrypt objects using server-side encryption with KMS managed keys, e.g. \"s3/finance=my-key-id\"",
+		},
+		cli.StringFlag{
+			Name:  "encrypt-client",
+			Usage: "encrypt objects on the client with the 32 byte key in KEYFILE",
+		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress objects on upload with gzip or zstd",
+		},
 	}
 )
 
@@ -39,7 +51,7 @@ var pipeCmd = cli.Command{
 	Usage:  "stream STDIN to an object",
 	Action: mainPipe,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(pipeFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(pipeFlags, ioFlags...), limitUploadFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -52,6 +64,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefix values
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+   MC_ENCRYPT_KMS:  list of comma delimited prefix=key-id values, key-id may end with ?key=value encryption context
 
 EXAMPLES:
    1. Write contents of stdin to a file on local filesystem.
@@ -65,10 +78,22 @@ EXAMPLES:
 
    4. Stream MySQL database dump to Amazon S3 directly.
       $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} s3/sql-backups/backups/accountsdb-oct-9-2015.sql
+
+   5. Stream to an object on Amazon S3 encrypted with a KMS managed key and an encryption context.
+      $ cat ledger.csv | {{.HelpName}} --encrypt-kms "s3/finance=my-key-id?department=finance" s3/finance/ledger.csv
+
+   6. Stream MySQL database dump to Amazon S3, encrypted on the client before upload.
+      $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} --encrypt-client ~/.mc/backup.key s3/sql-backups/accountsdb.sql
+
+   7. Stream application logs to Amazon S3, compressed with zstd on upload.
+      $ journalctl -o json | {{.HelpName}} --compress zstd s3/logs/journal.json
+
+   8. Stream a database dump to Amazon S3, limiting the upload bandwidth to 5MiB per second.
+      $ pg_dump accountsdb | {{.HelpName}} --limit-upload 5MiB/s s3/sql-backups/accountsdb.sql
 `,
 }
 
-func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func pipe(targetURL, compression string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	if targetURL == "" {
 		// When no target is specified, pipe cat's stdin to stdout.
 		return catOut(os.Stdin, -1).Trace()
@@ -79,7 +104,7 @@ func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	// Stream from stdin to multiple objects until EOF.
 	// Ignore size, since os.Stat() would not return proper size all the time
 	// for local filesystem for example /proc files.
-	_, err := putTargetStreamWithURL(targetURL, os.Stdin, -1, sseKey)
+	_, err := putTargetStreamWithURL(targetURL, os.Stdin, -1, compression, sseKey)
 	// TODO: See if this check is necessary.
 	switch e := err.ToGoError().(type) {
 	case *os.PathError:
@@ -96,6 +121,7 @@ func checkPipeSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, "pipe", 1) // last argument is exit code.
 	}
+	fatalIf(checkCompression(ctx.String("compress")).Trace(ctx.Args()...), "Unable to compress objects.")
 }
 
 // mainPipe is the main entry point for pipe command.
@@ -104,16 +130,20 @@ func mainPipe(ctx *cli.Context) error {
 	encKeyDB, err := getEncKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	keyFile := ctx.String("encrypt-client")
+	fatalIf(setCSEKey(keyFile, ctx.Args()...), "Unable to load client-side encryption key `"+keyFile+"`.")
+	fatalIf(setBandwidthLimits(ctx.String("limit-upload"), ""), "Unable to set bandwidth limits.")
+
 	// validate pipe input arguments.
 	checkPipeSyntax(ctx)
 
 	if len(ctx.Args()) == 0 {
-		err = pipe("", nil)
+		err = pipe("", "", nil)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
 	} else {
 		// extract URLs.
 		URLs := ctx.Args()
-		err = pipe(URLs[0], encKeyDB)
+		err = pipe(URLs[0], ctx.String("compress"), encKeyDB)
 		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
 	}
 
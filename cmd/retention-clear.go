@@ -0,0 +1,100 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionClearFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "clear retention of all objects under the prefix",
+		},
+		cli.BoolFlag{
+			Name:  "bypass",
+			Usage: "bypass governance mode, required to clear a governance retention",
+		},
+		cli.BoolFlag{
+			Name:  "default",
+			Usage: "clear the default retention of the bucket",
+		},
+	}
+)
+
+var retentionClearCmd = cli.Command{
+	Name:   "clear",
+	Usage:  "clear retention of objects or the default retention of a bucket",
+	Action: mainRetentionClear,
+	Before: setGlobalsFromContext,
+	Flags:  append(retentionClearFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Clear the governance mode retention of an object
+     $ {{.HelpName}} --bypass myminio/mybucket/myobject.csv
+
+   2. Clear the default retention of a bucket
+     $ {{.HelpName}} --default myminio/mybucket
+
+`,
+}
+
+// checkRetentionClearSyntax - validate all the passed arguments
+func checkRetentionClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+	if ctx.Bool("default") && ctx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--default cannot be used with --recursive.")
+	}
+}
+
+func mainRetentionClear(ctx *cli.Context) error {
+	console.SetColor("Retention", color.New(color.FgGreen, color.Bold))
+
+	checkRetentionClearSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	if ctx.Bool("default") {
+		err := newLockClient(urlStr).SetObjectLockConfig("", 0, "")
+		fatalIf(err.Trace(urlStr), "Cannot clear the default retention of the specified bucket.")
+		printMsg(retentionSetMessage{URL: urlStr, IsDefault: true})
+		return nil
+	}
+
+	return forEachLockObject(urlStr, ctx.Bool("recursive"), "Unable to clear retention of", func(clnt *s3Client, objectURL string) *probe.Error {
+		if err := clnt.SetObjectRetention("", time.Time{}, ctx.Bool("bypass")); err != nil {
+			return err
+		}
+		printMsg(retentionSetMessage{URL: objectURL})
+		return nil
+	})
+}
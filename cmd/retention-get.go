@@ -0,0 +1,145 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionGetFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "show retention of all objects under the prefix",
+		},
+		cli.BoolFlag{
+			Name:  "default",
+			Usage: "show the default retention of the bucket",
+		},
+	}
+)
+
+var retentionGetCmd = cli.Command{
+	Name:   "get",
+	Usage:  "show retention of objects or the default retention of a bucket",
+	Action: mainRetentionGet,
+	Before: setGlobalsFromContext,
+	Flags:  append(retentionGetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Show the retention of an object
+     $ {{.HelpName}} myminio/mybucket/myobject.csv
+
+   2. Show the retention of all objects under a prefix
+     $ {{.HelpName}} --recursive myminio/mybucket/reports/
+
+   3. Show the default retention of a bucket
+     $ {{.HelpName}} --default myminio/mybucket
+
+`,
+}
+
+// checkRetentionGetSyntax - validate all the passed arguments
+func checkRetentionGetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "get", 1) // last argument is exit code
+	}
+	if ctx.Bool("default") && ctx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--default cannot be used with --recursive.")
+	}
+}
+
+// retentionGetMessage container
+type retentionGetMessage struct {
+	Status          string     `json:"status"`
+	URL             string     `json:"url"`
+	Mode            string     `json:"mode"`
+	RetainUntilDate *time.Time `json:"retainUntilDate,omitempty"`
+	Validity        string     `json:"validity,omitempty"`
+	IsDefault       bool       `json:"isDefault,omitempty"`
+}
+
+// JSON jsonified retention get message.
+func (r retentionGetMessage) JSON() string {
+	r.Status = "success"
+	retentionGetMessageJSONBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(retentionGetMessageJSONBytes)
+}
+
+func (r retentionGetMessage) String() string {
+	msg := console.Colorize("URL", r.URL+": ")
+	switch {
+	case r.Mode == "" && r.IsDefault:
+		return msg + "No default retention"
+	case r.Mode == "":
+		return msg + "No retention"
+	case r.IsDefault:
+		return msg + console.Colorize("Mode", r.Mode) + " for " + r.Validity
+	case r.RetainUntilDate == nil:
+		return msg + console.Colorize("Mode", r.Mode)
+	}
+	return msg + console.Colorize("Mode", r.Mode) + " until " + r.RetainUntilDate.Format(printDate)
+}
+
+func mainRetentionGet(ctx *cli.Context) error {
+	console.SetColor("URL", color.New(color.Bold))
+	console.SetColor("Mode", color.New(color.FgCyan, color.Bold))
+
+	checkRetentionGetSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	if ctx.Bool("default") {
+		mode, validity, unit, err := newLockClient(urlStr).GetObjectLockConfig()
+		fatalIf(err.Trace(urlStr), "Cannot get the default retention of the specified bucket.")
+		msg := retentionGetMessage{URL: urlStr, Mode: mode, IsDefault: true}
+		if mode != "" {
+			msg.Validity = strconv.FormatUint(validity, 10) + " " + strings.ToLower(unit)
+		}
+		printMsg(msg)
+		return nil
+	}
+
+	return forEachLockObject(urlStr, ctx.Bool("recursive"), "Unable to get retention of", func(clnt *s3Client, objectURL string) *probe.Error {
+		mode, until, err := clnt.GetObjectRetention()
+		if err != nil {
+			return err
+		}
+		printMsg(retentionGetMessage{
+			URL:             objectURL,
+			Mode:            mode,
+			RetainUntilDate: &until,
+		})
+		return nil
+	})
+}
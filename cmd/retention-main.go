@@ -0,0 +1,127 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionFlags = []cli.Flag{}
+)
+
+var retentionCmd = cli.Command{
+	Name:            "retention",
+	Usage:           "manage object retention and default bucket retention",
+	HideHelpCommand: true,
+	Action:          mainRetention,
+	Before:          setGlobalsFromContext,
+	Flags:           append(retentionFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		retentionSetCmd,
+		retentionGetCmd,
+		retentionClearCmd,
+	},
+}
+
+// mainRetention is the handle for "mc retention" command.
+func mainRetention(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "get", "clear" have their own main.
+}
+
+// newLockClient - returns the S3 client of a bucket or an object
+// with object lock enabled.
+func newLockClient(urlStr string) *s3Client {
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(urlStr), "The provided url doesn't point to a S3 server.")
+	}
+	return s3Client
+}
+
+// forEachLockObject - calls fn with the object at urlStr, or with all
+// objects under urlStr when isRecursive is set. Failures are reported
+// with errMsg and do not stop the walk, any failure results in an
+// error exit status.
+func forEachLockObject(urlStr string, isRecursive bool, errMsg string, fn func(clnt *s3Client, objectURL string) *probe.Error) error {
+	clnt := newLockClient(urlStr)
+	if !isRecursive {
+		if err := fn(clnt, urlStr); err != nil {
+			errorIf(err.Trace(urlStr), errMsg+" `"+urlStr+"`.")
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
+	alias, _, _ := mustExpandAlias(urlStr)
+	var cErr error
+	for content := range clnt.List(isRecursive, false, DirNone) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(urlStr), "Unable to list `"+urlStr+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		objectURL := filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+		objectClnt, err := newClientFromAlias(alias, content.URL.String())
+		if err == nil {
+			err = fn(objectClnt.(*s3Client), objectURL)
+		}
+		if err != nil {
+			errorIf(err.Trace(objectURL), errMsg+" `"+objectURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+		}
+	}
+	return cErr
+}
+
+// parseRetentionValidity parses a retention validity of the form
+// <n>d or <n>y, e.g. 30d or 1y.
+func parseRetentionValidity(validity string) (uint64, string, *probe.Error) {
+	unit := validityDays
+	switch {
+	case strings.HasSuffix(validity, "y"), strings.HasSuffix(validity, "Y"):
+		unit = validityYears
+	case strings.HasSuffix(validity, "d"), strings.HasSuffix(validity, "D"):
+	default:
+		return 0, "", probe.NewError(fmt.Errorf("invalid validity `%s`, should be of the form 30d or 1y", validity))
+	}
+	value, e := strconv.ParseUint(validity[:len(validity)-1], 10, 32)
+	if e != nil || value == 0 {
+		return 0, "", probe.NewError(fmt.Errorf("invalid validity `%s`, should be of the form 30d or 1y", validity))
+	}
+	return value, unit, nil
+}
+
+// retainUntil returns the end of a retention validity starting at t.
+func retainUntil(t time.Time, validity uint64, unit string) time.Time {
+	if unit == validityYears {
+		return t.AddDate(int(validity), 0, 0)
+	}
+	return t.AddDate(0, 0, int(validity))
+}
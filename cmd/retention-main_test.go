@@ -0,0 +1,53 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestParseRetentionValidity(t *testing.T) {
+	testCases := []struct {
+		validity string
+		value    uint64
+		unit     string
+		success  bool
+	}{
+		{validity: "30d", value: 30, unit: validityDays, success: true},
+		{validity: "1y", value: 1, unit: validityYears, success: true},
+		{validity: "2Y", value: 2, unit: validityYears, success: true},
+		{validity: "0d", success: false},
+		{validity: "30", success: false},
+		{validity: "d", success: false},
+		{validity: "-1y", success: false},
+		{validity: "1m", success: false},
+	}
+
+	for i, testCase := range testCases {
+		value, unit, err := parseRetentionValidity(testCase.validity)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: Expected success, got %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: Expected error, got success", i+1)
+		}
+		if err != nil {
+			continue
+		}
+		if value != testCase.value || unit != testCase.unit {
+			t.Errorf("Test %d: Expected %d %s, got %d %s", i+1, testCase.value, testCase.unit, value, unit)
+		}
+	}
+}
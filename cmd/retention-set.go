@@ -0,0 +1,169 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	retentionSetFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "set retention of all objects under the prefix",
+		},
+		cli.BoolFlag{
+			Name:  "bypass",
+			Usage: "bypass governance mode, required to shorten a governance retention",
+		},
+		cli.BoolFlag{
+			Name:  "default",
+			Usage: "set the default retention of the bucket, applied to new objects",
+		},
+	}
+)
+
+var retentionSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set retention of objects or the default retention of a bucket",
+	Action: mainRetentionSet,
+	Before: setGlobalsFromContext,
+	Flags:  append(retentionSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] MODE VALIDITY TARGET
+
+MODE:
+  governance, compliance
+
+VALIDITY:
+  number of days or years, e.g. 30d or 1y
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Protect an object in governance mode for 30 days
+     $ {{.HelpName}} governance 30d myminio/mybucket/myobject.csv
+
+   2. Protect all objects under a prefix in compliance mode for 1 year
+     $ {{.HelpName}} --recursive compliance 1y myminio/mybucket/reports/
+
+   3. Set the default retention of a bucket, applied to all new objects
+     $ {{.HelpName}} --default governance 90d myminio/mybucket
+
+`,
+}
+
+// parseRetentionMode - validate and normalize a retention mode argument.
+func parseRetentionMode(mode string) (string, *probe.Error) {
+	switch strings.ToUpper(mode) {
+	case retentionGovernance:
+		return retentionGovernance, nil
+	case retentionCompliance:
+		return retentionCompliance, nil
+	}
+	return "", errInvalidArgument().Trace(mode)
+}
+
+// checkRetentionSetSyntax - validate all the passed arguments
+func checkRetentionSetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 3 {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+	if ctx.Bool("default") && ctx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--default cannot be used with --recursive.")
+	}
+}
+
+// retentionSetMessage container
+type retentionSetMessage struct {
+	Status          string     `json:"status"`
+	URL             string     `json:"url"`
+	Mode            string     `json:"mode,omitempty"`
+	RetainUntilDate *time.Time `json:"retainUntilDate,omitempty"`
+	Validity        string     `json:"validity,omitempty"`
+	IsDefault       bool       `json:"isDefault,omitempty"`
+}
+
+// JSON jsonified retention set message.
+func (r retentionSetMessage) JSON() string {
+	r.Status = "success"
+	retentionSetMessageJSONBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(retentionSetMessageJSONBytes)
+}
+
+func (r retentionSetMessage) String() string {
+	switch {
+	case r.Mode == "" && r.IsDefault:
+		return console.Colorize("Retention", "Successfully cleared the default retention of `"+r.URL+"`.")
+	case r.Mode == "":
+		return console.Colorize("Retention", "Successfully cleared the retention of `"+r.URL+"`.")
+	case r.IsDefault:
+		return console.Colorize("Retention", "Successfully set the default retention of `"+r.URL+"` to "+r.Mode+" for "+r.Validity+".")
+	}
+	return console.Colorize("Retention", "Successfully protected `"+r.URL+"` in "+r.Mode+" mode until "+r.RetainUntilDate.Format(printDate)+".")
+}
+
+func mainRetentionSet(ctx *cli.Context) error {
+	console.SetColor("Retention", color.New(color.FgGreen, color.Bold))
+
+	checkRetentionSetSyntax(ctx)
+
+	args := ctx.Args()
+	mode, err := parseRetentionMode(args.Get(0))
+	fatalIf(err, "Unable to parse retention mode, use governance or compliance.")
+	validity, unit, err := parseRetentionValidity(args.Get(1))
+	fatalIf(err, "Unable to parse retention validity.")
+	urlStr := args.Get(2)
+
+	if ctx.Bool("default") {
+		err = newLockClient(urlStr).SetObjectLockConfig(mode, validity, unit)
+		fatalIf(err.Trace(urlStr), "Cannot set the default retention of the specified bucket.")
+		printMsg(retentionSetMessage{
+			URL:       urlStr,
+			Mode:      mode,
+			Validity:  strconv.FormatUint(validity, 10) + " " + strings.ToLower(unit),
+			IsDefault: true,
+		})
+		return nil
+	}
+
+	until := retainUntil(UTCNow(), validity, unit)
+	return forEachLockObject(urlStr, ctx.Bool("recursive"), "Unable to set retention of", func(clnt *s3Client, objectURL string) *probe.Error {
+		if err := clnt.SetObjectRetention(mode, until, ctx.Bool("bypass")); err != nil {
+			return err
+		}
+		printMsg(retentionSetMessage{
+			URL:             objectURL,
+			Mode:            mode,
+			RetainUntilDate: &until,
+		})
+		return nil
+	})
+}
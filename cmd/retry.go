@@ -0,0 +1,283 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Default retry policy of transfers.
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryBackoff    = time.Second
+	defaultRetryMaxBackoff = time.Minute
+)
+
+// retryPolicy - how transfers failing for transient reasons are retried.
+type retryPolicy struct {
+	// Attempts of a transfer in total, one means no retry.
+	Attempts int
+	// Delay before the first retry, doubled on every further retry
+	// up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// hostRetryConfig - retry policy of an alias, empty values fall back
+// to the defaults.
+type hostRetryConfig struct {
+	Attempts int    `json:"attempts,omitempty"`
+	Backoff  string `json:"backoff,omitempty"`
+}
+
+// Retry options given on the command line, which take precedence over
+// the retry policy of aliases, zero values mean not set.
+var (
+	globalRetryAttempts int
+	globalRetryBackoff  time.Duration
+)
+
+// parseRetryOptions - parse retry attempts and the initial backoff,
+// zero attempts and an empty backoff mean not set.
+func parseRetryOptions(attempts int, backoff string) (time.Duration, *probe.Error) {
+	if attempts < 0 {
+		return 0, errInvalidRetry(fmt.Sprintf("%d attempts", attempts)).Trace(backoff)
+	}
+	if backoff == "" {
+		return 0, nil
+	}
+	duration, e := time.ParseDuration(backoff)
+	if e != nil || duration <= 0 {
+		return 0, errInvalidRetry("backoff " + backoff).Trace(backoff)
+	}
+	return duration, nil
+}
+
+// setRetryOptions - set the retry options given on the command line.
+func setRetryOptions(attempts int, backoff string) *probe.Error {
+	duration, err := parseRetryOptions(attempts, backoff)
+	if err != nil {
+		return err
+	}
+	globalRetryAttempts, globalRetryBackoff = attempts, duration
+	return nil
+}
+
+// merge - override the policy by the options which are set.
+func (p retryPolicy) merge(attempts int, backoff time.Duration) retryPolicy {
+	if attempts > 0 {
+		p.Attempts = attempts
+	}
+	if backoff > 0 {
+		p.Backoff = backoff
+		if p.MaxBackoff < backoff {
+			p.MaxBackoff = backoff
+		}
+	}
+	return p
+}
+
+// retryPolicyFor - the retry policy of a transfer from sourceAlias to
+// targetAlias. Options given on the command line come first, then the
+// policy of the target alias, then the one of the source alias.
+func retryPolicyFor(sourceAlias, targetAlias string) retryPolicy {
+	policy := retryPolicy{
+		Attempts:   defaultRetryAttempts,
+		Backoff:    defaultRetryBackoff,
+		MaxBackoff: defaultRetryMaxBackoff,
+	}
+	for _, alias := range []string{sourceAlias, targetAlias} {
+		if alias == "" {
+			continue
+		}
+		hostCfg := mustGetHostConfig(alias)
+		if hostCfg == nil || hostCfg.Retry == nil {
+			continue
+		}
+		// The configuration has been validated when it was added.
+		backoff, _ := parseRetryOptions(hostCfg.Retry.Attempts, hostCfg.Retry.Backoff)
+		policy = policy.merge(hostCfg.Retry.Attempts, backoff)
+	}
+	return policy.merge(globalRetryAttempts, globalRetryBackoff)
+}
+
+// delay - the delay before the given retry, starting at one. Half of
+// the delay is random, so that parallel transfers failing together
+// are not retried together.
+func (p retryPolicy) delay(retry int) time.Duration {
+	backoff := p.Backoff
+	for i := 1; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	half := int64(backoff / 2)
+	if half <= 0 {
+		return backoff
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// isErrTransient - returns true if the error of a transfer may go
+// away by itself, such as timeouts, dropped connections, server errors
+// and throttling. Errors about permissions, missing buckets, unknown
+// hosts, certificates and such are permanent.
+func isErrTransient(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	if isErrThrottled(err) {
+		return true
+	}
+	switch e := err.ToGoError().(type) {
+	case UnexpectedEOF, UnexpectedShortWrite:
+		return true
+	case minio.ErrorResponse:
+		return e.StatusCode >= 500 || e.Code == "InternalError" || e.Code == "IncompleteBody"
+	case net.Error:
+		return e.Timeout() || e.Temporary() || isErrnoTransient(e)
+	case syscall.Errno:
+		return isErrnoTransient(e)
+	}
+	return err.ToGoError() == io.ErrUnexpectedEOF
+}
+
+// isErrnoTransient - returns true if the error is caused by a
+// connection which was reset, refused or broken.
+func isErrnoTransient(e error) bool {
+	for {
+		switch err := e.(type) {
+		case *url.Error:
+			e = err.Err
+		case *net.OpError:
+			e = err.Err
+		case *os.SyscallError:
+			e = err.Err
+		case syscall.Errno:
+			return err == syscall.ECONNRESET || err == syscall.ECONNREFUSED || err == syscall.EPIPE
+		default:
+			return false
+		}
+	}
+}
+
+// retryMessage container for a transfer about to be retried
+type retryMessage struct {
+	Status   string `json:"status"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Attempt  int    `json:"attempt"`
+	Attempts int    `json:"attempts"`
+	Delay    string `json:"delay"`
+	Error    string `json:"error"`
+}
+
+// String colorized retry message
+func (r retryMessage) String() string {
+	return console.Colorize("Retry", fmt.Sprintf("Retrying `%s` -> `%s` in %s, attempt %d of %d failed: %s",
+		r.Source, r.Target, r.Delay, r.Attempt, r.Attempts, r.Error))
+}
+
+// JSON jsonified retry message
+func (r retryMessage) JSON() string {
+	r.Status = "retry"
+	retryMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(retryMessageBytes)
+}
+
+// attemptProgress - reports the progress of an attempt of a transfer
+// and counts it, so that it can be taken back when the attempt fails.
+type attemptProgress struct {
+	progress io.Reader
+	n        int64
+}
+
+func (a *attemptProgress) Read(p []byte) (int, error) {
+	atomic.AddInt64(&a.n, int64(len(p)))
+	return a.progress.Read(p)
+}
+
+// takeBack - take back the progress reported by the attempt.
+func (a *attemptProgress) takeBack() {
+	n := -atomic.SwapInt64(&a.n, 0)
+	switch p := a.progress.(type) {
+	case Status:
+		p.Add(n)
+	case *progressBar:
+		p.Add64(n)
+	case *accounter:
+		p.Add(n)
+	}
+}
+
+// retryUploadSourceToTargetURL - uploads to targetURL from source,
+// retrying transient failures and uploads failing verification with
+// an exponential backoff. Every retry is reported through printFn, and
+// attempts failing because the server throttles through throttledFn
+// as well, if set. The progress of a failed attempt is taken back.
+func retryUploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader, encKeyDB map[string][]prefixSSEPair, isVerify bool, printFn func(message), throttledFn func()) URLs {
+	policy := retryPolicyFor(urls.SourceAlias, urls.TargetAlias)
+	var attemptProgressReader *attemptProgress
+	if progress != nil {
+		attemptProgressReader = &attemptProgress{progress: progress}
+		progress = attemptProgressReader
+	}
+	for attempt := 1; ; attempt++ {
+		result := uploadSourceToTargetURL(ctx, urls, progress, encKeyDB, isVerify)
+		if throttledFn != nil && isErrThrottled(result.Error) {
+			throttledFn()
+		}
+		isRetriable := isErrTransient(result.Error) || isErrCorrupted(result.Error)
+		if result.Error == nil || attempt >= policy.Attempts || !isRetriable || ctx.Err() != nil {
+			return result
+		}
+		if attemptProgressReader != nil {
+			attemptProgressReader.takeBack()
+		}
+		delay := policy.delay(attempt)
+		printFn(retryMessage{
+			Source:   filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path)),
+			Target:   filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)),
+			Attempt:  attempt,
+			Attempts: policy.Attempts,
+			Delay:    delay.Round(time.Millisecond).String(),
+			Error:    result.Error.ToGoError().Error(),
+		})
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return result
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestAttemptProgress(t *testing.T) {
+	acct := &accounter{}
+	acct.Add(100)
+
+	// A failed attempt takes back what it reported, the next
+	// attempt reports the object again.
+	progress := &attemptProgress{progress: acct}
+	progress.Read(make([]byte, 30))
+	progress.takeBack()
+	if acct.Get() != 100 {
+		t.Fatalf("Expected progress of 100 after a failed attempt, got %d", acct.Get())
+	}
+	progress.Read(make([]byte, 50))
+	if acct.Get() != 150 {
+		t.Fatalf("Expected progress of 150 after the next attempt, got %d", acct.Get())
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := retryPolicy{Attempts: 5, Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	testCases := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 500 * time.Millisecond, time.Second},
+		{2, time.Second, 2 * time.Second},
+		{3, 2 * time.Second, 4 * time.Second},
+		{4, 2500 * time.Millisecond, 5 * time.Second},
+		{10, 2500 * time.Millisecond, 5 * time.Second},
+	}
+	for i, testCase := range testCases {
+		if delay := policy.delay(testCase.retry); delay < testCase.min || delay > testCase.max {
+			t.Fatalf("Test %d: Expected a delay between %s and %s, got %s", i+1, testCase.min, testCase.max, delay)
+		}
+	}
+
+	merged := policy.merge(0, 10*time.Second)
+	if merged.Attempts != 5 || merged.Backoff != 10*time.Second || merged.MaxBackoff != 10*time.Second {
+		t.Fatalf("Unexpected merged policy %+v", merged)
+	}
+	if _, err := parseRetryOptions(-1, ""); err == nil {
+		t.Fatal("Expected negative attempts to fail")
+	}
+	if _, err := parseRetryOptions(3, "soon"); err == nil {
+		t.Fatal("Expected an invalid backoff to fail")
+	}
+}
+
+func TestIsErrTransient(t *testing.T) {
+	testCases := []struct {
+		err       error
+		transient bool
+	}{
+		{minio.ErrorResponse{Code: "SlowDown", StatusCode: 503}, true},
+		{minio.ErrorResponse{Code: "InternalError", StatusCode: 500}, true},
+		{UnexpectedEOF{TotalSize: 10, TotalWritten: 5}, true},
+		{io.ErrUnexpectedEOF, true},
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{syscall.ECONNRESET, true},
+		{&url.Error{Op: "Put", URL: "https://s3/bucket", Err: &net.OpError{Op: "dial", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}}, true},
+		{&net.DNSError{Err: "no such host", Name: "s3", IsNotFound: true}, false},
+		{&url.Error{Op: "Get", URL: "https://s3/bucket", Err: x509.UnknownAuthorityError{}}, false},
+		{io.EOF, false},
+		{PathInsufficientPermission{Path: "s3/bucket"}, false},
+		{BucketDoesNotExist{Bucket: "bucket"}, false},
+		{minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}, false},
+		{context.Canceled, false},
+	}
+	for i, testCase := range testCases {
+		if transient := isErrTransient(probe.NewError(testCase.err)); transient != testCase.transient {
+			t.Fatalf("Test %d: Expected %t for %v, got %t", i+1, testCase.transient, testCase.err, transient)
+		}
+	}
+}
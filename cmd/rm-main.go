@@ -64,6 +64,18 @@ var (
 			Name:  "newer-than",
 			Usage: "remove objects newer than L days, M hours and N minutes",
 		},
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "remove a specific version of an object, or a delete marker",
+		},
+		cli.StringFlag{
+			Name:  "report",
+			Usage: "record the outcome of every object in a JSON report file, or CSV if its name ends with .csv",
+		},
+		cli.StringFlag{
+			Name:  "from-list",
+			Usage: "remove the sources read from a JSON lines file, or CSV if its name ends with .csv, as given to 'cp --from-list'",
+		},
 	}
 )
 
@@ -79,6 +91,7 @@ var rmCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+  {{.HelpName}} [FLAGS] --force --from-list LIST
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -113,18 +126,32 @@ EXAMPLES:
 
    9. Remove an encrypted object from Amazon S3 cloud storage.
       $ {{.HelpName}} --encrypt-key "s3/sql-backups/=32byteslongsecretkeymustbegiven1" s3/sql-backups/1999/old-backup.tgz
+
+  10. Remove a delete marker to restore the previous version of an object in a versioned bucket.
+      $ {{.HelpName}} --version-id "uPsT7ZBnS2GLTmpK6fEPkTwzuXnjM3rV" s3/sql-backups/1999/old-backup.tgz
+
+  11. Remove all objects older than 1 year recursively from bucket 'audit-logs' and record every removed object in a report.
+      $ {{.HelpName}} --recursive --force --older-than 365d --report removed-logs.csv s3/audit-logs/
+
+  12. Move the objects of a list by copying them, then removing their sources.
+      $ mc cp --from-list objects.jsonl
+      $ {{.HelpName}} --force --from-list objects.jsonl
 `,
 }
 
 // Structured message depending on the type of console.
 type rmMessage struct {
-	Status string `json:"status"`
-	Key    string `json:"key"`
-	Size   int64  `json:"size"`
+	Status    string `json:"status"`
+	Key       string `json:"key"`
+	Size      int64  `json:"size"`
+	VersionID string `json:"versionId,omitempty"`
 }
 
 // Colorized message for console printing.
 func (r rmMessage) String() string {
+	if r.VersionID != "" {
+		return console.Colorize("Remove", fmt.Sprintf("Removing `%s` (version `%s`).", r.Key, r.VersionID))
+	}
 	return console.Colorize("Remove", fmt.Sprintf("Removing `%s`.", r.Key))
 }
 
@@ -142,9 +169,22 @@ func checkRmSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	isForce := ctx.Bool("force")
 	isRecursive := ctx.Bool("recursive")
 	isStdin := ctx.Bool("stdin")
+	fromList := ctx.String("from-list")
 	isDangerous := ctx.Bool("dangerous")
 	isNamespaceRemoval := false
 
+	if ctx.String("version-id") != "" {
+		if isRecursive || isStdin || fromList != "" || ctx.Bool("incomplete") || len(ctx.Args()) != 1 {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...),
+				"--version-id requires exactly one object and cannot be used with --recursive, --stdin, --from-list or --incomplete.")
+		}
+	}
+	if fromList != "" {
+		list, err := openCopyList(fromList, false)
+		fatalIf(err.Trace(fromList), "Unable to read the list of objects to remove.")
+		list.close()
+	}
+
 	for _, url := range ctx.Args() {
 		// clean path for aliases like s3/.
 		//Note: UNC path using / works properly in go 1.9.2 even though it breaks the UNC specification.
@@ -156,13 +196,13 @@ func checkRmSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 			break
 		}
 	}
-	if !ctx.Args().Present() && !isStdin {
+	if !ctx.Args().Present() && !isStdin && fromList == "" {
 		exitCode := 1
 		cli.ShowCommandHelpAndExit(ctx, "rm", exitCode)
 	}
 
 	// For all recursive operations make sure to check for 'force' flag.
-	if (isRecursive || isStdin) && !isForce {
+	if (isRecursive || isStdin || fromList != "") && !isForce {
 		if isNamespaceRemoval {
 			fatalIf(errDummy().Trace(),
 				"This operation results in site-wide removal of objects. If you are really sure, retry this command with ‘--dangerous’ and ‘--force’ flags.")
@@ -176,16 +216,37 @@ func checkRmSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	}
 }
 
-func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+// statRemoveVersion - stat the version of an object to be removed,
+// delete markers cannot be stat'ed but can be removed.
+func statRemoveVersion(url, versionID string, encKeyDB map[string][]prefixSSEPair) ([]*clientContent, *probe.Error) {
+	content, pErr := statURLVersion(url, versionID, encKeyDB)
+	if pErr != nil {
+		if _, ok := pErr.ToGoError().(VersionIsDeleteMarker); !ok {
+			return nil, pErr
+		}
+		content = &clientContent{VersionID: versionID, IsDeleteMarker: true}
+	}
+	return []*clientContent{content}, nil
+}
+
+func removeSingle(url, versionID string, isIncomplete bool, isFake, isForce bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair, report *transferReport) error {
 	isRecursive := false
-	contents, pErr := statURL(url, isIncomplete, isRecursive, encKeyDB)
+	var contents []*clientContent
+	var pErr *probe.Error
+	if versionID != "" {
+		contents, pErr = statRemoveVersion(url, versionID, encKeyDB)
+	} else {
+		contents, pErr = statURL(url, isIncomplete, isRecursive, encKeyDB)
+	}
 	if pErr != nil {
 		errorIf(pErr.Trace(url), "Failed to remove `"+url+"`.")
+		report.record(reportObject{Status: reportFailed, Target: url, Reason: pErr.ToGoError().Error()})
 		return exitStatus(globalErrorExitStatus)
 	}
 	if len(contents) == 0 {
 		if !isForce {
 			errorIf(errDummy().Trace(url), "Failed to remove `"+url+"`. Target object is not found")
+			report.record(reportObject{Status: reportFailed, Target: url, Reason: "object not found"})
 			return exitStatus(globalErrorExitStatus)
 		}
 		return nil
@@ -195,17 +256,20 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 
 	// Skip objects older than older--than parameter if specified
 	if olderThan != "" && isOlder(content.Time, olderThan) {
+		report.record(reportObject{Status: reportSkipped, Target: url, Size: content.Size, Reason: "not older than " + olderThan})
 		return nil
 	}
 
 	// Skip objects older than older--than parameter if specified
 	if newerThan != "" && isNewer(content.Time, newerThan) {
+		report.record(reportObject{Status: reportSkipped, Target: url, Size: content.Size, Reason: "not newer than " + newerThan})
 		return nil
 	}
 
 	printMsg(rmMessage{
-		Key:  url,
-		Size: content.Size,
+		Key:       url,
+		Size:      content.Size,
+		VersionID: versionID,
 	})
 
 	if !isFake {
@@ -213,17 +277,19 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 		clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 		if pErr != nil {
 			errorIf(pErr.Trace(url), "Invalid argument `"+url+"`.")
+			report.record(reportObject{Status: reportFailed, Target: url, Size: content.Size, Reason: pErr.ToGoError().Error()})
 			return exitStatus(globalErrorExitStatus) // End of journey.
 		}
 
 		contentCh := make(chan *clientContent, 1)
-		contentCh <- &clientContent{URL: *newClientURL(targetURL)}
+		contentCh <- &clientContent{URL: *newClientURL(targetURL), VersionID: versionID}
 		close(contentCh)
 		isRemoveBucket := false
 		errorCh := clnt.Remove(isIncomplete, isRemoveBucket, contentCh)
 		for pErr := range errorCh {
 			if pErr != nil {
 				errorIf(pErr.Trace(url), "Failed to remove `"+url+"`.")
+				report.record(reportObject{Status: reportFailed, Target: url, Size: content.Size, Reason: pErr.ToGoError().Error()})
 				switch pErr.ToGoError().(type) {
 				case PathInsufficientPermission:
 					// Ignore Permission error.
@@ -233,14 +299,52 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 			}
 		}
 	}
+	report.record(reportObject{Status: reportRemoved, Target: url, Size: content.Size})
 	return nil
 }
 
-func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+// removeErrorPath - path of the object whose removal failed with err,
+// empty if it is not known.
+func removeErrorPath(err *probe.Error) string {
+	switch e := err.ToGoError().(type) {
+	case PathInsufficientPermission:
+		return e.Path
+	case ObjectLocked:
+		return e.Object
+	case *os.PathError:
+		return e.Path
+	}
+	return ""
+}
+
+// recordRemovals - record the outcome of the contents sent for removal,
+// Remove reports failures only. Objects are removed in order, those
+// following the one whose error stopped the removal are not removed.
+// If that one is not known, the removal of none of them is confirmed.
+func recordRemovals(report *transferReport, alias string, contents []*clientContent, failed map[string]string, stopErr *probe.Error) {
+	var stopPath string
+	if stopErr != nil {
+		stopPath = removeErrorPath(stopErr)
+	}
+	isStopped := false
+	for _, content := range contents {
+		object := reportObject{Status: reportRemoved, Target: alias + content.URL.Path, Size: content.Size}
+		if reason, ok := failed[content.URL.Path]; ok {
+			object.Status, object.Reason = reportFailed, reason
+			isStopped = isStopped || content.URL.Path == stopPath
+		} else if stopErr != nil && (isStopped || stopPath == "") {
+			object.Status, object.Reason = reportFailed, stopErr.ToGoError().Error()
+		}
+		report.record(object)
+	}
+}
+
+func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair, report *transferReport) error {
 	targetAlias, targetURL, _ := mustExpandAlias(url)
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
 		errorIf(pErr.Trace(url), "Failed to remove `"+url+"` recursively.")
+		report.record(reportObject{Status: reportFailed, Target: url, Reason: pErr.ToGoError().Error()})
 		return exitStatus(globalErrorExitStatus) // End of journey.
 	}
 	contentCh := make(chan *clientContent)
@@ -248,16 +352,30 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 
 	errorCh := clnt.Remove(isIncomplete, isRemoveBucket, contentCh)
 
+	var cErr error
+
+	// Remove reports failures only, the outcome of the objects sent
+	// to it is recorded once the removal is over.
+	var pending []*clientContent
+	failed := make(map[string]string)
+	recordError := func(pErr *probe.Error) {
+		if errorPath := removeErrorPath(pErr); errorPath != "" {
+			failed[errorPath] = pErr.ToGoError().Error()
+		}
+	}
+
 	isRecursive := true
 	for content := range clnt.List(isRecursive, isIncomplete, DirLast) {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(url), "Failed to remove `"+url+"` recursively.")
+			report.record(reportObject{Status: reportFailed, Target: url, Reason: content.Err.ToGoError().Error()})
 			switch content.Err.ToGoError().(type) {
 			case PathInsufficientPermission:
 				// Ignore Permission error.
 				continue
 			}
 			close(contentCh)
+			recordRemovals(report, targetAlias, pending, failed, content.Err)
 			return exitStatus(globalErrorExitStatus)
 		}
 		urlString := content.URL.Path
@@ -265,11 +383,13 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 		if !content.Time.IsZero() {
 			// Skip objects older than --older-than parameter if specified
 			if olderThan != "" && isOlder(content.Time, olderThan) {
+				report.record(reportObject{Status: reportSkipped, Target: targetAlias + urlString, Size: content.Size, Reason: "not older than " + olderThan})
 				continue
 			}
 
 			// Skip objects newer than --newer-than parameter if specified
 			if newerThan != "" && isNewer(content.Time, newerThan) {
+				report.record(reportObject{Status: reportSkipped, Target: targetAlias + urlString, Size: content.Size, Reason: "not newer than " + newerThan})
 				continue
 			}
 		}
@@ -287,30 +407,47 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 					sent = true
 				case pErr := <-errorCh:
 					errorIf(pErr.Trace(urlString), "Failed to remove `"+urlString+"`.")
+					recordError(pErr)
 					switch pErr.ToGoError().(type) {
 					case PathInsufficientPermission:
 						// Ignore Permission error.
 						continue
+					case ObjectLocked:
+						// Locked objects are kept, remove the others.
+						cErr = exitStatus(globalErrorExitStatus)
+						continue
 					}
 					close(contentCh)
+					recordRemovals(report, targetAlias, pending, failed, pErr)
 					return exitStatus(globalErrorExitStatus)
 				}
 			}
+			if report != nil {
+				pending = append(pending, content)
+			}
+		} else {
+			report.record(reportObject{Status: reportRemoved, Target: targetAlias + urlString, Size: content.Size})
 		}
 	}
 
 	close(contentCh)
 	for pErr := range errorCh {
 		errorIf(pErr.Trace(url), "Failed to remove `"+url+"` recursively.")
+		recordError(pErr)
 		switch pErr.ToGoError().(type) {
 		case PathInsufficientPermission:
 			// Ignore Permission error.
 			continue
+		case ObjectLocked:
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
 		}
+		recordRemovals(report, targetAlias, pending, failed, pErr)
 		return exitStatus(globalErrorExitStatus)
 	}
 
-	return nil
+	recordRemovals(report, targetAlias, pending, failed, nil)
+	return cErr
 }
 
 // main for rm command.
@@ -327,21 +464,26 @@ func mainRm(ctx *cli.Context) error {
 	isRecursive := ctx.Bool("recursive")
 	isFake := ctx.Bool("fake")
 	isStdin := ctx.Bool("stdin")
+	fromList := ctx.String("from-list")
 	olderThan := ctx.String("older-than")
 	newerThan := ctx.String("newer-than")
 	isForce := ctx.Bool("force")
+	versionID := ctx.String("version-id")
 
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
 
+	report, err := newTransferReport(ctx.String("report"), "rm", ctx.Args(), false)
+	fatalIf(err, "Unable to create report.")
+
 	var rerr error
 	var e error
 	// Support multiple targets.
 	for _, url := range ctx.Args() {
 		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
+			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB, report)
 		} else {
-			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
+			e = removeSingle(url, versionID, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB, report)
 		}
 
 		if rerr == nil {
@@ -349,23 +491,56 @@ func mainRm(ctx *cli.Context) error {
 		}
 	}
 
-	if !isStdin {
-		return rerr
+	if isStdin {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			url := scanner.Text()
+			if isRecursive {
+				e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB, report)
+			} else {
+				e = removeSingle(url, versionID, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB, report)
+			}
+
+			if rerr == nil {
+				rerr = e
+			}
+		}
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		url := scanner.Text()
-		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
-		} else {
-			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
+	if fromList != "" {
+		list, err := openCopyList(fromList, false)
+		fatalIf(err.Trace(fromList), "Unable to read the list of objects to remove.")
+		for {
+			entry, err := list.next()
+			if err != nil {
+				errorIf(err.Trace(fromList), "Unable to read the list of objects to remove.")
+				report.record(reportObject{Status: reportFailed, Reason: err.ToGoError().Error()})
+				if rerr == nil {
+					rerr = exitStatus(globalErrorExitStatus)
+				}
+				continue
+			}
+			if entry == nil {
+				break
+			}
+			if isRecursive {
+				e = removeRecursive(entry.Source, isIncomplete, isFake, olderThan, newerThan, encKeyDB, report)
+			} else {
+				e = removeSingle(entry.Source, versionID, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB, report)
+			}
+
+			if rerr == nil {
+				rerr = e
+			}
 		}
+		list.close()
+	}
 
+	if err = report.close(); err != nil {
+		errorIf(err.Trace(), "Unable to write report.")
 		if rerr == nil {
-			rerr = e
+			rerr = exitStatus(globalErrorExitStatus)
 		}
 	}
-
 	return rerr
 }
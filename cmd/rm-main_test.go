@@ -0,0 +1,85 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestRecordRemovals(t *testing.T) {
+	var contents []*clientContent
+	for _, name := range []string{"a", "b", "c", "d"} {
+		contents = append(contents, &clientContent{URL: *newClientURL("/bucket/" + name), Size: 1})
+	}
+
+	testCases := []struct {
+		failed   map[string]string
+		stopErr  *probe.Error
+		statuses string
+	}{
+		{nil, nil, "RRRR"},
+		{map[string]string{"/bucket/b": "locked"}, nil, "RFRR"},
+		{
+			map[string]string{"/bucket/c": "input/output error"},
+			probe.NewError(&os.PathError{Op: "remove", Path: "/bucket/c", Err: syscall.EIO}),
+			"RRFF",
+		},
+		{nil, probe.NewError(io.ErrUnexpectedEOF), "FFFF"},
+	}
+
+	dir, e := ioutil.TempDir("", "mc-report-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	for i, testCase := range testCases {
+		reportPath := filepath.Join(dir, fmt.Sprintf("report-%d.csv", i))
+		report, err := newTransferReport(reportPath, "rm", nil, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recordRemovals(report, "s3", contents, testCase.failed, testCase.stopErr)
+		if err = report.close(); err != nil {
+			t.Fatal(err)
+		}
+		data, e := ioutil.ReadFile(reportPath)
+		if e != nil {
+			t.Fatal(e)
+		}
+		var statuses string
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, reportRemoved+",") {
+				statuses += "R"
+			} else if strings.HasPrefix(line, reportFailed+",") {
+				statuses += "F"
+			}
+		}
+		if statuses != testCase.statuses {
+			t.Errorf("Test %d: expected outcomes %s, got %s", i+1, testCase.statuses, statuses)
+		}
+	}
+}
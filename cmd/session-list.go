@@ -17,10 +17,13 @@
 package cmd
 
 import (
+	"fmt"
+	"path/filepath"
 	"sort"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 )
@@ -35,7 +38,7 @@ var sessionList = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}}
+  {{.HelpName}} [SESSION-ID]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -43,14 +46,17 @@ FLAGS:
 EXAMPLES:
   1. List sessions.
      $ {{.HelpName}}
+
+  2. List objects which failed in a session, they are not retried when the session is resumed.
+     $ {{.HelpName}} ygVIpSJs
 `,
 }
 
 // listSessions list all current sessions.
 func listSessions() *probe.Error {
-	var bySessions []*sessionV8
+	var bySessions []*sessionV9
 	for _, sid := range getSessionIDs() {
-		session, err := loadSessionV8(sid)
+		session, err := loadSessionV9(sid)
 		if err != nil {
 			continue // Skip 'broken' session during listing
 		}
@@ -65,8 +71,71 @@ func listSessions() *probe.Error {
 	return nil
 }
 
+// sessionFailedMessage container for an object which failed in a session.
+type sessionFailedMessage struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+	URL       string `json:"url"`
+}
+
+// String colorized session failed message.
+func (s sessionFailedMessage) String() string {
+	return console.Colorize("SessionFailed", fmt.Sprintf("`%s` failed.", s.URL))
+}
+
+// JSON jsonified session failed message.
+func (s sessionFailedMessage) JSON() string {
+	s.Status = "success"
+	failedBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(failedBytes)
+}
+
+// sessionEntryURL - the object an entry of the session data is about,
+// the source of a copy or the target of a removal.
+func sessionEntryURL(commandType string, data []byte) string {
+	var sURLs URLs
+	if commandType == "mirror" {
+		var entry mirrorSessionEntry
+		if e := json.Unmarshal(data, &entry); e != nil {
+			return string(data)
+		}
+		sURLs = entry.URLs
+	} else if e := json.Unmarshal(data, &sURLs); e != nil {
+		return string(data)
+	}
+	switch {
+	case sURLs.SourceContent != nil:
+		return filepath.ToSlash(filepath.Join(sURLs.SourceAlias, sURLs.SourceContent.URL.Path))
+	case sURLs.TargetContent != nil:
+		return filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
+	}
+	return string(data)
+}
+
+// listSessionFailed list all objects which failed in a session.
+func listSessionFailed(sid string) *probe.Error {
+	session, err := loadSessionV9(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	defer session.Close()
+
+	for entry := range session.FailedEntries() {
+		if entry.Err != nil {
+			return entry.Err.Trace(sid)
+		}
+		printMsg(sessionFailedMessage{
+			SessionID: sid,
+			URL:       sessionEntryURL(session.Header.CommandType, entry.Data),
+		})
+	}
+	return nil
+}
+
 func checkSessionListSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 0 {
+	if len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
 	}
 }
@@ -79,10 +148,19 @@ func mainSessionList(ctx *cli.Context) error {
 	console.SetColor("Command", color.New(color.FgWhite, color.Bold))
 	console.SetColor("SessionID", color.New(color.FgYellow, color.Bold))
 	console.SetColor("SessionTime", color.New(color.FgGreen))
+	console.SetColor("SessionFailed", color.New(color.FgRed))
 
 	if !isSessionDirExists() {
 		fatalIf(createSessionDir().Trace(), "Unable to create session folder.")
 	}
+	if sid := ctx.Args().First(); sid != "" {
+		if !isSessionExists(sid) {
+			fatalIf(errDummy().Trace(sid), "Session `"+sid+"` not found.")
+		}
+		// List objects which failed in the session.
+		fatalIf(listSessionFailed(sid).Trace(ctx.Args()...), "Unable to list failed objects of session `"+sid+"`.")
+		return nil
+	}
 	// List all resumable sessions.
 	fatalIf(listSessions().Trace(ctx.Args()...), "Unable to list sessions.")
 	return nil
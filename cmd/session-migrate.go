@@ -17,14 +17,187 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/binary"
+	"io"
 	"os"
 	"strconv"
 
+	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/quick"
 )
 
+// Migrates session version '8' to '9'. Version '9' adds an index with
+// the status of every entry of the session data. Version '8' resumed
+// after the last copied entry, all entries up to it are done.
+func migrateSessionV8ToV9() {
+	for _, sid := range getSessionIDs() {
+		sV8Header, err := loadSessionV8Header(sid)
+		if err != nil {
+			if os.IsNotExist(err.ToGoError()) {
+				continue
+			}
+			fatalIf(err.Trace(sid), "Unable to load version `8`. Migration failed please report this issue at https://github.com/minio/mc/issues.")
+		}
+
+		sessionVersion, e := strconv.Atoi(sV8Header.Version)
+		fatalIf(probe.NewError(e), "Unable to load version `8`. Migration failed please report this issue at https://github.com/minio/mc/issues.")
+		if sessionVersion > 8 { // It is new format.
+			continue
+		}
+
+		sessionFile, err := getSessionFile(sid)
+		fatalIf(err.Trace(sid), "Unable to get session file.")
+
+		sessionDataFile, err := getSessionDataFile(sid)
+		fatalIf(err.Trace(sid), "Unable to get session data file.")
+
+		sessionIndexFile, err := getSessionIndexFile(sid)
+		fatalIf(err.Trace(sid), "Unable to get session index file.")
+
+		// Initialize v9 header and migrate to new config.
+		sV9Header := &sessionV9Header{}
+		sV9Header.Version = globalSessionConfigVersion
+		sV9Header.When = sV8Header.When
+		sV9Header.RootPath = sV8Header.RootPath
+		sV9Header.GlobalBoolFlags = sV8Header.GlobalBoolFlags
+		sV9Header.GlobalIntFlags = sV8Header.GlobalIntFlags
+		sV9Header.GlobalStringFlags = sV8Header.GlobalStringFlags
+		sV9Header.CommandType = sV8Header.CommandType
+		sV9Header.CommandArgs = sV8Header.CommandArgs
+		sV9Header.CommandBoolFlags = sV8Header.CommandBoolFlags
+		sV9Header.CommandIntFlags = sV8Header.CommandIntFlags
+		sV9Header.CommandStringFlags = sV8Header.CommandStringFlags
+		sV9Header.TotalBytes = sV8Header.TotalBytes
+		sV9Header.TotalObjects = sV8Header.TotalObjects
+		sV9Header.UserMetaData = sV8Header.UserMetaData
+
+		// Build the index of the session data.
+		sV9Header.ResumeEntry, sV9Header.ResumeBytes, err = migrateSessionDataV8ToV9(sV8Header, sessionDataFile, sessionIndexFile)
+		fatalIf(err.Trace(sid, sessionDataFile), "Unable to migrate session data from '8' to '9'.")
+
+		qs, e := quick.NewConfig(sV9Header, nil)
+		fatalIf(probe.NewError(e).Trace(sid), "Unable to initialize quick config for session '9' header.")
+
+		e = qs.Save(sessionFile)
+		fatalIf(probe.NewError(e).Trace(sid, sessionFile), "Unable to migrate session from '8' to '9'.")
+
+		console.Println("Successfully migrated `" + sessionFile + "` from version `" + sV8Header.Version + "` to " + "`" + sV9Header.Version + "`.")
+	}
+}
+
+// sessionV8Entry - key and size of an entry of a version '8' session
+// data file, the key is what version '8' saved as the last copied entry.
+func sessionV8Entry(commandType string, data []byte) (key string, size int64) {
+	var sURLs URLs
+	if commandType == "mirror" {
+		var entry mirrorSessionEntry
+		if e := json.Unmarshal(data, &entry); e != nil {
+			return "", 0
+		}
+		sURLs = entry.URLs
+		if sURLs.TargetContent != nil {
+			key = sURLs.TargetAlias + ":" + sURLs.TargetContent.URL.String()
+		}
+	} else {
+		if e := json.Unmarshal(data, &sURLs); e != nil {
+			return "", 0
+		}
+		if sURLs.SourceContent != nil {
+			key = sURLs.SourceContent.URL.String()
+		}
+	}
+	if sURLs.SourceContent != nil {
+		size = sURLs.SourceContent.Size
+	}
+	return key, size
+}
+
+// migrateSessionDataV8ToV9 - write the index of a version '8' session
+// data file, returns the first entry which is not done and the bytes
+// of all entries before it.
+func migrateSessionDataV8ToV9(header *sessionV8Header, dataFile, indexFile string) (resumeEntry, resumeBytes int64, err *probe.Error) {
+	lastCopied := header.LastCopied
+	if lastCopied == "" {
+		lastCopied = header.LastRemoved
+	}
+
+	// readEntries calls fn for every entry of the data file.
+	readEntries := func(fn func(offset int64, key string, size int64) *probe.Error) *probe.Error {
+		dataFP, e := os.Open(dataFile)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		defer dataFP.Close()
+		reader := bufio.NewReader(dataFP)
+		var offset int64
+		for {
+			line, e := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				key, size := sessionV8Entry(header.CommandType, line)
+				if err := fn(offset, key, size); err != nil {
+					return err
+				}
+				offset += int64(len(line))
+			}
+			if e == io.EOF {
+				return nil
+			}
+			if e != nil {
+				return probe.NewError(e)
+			}
+		}
+	}
+
+	// Find the last copied entry, entries up to it are done.
+	doneEntries := int64(0)
+	if lastCopied != "" {
+		var index int64
+		err = readEntries(func(offset int64, key string, size int64) *probe.Error {
+			index++
+			if doneEntries == 0 && key == lastCopied {
+				doneEntries = index
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	indexFP, e := os.Create(indexFile)
+	if e != nil {
+		return 0, 0, probe.NewError(e)
+	}
+	defer indexFP.Close()
+	writer := bufio.NewWriter(indexFP)
+	record := make([]byte, sessionIndexRecordSize)
+	var index int64
+	err = readEntries(func(offset int64, key string, size int64) *probe.Error {
+		binary.BigEndian.PutUint64(record[0:8], uint64(offset))
+		binary.BigEndian.PutUint64(record[8:16], uint64(size))
+		record[16] = sessionEntryPending
+		if index < doneEntries {
+			record[16] = sessionEntryDone
+			resumeBytes += size
+		}
+		index++
+		if _, e := writer.Write(record); e != nil {
+			return probe.NewError(e)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if e = writer.Flush(); e != nil {
+		return 0, 0, probe.NewError(e)
+	}
+	return doneEntries, resumeBytes, nil
+}
+
 // Migrates session header version '7' to '8'. The only
 // change was the adding of insecure global flag
 func migrateSessionV7ToV8() {
@@ -51,7 +224,7 @@ func migrateSessionV7ToV8() {
 
 		// Initialize v7 header and migrate to new config.
 		sV8Header := &sessionV8Header{}
-		sV8Header.Version = globalSessionConfigVersion
+		sV8Header.Version = "8"
 		sV8Header.When = sV7.Header.When
 		sV8Header.RootPath = sV7.Header.RootPath
 		sV8Header.GlobalBoolFlags = sV7.Header.GlobalBoolFlags
@@ -146,3 +146,54 @@ func loadSessionV7(sid string) (*sessionV7, *probe.Error) {
 
 	return s, nil
 }
+
+/////////////////// Session V8 ///////////////////
+
+// sessionV8Header for resumable sessions.
+type sessionV8Header struct {
+	Version            string            `json:"version"`
+	When               time.Time         `json:"time"`
+	RootPath           string            `json:"workingFolder"`
+	GlobalBoolFlags    map[string]bool   `json:"globalBoolFlags"`
+	GlobalIntFlags     map[string]int    `json:"globalIntFlags"`
+	GlobalStringFlags  map[string]string `json:"globalStringFlags"`
+	CommandType        string            `json:"commandType"`
+	CommandArgs        []string          `json:"cmdArgs"`
+	CommandBoolFlags   map[string]bool   `json:"cmdBoolFlags"`
+	CommandIntFlags    map[string]int    `json:"cmdIntFlags"`
+	CommandStringFlags map[string]string `json:"cmdStringFlags"`
+	LastCopied         string            `json:"lastCopied"`
+	LastRemoved        string            `json:"lastRemoved"`
+	TotalBytes         int64             `json:"totalBytes"`
+	TotalObjects       int64             `json:"totalObjects"`
+	UserMetaData       map[string]string `json:"metaData"`
+}
+
+func loadSessionV8Header(sid string) (*sessionV8Header, *probe.Error) {
+	if !isSessionDirExists() {
+		return nil, errInvalidArgument().Trace()
+	}
+
+	sessionFile, err := getSessionFile(sid)
+	if err != nil {
+		return nil, err.Trace(sid)
+	}
+
+	if _, e := os.Stat(sessionFile); e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	sV8Header := &sessionV8Header{}
+	sV8Header.Version = "8"
+	qs, e := quick.NewConfig(sV8Header, nil)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(sid, sV8Header.Version)
+	}
+	e = qs.Load(sessionFile)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(sid, sV8Header.Version)
+	}
+
+	sV8Header = qs.Data().(*sessionV8Header)
+	return sV8Header, nil
+}
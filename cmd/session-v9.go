@@ -0,0 +1,704 @@
+/*
+ * MinIO Client, (C) 2015, 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cmd - session V9 - Version 9 stores session header, session data
+// and an index of the session data in three separate files. Session data
+// contains fully prepared URL list, the index keeps the status of every
+// entry so that a resumed session skips exactly the completed ones.
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/quick"
+)
+
+// Status of a session data entry.
+const (
+	sessionEntryPending byte = 'P'
+	sessionEntryDone    byte = 'D'
+	sessionEntryFailed  byte = 'F'
+	sessionEntryHeld    byte = 'H'
+)
+
+// A record of the session index holds the offset of the entry in the
+// session data, the number of bytes it accounts for and its status.
+const sessionIndexRecordSize = 17
+
+// Entries are read from the session data up to this size.
+const maxSessionDataSize = 1 << 62
+
+// The index is updated for every completed entry, the header, which
+// only tells where to resume from, is saved at most this often.
+const sessionSaveInterval = 5 * time.Second
+
+// sessionV9Header for resumable sessions.
+type sessionV9Header struct {
+	Version            string            `json:"version"`
+	When               time.Time         `json:"time"`
+	RootPath           string            `json:"workingFolder"`
+	GlobalBoolFlags    map[string]bool   `json:"globalBoolFlags"`
+	GlobalIntFlags     map[string]int    `json:"globalIntFlags"`
+	GlobalStringFlags  map[string]string `json:"globalStringFlags"`
+	CommandType        string            `json:"commandType"`
+	CommandArgs        []string          `json:"cmdArgs"`
+	CommandBoolFlags   map[string]bool   `json:"cmdBoolFlags"`
+	CommandIntFlags    map[string]int    `json:"cmdIntFlags"`
+	CommandStringFlags map[string]string `json:"cmdStringFlags"`
+	// All entries before ResumeEntry are complete, they account for ResumeBytes.
+	ResumeEntry   int64             `json:"resumeEntry"`
+	ResumeBytes   int64             `json:"resumeBytes"`
+	FailedObjects int64             `json:"failedObjects"`
+	TotalBytes    int64             `json:"totalBytes"`
+	TotalObjects  int64             `json:"totalObjects"`
+	UserMetaData  map[string]string `json:"metaData"`
+}
+
+// sessionMessage container for session messages
+type sessionMessage struct {
+	Status        string    `json:"status"`
+	SessionID     string    `json:"sessionId"`
+	Time          time.Time `json:"time"`
+	CommandType   string    `json:"commandType"`
+	CommandArgs   []string  `json:"commandArgs"`
+	FailedObjects int64     `json:"failedObjects,omitempty"`
+}
+
+// sessionEntry - an entry of the session data.
+type sessionEntry struct {
+	Index  int64
+	Size   int64
+	Status byte
+	Data   []byte
+	Err    *probe.Error
+}
+
+// sessionProgress - tracks the completion of the entries handed out
+// for processing. Parallel workers complete entries out of order, next
+// is the first entry which is not complete.
+type sessionProgress struct {
+	next  int64
+	bytes int64
+	// size of the entries handed out, and whether they are complete
+	sizes    map[int64]int64
+	complete map[int64]bool
+}
+
+func newSessionProgress(next, bytes int64) *sessionProgress {
+	return &sessionProgress{
+		next:     next,
+		bytes:    bytes,
+		sizes:    make(map[int64]int64),
+		complete: make(map[int64]bool),
+	}
+}
+
+// add - hand out an entry, entries are handed out in order.
+func (p *sessionProgress) add(index, size int64) {
+	p.sizes[index] = size
+}
+
+// finish - mark an entry as complete, returns true if the first entry
+// which is not complete moved forward.
+func (p *sessionProgress) finish(index int64) bool {
+	if _, ok := p.sizes[index]; !ok {
+		return false
+	}
+	p.complete[index] = true
+	moved := false
+	for p.complete[p.next] {
+		p.bytes += p.sizes[p.next]
+		delete(p.sizes, p.next)
+		delete(p.complete, p.next)
+		p.next++
+		moved = true
+	}
+	return moved
+}
+
+// sessionV9 resumable session container.
+type sessionV9 struct {
+	Header    *sessionV9Header
+	SessionID string
+	mutex     *sync.Mutex
+	DataFP    *sessionDataFP
+	IndexFP   *sessionDataFP
+
+	// size of the session data, where the next entry is added,
+	// and the number of entries
+	dataSize int64
+	entries  int64
+	progress *sessionProgress
+	savedAt  time.Time
+}
+
+// sessionDataFP data file pointer.
+type sessionDataFP struct {
+	dirty bool
+	*os.File
+}
+
+func (file *sessionDataFP) Write(p []byte) (int, error) {
+	file.dirty = true
+	return file.File.Write(p)
+}
+
+func (file *sessionDataFP) WriteAt(p []byte, off int64) (int, error) {
+	file.dirty = true
+	return file.File.WriteAt(p, off)
+}
+
+// String colorized session message.
+func (s sessionV9) String() string {
+	message := console.Colorize("SessionID", fmt.Sprintf("%s -> ", s.SessionID))
+	message = message + console.Colorize("SessionTime", fmt.Sprintf("[%s]", s.Header.When.Local().Format(printDate)))
+	message = message + console.Colorize("Command", fmt.Sprintf(" %s %s", s.Header.CommandType, strings.Join(s.Header.CommandArgs, " ")))
+	if s.Header.FailedObjects > 0 {
+		message = message + console.Colorize("SessionFailed", fmt.Sprintf(" (%d failed)", s.Header.FailedObjects))
+	}
+	return message
+}
+
+// JSON jsonified session message.
+func (s sessionV9) JSON() string {
+	sessionMsg := sessionMessage{
+		SessionID:     s.SessionID,
+		Time:          s.Header.When.Local(),
+		CommandType:   s.Header.CommandType,
+		CommandArgs:   s.Header.CommandArgs,
+		FailedObjects: s.Header.FailedObjects,
+	}
+	sessionMsg.Status = "success"
+	sessionBytes, e := json.MarshalIndent(sessionMsg, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(sessionBytes)
+}
+
+// loadSessionV9 - reads session file if exists and re-initiates internal variables
+func loadSessionV9(sid string) (*sessionV9, *probe.Error) {
+	if !isSessionDirExists() {
+		return nil, errInvalidArgument().Trace()
+	}
+	sessionFile, err := getSessionFile(sid)
+	if err != nil {
+		return nil, err.Trace(sid)
+	}
+
+	if _, e := os.Stat(sessionFile); e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	// Initialize new session.
+	s := &sessionV9{
+		Header: &sessionV9Header{
+			Version: globalSessionConfigVersion,
+		},
+		SessionID: sid,
+	}
+
+	// Initialize session config loader.
+	qs, e := quick.NewConfig(s.Header, nil)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(sid, s.Header.Version)
+	}
+
+	if e = qs.Load(sessionFile); e != nil {
+		return nil, probe.NewError(e).Trace(sid, s.Header.Version)
+	}
+
+	// Validate if the version matches with expected current version.
+	sV9Header := qs.Data().(*sessionV9Header)
+	if sV9Header.Version != globalSessionConfigVersion {
+		msg := fmt.Sprintf("Session header version %s does not match mc session version %s.\n",
+			sV9Header.Version, globalSessionConfigVersion)
+		return nil, probe.NewError(errors.New(msg)).Trace(sid, sV9Header.Version)
+	}
+
+	s.mutex = new(sync.Mutex)
+	s.Header = sV9Header
+	s.progress = newSessionProgress(s.Header.ResumeEntry, s.Header.ResumeBytes)
+	s.savedAt = UTCNow()
+
+	sessionDataFile, err := getSessionDataFile(s.SessionID)
+	if err != nil {
+		return nil, err.Trace(sid, s.Header.Version)
+	}
+	dataFile, e := os.OpenFile(sessionDataFile, os.O_RDWR, 0600)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	s.DataFP = &sessionDataFP{false, dataFile}
+
+	sessionIndexFile, err := getSessionIndexFile(s.SessionID)
+	if err != nil {
+		dataFile.Close()
+		return nil, err.Trace(sid, s.Header.Version)
+	}
+	indexFile, e := os.OpenFile(sessionIndexFile, os.O_RDWR, 0600)
+	if e != nil {
+		dataFile.Close()
+		return nil, probe.NewError(e)
+	}
+	s.IndexFP = &sessionDataFP{false, indexFile}
+
+	return s, nil
+}
+
+// newSessionV9 provides a new session.
+func newSessionV9() *sessionV9 {
+	s := &sessionV9{}
+	s.Header = &sessionV9Header{}
+	s.Header.Version = globalSessionConfigVersion
+	// map of command and files copied.
+	s.Header.GlobalBoolFlags = make(map[string]bool)
+	s.Header.GlobalIntFlags = make(map[string]int)
+	s.Header.GlobalStringFlags = make(map[string]string)
+	s.Header.CommandArgs = nil
+	s.Header.CommandBoolFlags = make(map[string]bool)
+	s.Header.CommandIntFlags = make(map[string]int)
+	s.Header.CommandStringFlags = make(map[string]string)
+	s.Header.UserMetaData = make(map[string]string)
+	s.Header.When = UTCNow()
+	s.mutex = new(sync.Mutex)
+	s.SessionID = newRandomID(8)
+	s.progress = newSessionProgress(0, 0)
+	s.savedAt = UTCNow()
+
+	sessionDataFile, err := getSessionDataFile(s.SessionID)
+	fatalIf(err.Trace(s.SessionID), "Unable to create session data file \""+sessionDataFile+"\".")
+
+	dataFile, e := os.Create(sessionDataFile)
+	fatalIf(probe.NewError(e), "Unable to create session data file \""+sessionDataFile+"\".")
+
+	s.DataFP = &sessionDataFP{false, dataFile}
+
+	sessionIndexFile, err := getSessionIndexFile(s.SessionID)
+	fatalIf(err.Trace(s.SessionID), "Unable to create session index file \""+sessionIndexFile+"\".")
+
+	indexFile, e := os.Create(sessionIndexFile)
+	fatalIf(probe.NewError(e), "Unable to create session index file \""+sessionIndexFile+"\".")
+
+	s.IndexFP = &sessionDataFP{false, indexFile}
+
+	// Capture state of global flags.
+	s.setGlobals()
+
+	return s
+}
+
+// HasData provides true if this is a session resume, false otherwise.
+func (s sessionV9) HasData() bool {
+	return s.Header.TotalObjects > 0
+}
+
+// ResetData removes all entries of the session data.
+func (s *sessionV9) ResetData() *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, fp := range []*sessionDataFP{s.DataFP, s.IndexFP} {
+		// when moving to file position 0 we want to truncate the file as well,
+		// otherwise we'll partly overwrite existing data
+		if _, e := fp.Seek(0, io.SeekStart); e != nil {
+			return probe.NewError(e)
+		}
+		if e := fp.Truncate(0); e != nil {
+			return probe.NewError(e)
+		}
+	}
+	s.dataSize = 0
+	s.entries = 0
+	s.progress = newSessionProgress(0, 0)
+	s.Header.ResumeEntry = 0
+	s.Header.ResumeBytes = 0
+	s.Header.FailedObjects = 0
+	return nil
+}
+
+// AddEntry adds a pending entry to the session data, size is the
+// number of bytes the entry accounts for in the progress.
+func (s *sessionV9) AddEntry(size int64, data []byte) *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err := s.addEntry(size, data, sessionEntryPending)
+	return err
+}
+
+// StartEntry adds a pending entry to the session data which is
+// handed out right away, as if it was provided by Entries. Returns
+// the index of the entry.
+func (s *sessionV9) StartEntry(size int64, data []byte) (int64, *probe.Error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	index, err := s.addEntry(size, data, sessionEntryPending)
+	if err != nil {
+		return 0, err
+	}
+	s.progress.add(index, size)
+	return index, nil
+}
+
+// HoldEntry adds an entry to the session data which is handed out
+// right away but held back, until it is released by ReleaseEntry or
+// dropped by DropEntry. Returns the index of the entry.
+func (s *sessionV9) HoldEntry(size int64, data []byte) (int64, *probe.Error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	index, err := s.addEntry(size, data, sessionEntryHeld)
+	if err != nil {
+		return 0, err
+	}
+	s.progress.add(index, size)
+	return index, nil
+}
+
+// addEntry adds an entry to the session data, with the mutex held.
+func (s *sessionV9) addEntry(size int64, data []byte, status byte) (int64, *probe.Error) {
+	line := make([]byte, len(data)+1)
+	copy(line, data)
+	line[len(data)] = '\n'
+	if _, e := s.DataFP.Write(line); e != nil {
+		return 0, probe.NewError(e)
+	}
+
+	record := make([]byte, sessionIndexRecordSize)
+	binary.BigEndian.PutUint64(record[0:8], uint64(s.dataSize))
+	binary.BigEndian.PutUint64(record[8:16], uint64(size))
+	record[16] = status
+	if _, e := s.IndexFP.Write(record); e != nil {
+		return 0, probe.NewError(e)
+	}
+	s.dataSize += int64(len(line))
+	s.entries++
+	return s.entries - 1, nil
+}
+
+// ReleaseEntry makes an entry held back by HoldEntry pending, its
+// status is set by SetEntryStatus once it is done or failed.
+func (s *sessionV9) ReleaseEntry(index int64) *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, e := s.IndexFP.WriteAt([]byte{sessionEntryPending}, index*sessionIndexRecordSize+16); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// DropEntry completes an entry held back by HoldEntry without
+// releasing it, a resumed session skips it.
+func (s *sessionV9) DropEntry(index int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.finish(index)
+}
+
+// Entries provides all entries of the session data from the first
+// one which is not complete. Entries complete before are handed out
+// as well, their status tells them apart.
+func (s *sessionV9) Entries() <-chan sessionEntry {
+	s.mutex.Lock()
+	first := s.Header.ResumeEntry
+	s.mutex.Unlock()
+
+	entryCh := make(chan sessionEntry)
+	go s.readEntries(first, 0, entryCh)
+	return entryCh
+}
+
+// FailedEntries provides all entries of the session data which failed.
+func (s *sessionV9) FailedEntries() <-chan sessionEntry {
+	entryCh := make(chan sessionEntry)
+	go s.readEntries(0, sessionEntryFailed, entryCh)
+	return entryCh
+}
+
+// HeldEntries provides all entries of the session data which are
+// held back.
+func (s *sessionV9) HeldEntries() <-chan sessionEntry {
+	entryCh := make(chan sessionEntry)
+	go s.readEntries(0, sessionEntryHeld, entryCh)
+	return entryCh
+}
+
+// readEntries reads the entries of the session data from entry first
+// on, only the entries with the given status unless it is zero.
+func (s *sessionV9) readEntries(first int64, status byte, entryCh chan<- sessionEntry) {
+	defer close(entryCh)
+
+	indexReader := bufio.NewReader(io.NewSectionReader(s.IndexFP, first*sessionIndexRecordSize, maxSessionDataSize))
+	var dataReader *bufio.Reader
+	dataOffset := int64(-1)
+	record := make([]byte, sessionIndexRecordSize)
+	for index := first; ; index++ {
+		if _, e := io.ReadFull(indexReader, record); e != nil {
+			if e != io.EOF {
+				entryCh <- sessionEntry{Err: probe.NewError(e).Trace(s.SessionID)}
+			}
+			return
+		}
+		entry := sessionEntry{
+			Index:  index,
+			Size:   int64(binary.BigEndian.Uint64(record[8:16])),
+			Status: record[16],
+		}
+		if status != 0 && entry.Status != status {
+			continue
+		}
+
+		// Entries are read in a row, unless some are skipped.
+		offset := int64(binary.BigEndian.Uint64(record[0:8]))
+		if offset != dataOffset {
+			dataReader = bufio.NewReader(io.NewSectionReader(s.DataFP, offset, maxSessionDataSize))
+		}
+		data, e := dataReader.ReadBytes('\n')
+		if e != nil {
+			entryCh <- sessionEntry{Err: probe.NewError(e).Trace(s.SessionID)}
+			return
+		}
+		dataOffset = offset + int64(len(data))
+		entry.Data = bytes.TrimSuffix(data, []byte("\n"))
+
+		if status == 0 {
+			s.mutex.Lock()
+			s.progress.add(entry.Index, entry.Size)
+			if entry.Status != sessionEntryPending {
+				s.finish(entry.Index)
+			}
+			s.mutex.Unlock()
+		}
+		entryCh <- entry
+	}
+}
+
+// SetEntryStatus sets the status of an entry handed out by Entries
+// once it is done or failed.
+func (s *sessionV9) SetEntryStatus(index int64, status byte) *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, e := s.IndexFP.WriteAt([]byte{status}, index*sessionIndexRecordSize+16); e != nil {
+		return probe.NewError(e)
+	}
+	if status == sessionEntryFailed {
+		s.Header.FailedObjects++
+	}
+	s.finish(index)
+
+	if time.Since(s.savedAt) < sessionSaveInterval {
+		return nil
+	}
+	return s.flush()
+}
+
+// finish marks an entry as complete, with the mutex held.
+func (s *sessionV9) finish(index int64) {
+	if s.progress.finish(index) {
+		s.Header.ResumeEntry = s.progress.next
+		s.Header.ResumeBytes = s.progress.bytes
+	}
+}
+
+// Save this session.
+func (s *sessionV9) Save() *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.flush()
+}
+
+// flush syncs the session data and saves the header, with the mutex held.
+func (s *sessionV9) flush() *probe.Error {
+	for _, fp := range []*sessionDataFP{s.DataFP, s.IndexFP} {
+		if fp.dirty {
+			if err := fp.Sync(); err != nil {
+				return probe.NewError(err)
+			}
+			fp.dirty = false
+		}
+	}
+
+	qs, e := quick.NewConfig(s.Header, nil)
+	if e != nil {
+		return probe.NewError(e).Trace(s.SessionID)
+	}
+
+	sessionFile, err := getSessionFile(s.SessionID)
+	if err != nil {
+		return err.Trace(s.SessionID)
+	}
+	e = qs.Save(sessionFile)
+	if e != nil {
+		return probe.NewError(e).Trace(sessionFile)
+	}
+	s.savedAt = UTCNow()
+	return nil
+}
+
+// setGlobals captures the state of global variables into session header.
+// Used by newSession.
+func (s *sessionV9) setGlobals() {
+	s.Header.GlobalBoolFlags["quiet"] = globalQuiet
+	s.Header.GlobalBoolFlags["debug"] = globalDebug
+	s.Header.GlobalBoolFlags["json"] = globalJSON
+	s.Header.GlobalBoolFlags["noColor"] = globalNoColor
+	s.Header.GlobalBoolFlags["insecure"] = globalInsecure
+}
+
+// RestoreGlobals restores the state of global variables.
+// Used by resumeSession.
+func (s sessionV9) restoreGlobals() {
+	quiet := s.Header.GlobalBoolFlags["quiet"]
+	debug := s.Header.GlobalBoolFlags["debug"]
+	json := s.Header.GlobalBoolFlags["json"]
+	noColor := s.Header.GlobalBoolFlags["noColor"]
+	insecure := s.Header.GlobalBoolFlags["insecure"]
+	setGlobals(quiet, debug, json, noColor, insecure)
+}
+
+// IsModified - returns if in memory session header has changed from
+// its on disk value.
+func (s *sessionV9) isModified(sessionFile string) (bool, *probe.Error) {
+	qs, e := quick.NewConfig(s.Header, nil)
+	if e != nil {
+		return false, probe.NewError(e).Trace(s.SessionID)
+	}
+
+	var currentHeader = &sessionV9Header{}
+	currentQS, e := quick.LoadConfig(sessionFile, nil, currentHeader)
+	if e != nil {
+		// If session does not exist for the first, return modified to
+		// be true.
+		if os.IsNotExist(e) {
+			return true, nil
+		}
+		// For all other errors return.
+		return false, probe.NewError(e).Trace(s.SessionID)
+	}
+
+	changedFields, e := qs.DeepDiff(currentQS)
+	if e != nil {
+		return false, probe.NewError(e).Trace(s.SessionID)
+	}
+
+	// Returns true if there are changed entries.
+	return len(changedFields) > 0, nil
+}
+
+// save - wrapper for quick.Save and saves only if sessionHeader is
+// modified.
+func (s *sessionV9) save() *probe.Error {
+	sessionFile, err := getSessionFile(s.SessionID)
+	if err != nil {
+		return err.Trace(s.SessionID)
+	}
+
+	// Verify if sessionFile is modified.
+	modified, err := s.isModified(sessionFile)
+	if err != nil {
+		return err.Trace(s.SessionID)
+	}
+	// Header is modified, we save it.
+	if modified {
+		qs, e := quick.NewConfig(s.Header, nil)
+		if e != nil {
+			return probe.NewError(e).Trace(s.SessionID)
+		}
+		// Save an return.
+		e = qs.Save(sessionFile)
+		if e != nil {
+			return probe.NewError(e).Trace(sessionFile)
+		}
+	}
+	return nil
+}
+
+// Close ends this session and removes all associated session files.
+func (s *sessionV9) Close() *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.DataFP.Close(); err != nil {
+		return probe.NewError(err)
+	}
+	if err := s.IndexFP.Close(); err != nil {
+		return probe.NewError(err)
+	}
+
+	// Attempt to save the header if modified.
+	return s.save()
+}
+
+// Delete removes all the session files.
+func (s *sessionV9) Delete() *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, fp := range []*sessionDataFP{s.DataFP, s.IndexFP} {
+		if fp == nil {
+			continue
+		}
+		name := fp.Name()
+		// close file pro-actively before deleting
+		// ignore any error, it could be possibly that
+		// the file is closed already
+		fp.Close()
+
+		// Remove the data or index file.
+		if e := os.Remove(name); e != nil {
+			return probe.NewError(e)
+		}
+	}
+
+	// Fetch the session file.
+	sessionFile, err := getSessionFile(s.SessionID)
+	if err != nil {
+		return err.Trace(s.SessionID)
+	}
+
+	// Remove session file
+	if e := os.Remove(sessionFile); e != nil {
+		return probe.NewError(e)
+	}
+
+	// Remove session backup file if any, ignore any error.
+	os.Remove(sessionFile + ".old")
+
+	return nil
+}
+
+// Close a session and exit.
+func (s sessionV9) CloseAndDie() {
+	s.Close()
+	console.Fatalln("Session safely terminated. To resume session `mc session resume " + s.SessionID + "`")
+}
@@ -34,6 +34,9 @@ func migrateSession() {
 
 	// Migrate V7 to V8
 	migrateSessionV7ToV8()
+
+	// Migrate V8 to V9
+	migrateSessionV8ToV9()
 }
 
 // createSessionDir - create session directory.
@@ -105,6 +108,17 @@ func getSessionDataFile(sid string) (string, *probe.Error) {
 	return sessionDataFile, nil
 }
 
+// getSessionIndexFile - get session index file for a given session.
+func getSessionIndexFile(sid string) (string, *probe.Error) {
+	sessionDir, err := getSessionDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+
+	sessionIndexFile := filepath.Join(sessionDir, sid+".index")
+	return sessionIndexFile, nil
+}
+
 // getSessionIDs - get all active sessions.
 func getSessionIDs() (sids []string) {
 	sessionDir, err := getSessionDir()
@@ -136,3 +150,12 @@ func removeSessionDataFile(sid string) {
 	}
 	os.Remove(dataFile)
 }
+
+// removeSessionIndexFile - remove the session index file, ending with .index
+func removeSessionIndexFile(sid string) {
+	indexFile, err := getSessionIndexFile(sid)
+	if err != nil {
+		return
+	}
+	os.Remove(indexFile)
+}
@@ -35,7 +35,7 @@ func (s *TestSuite) TestSession(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(isSessionDirExists(), Equals, true)
 
-	session := newSessionV8()
+	session := newSessionV9()
 	c.Assert(session.Header.CommandArgs, IsNil)
 	c.Assert(len(session.SessionID), Equals, 8)
 	_, e := os.Stat(session.DataFP.Name())
@@ -45,7 +45,7 @@ func (s *TestSuite) TestSession(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(isSessionExists(session.SessionID), Equals, true)
 
-	savedSession, err := loadSessionV8(session.SessionID)
+	savedSession, err := loadSessionV9(session.SessionID)
 	c.Assert(err, IsNil)
 	c.Assert(session.SessionID, Equals, savedSession.SessionID)
 
@@ -58,3 +58,108 @@ func (s *TestSuite) TestSession(c *C) {
 	_, e = os.Stat(session.DataFP.Name())
 	c.Assert(e, NotNil)
 }
+
+func (s *TestSuite) TestSessionEntries(c *C) {
+	err := createSessionDir()
+	c.Assert(err, IsNil)
+
+	session := newSessionV9()
+	c.Assert(session.ResetData(), IsNil)
+	for _, data := range []string{"a", "b", "c", "d"} {
+		c.Assert(session.AddEntry(int64(len(data)*10), []byte(data)), IsNil)
+	}
+	session.Header.TotalObjects = 4
+	c.Assert(session.HasData(), Equals, true)
+
+	var entries []sessionEntry
+	for entry := range session.Entries() {
+		c.Assert(entry.Err, IsNil)
+		c.Assert(entry.Status, Equals, sessionEntryPending)
+		entries = append(entries, entry)
+	}
+	c.Assert(len(entries), Equals, 4)
+	c.Assert(string(entries[2].Data), Equals, "c")
+
+	// Entries completing ahead of others do not move the resume entry.
+	c.Assert(session.SetEntryStatus(1, sessionEntryFailed), IsNil)
+	c.Assert(session.SetEntryStatus(2, sessionEntryDone), IsNil)
+	c.Assert(session.Header.ResumeEntry, Equals, int64(0))
+	c.Assert(session.SetEntryStatus(0, sessionEntryDone), IsNil)
+	c.Assert(session.Header.ResumeEntry, Equals, int64(3))
+	c.Assert(session.Header.ResumeBytes, Equals, int64(30))
+	c.Assert(session.Header.FailedObjects, Equals, int64(1))
+	c.Assert(session.Close(), IsNil)
+
+	// A resumed session reads only the remaining entries.
+	savedSession, err := loadSessionV9(session.SessionID)
+	c.Assert(err, IsNil)
+	entries = nil
+	for entry := range savedSession.Entries() {
+		c.Assert(entry.Err, IsNil)
+		entries = append(entries, entry)
+	}
+	c.Assert(len(entries), Equals, 1)
+	c.Assert(entries[0].Index, Equals, int64(3))
+	c.Assert(string(entries[0].Data), Equals, "d")
+
+	var failed []string
+	for entry := range savedSession.FailedEntries() {
+		c.Assert(entry.Err, IsNil)
+		failed = append(failed, string(entry.Data))
+	}
+	c.Assert(failed, DeepEquals, []string{"b"})
+
+	c.Assert(savedSession.Delete(), IsNil)
+	c.Assert(isSessionExists(session.SessionID), Equals, false)
+}
+
+func (s *TestSuite) TestSessionHeldEntries(c *C) {
+	err := createSessionDir()
+	c.Assert(err, IsNil)
+
+	session := newSessionV9()
+	c.Assert(session.ResetData(), IsNil)
+	for i, data := range []string{"a", "b", "c", "d"} {
+		var index int64
+		if i%2 == 0 {
+			index, err = session.StartEntry(10, []byte(data))
+		} else {
+			index, err = session.HoldEntry(10, []byte(data))
+		}
+		c.Assert(err, IsNil)
+		c.Assert(index, Equals, int64(i))
+	}
+
+	var held []sessionEntry
+	for entry := range session.HeldEntries() {
+		c.Assert(entry.Err, IsNil)
+		c.Assert(entry.Status, Equals, sessionEntryHeld)
+		held = append(held, entry)
+	}
+	c.Assert(len(held), Equals, 2)
+	c.Assert(string(held[0].Data), Equals, "b")
+
+	// Held entries hold up the resume entry until they are done or dropped.
+	c.Assert(session.SetEntryStatus(0, sessionEntryDone), IsNil)
+	c.Assert(session.SetEntryStatus(2, sessionEntryDone), IsNil)
+	c.Assert(session.Header.ResumeEntry, Equals, int64(1))
+	c.Assert(session.ReleaseEntry(held[0].Index), IsNil)
+	c.Assert(session.SetEntryStatus(held[0].Index, sessionEntryDone), IsNil)
+	c.Assert(session.Header.ResumeEntry, Equals, int64(3))
+	session.DropEntry(held[1].Index)
+	c.Assert(session.Header.ResumeEntry, Equals, int64(4))
+
+	// A dropped entry stays held back.
+	var statuses []byte
+	c.Assert(session.ResetData(), IsNil)
+	_, err = session.HoldEntry(10, []byte("e"))
+	c.Assert(err, IsNil)
+	for entry := range session.Entries() {
+		c.Assert(entry.Err, IsNil)
+		statuses = append(statuses, entry.Status)
+	}
+	c.Assert(statuses, DeepEquals, []byte{sessionEntryHeld})
+
+	c.Assert(session.Close(), IsNil)
+	c.Assert(session.Delete(), IsNil)
+}
@@ -89,6 +89,7 @@ FLAGS:
   {{end}}
 ENVIRONMENT VARIABLES:
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+   MC_ENCRYPT_KMS:  list of comma delimited prefix=key-id values, key-id may end with ?key=value encryption context
 
 SERIALIZATION OPTIONS:
    For query serialization options, refer to https://docs.min.io/docs/minio-client-complete-guide#sql
@@ -318,7 +319,7 @@ func getCSVHeader(sourceURL string, encKeyDB map[string][]prefixSSEPair) ([]stri
 	default:
 		var err *probe.Error
 		var metadata map[string]string
-		if r, metadata, err = getSourceStreamMetadataFromURL(sourceURL, encKeyDB); err != nil {
+		if r, metadata, err = getSourceStreamMetadataFromURL(sourceURL, "", encKeyDB); err != nil {
 			return nil, err.Trace(sourceURL)
 		}
 		ctype := metadata["Content-Type"]
@@ -401,6 +402,14 @@ func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair
 	}
 
 	sseKey := getSSE(targetURL, encKeyDB[alias])
+	// Objects compressed by mc are decompressed by the server.
+	if selOpts.CompressionType == "" {
+		if content, err := targetClnt.Stat(false, true, sseKey); err == nil {
+			if selOpts.CompressionType, err = compressionSelectType(content.Metadata); err != nil {
+				return err.Trace(targetURL)
+			}
+		}
+	}
 	outputer, err := targetClnt.Select(expression, sseKey, selOpts)
 	if err != nil {
 		return err.Trace(targetURL, expression)
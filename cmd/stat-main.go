@@ -22,6 +22,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
 )
 
 // stat specific flags.
@@ -31,6 +32,10 @@ var (
 			Name:  "recursive, r",
 			Usage: "stat all objects recursively",
 		},
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "stat a specific version of an object",
+		},
 	}
 )
 
@@ -65,6 +70,9 @@ EXAMPLES:
 
    4. Stat encrypted files on Amazon S3 cloud storage.
       $ {{.HelpName}} --encrypt-key "s3/personal-docs/=32byteslongsecretkeymustbegiven1" s3/personal-docs/2018-account_report.docx
+
+   5. Stat a specific version of an object.
+      $ {{.HelpName}} --version-id "3/L4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nr8X8gdRQBpUMLUo" s3/mybucket/myobject.txt
 `,
 }
 
@@ -80,6 +88,13 @@ func checkStatSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 			fatalIf(errInvalidArgument().Trace(args...), "Unable to validate empty argument.")
 		}
 	}
+	if ctx.String("version-id") != "" {
+		if len(args) != 1 || ctx.Bool("recursive") {
+			fatalIf(errInvalidArgument().Trace(args...), "--version-id requires exactly one object and cannot be used with --recursive.")
+		}
+		return
+	}
+
 	// extract URLs.
 	URLs := ctx.Args()
 	isIncomplete := false
@@ -112,6 +127,7 @@ func mainStat(ctx *cli.Context) error {
 
 	// Set command flags from context.
 	isRecursive := ctx.Bool("recursive")
+	versionID := ctx.String("version-id")
 
 	args := ctx.Args()
 	// mimic operating system tool behavior.
@@ -121,12 +137,22 @@ func mainStat(ctx *cli.Context) error {
 
 	var cErr error
 	for _, targetURL := range args {
-		stats, err := statURL(targetURL, false, isRecursive, encKeyDB)
-		if err != nil {
+		var stats []*clientContent
+		if versionID != "" {
+			stat, err := statURLVersion(targetURL, versionID, encKeyDB)
 			fatalIf(err, "Unable to stat `"+targetURL+"`.")
+			stats = append(stats, stat)
+		} else {
+			var err *probe.Error
+			stats, err = statURL(targetURL, false, isRecursive, encKeyDB)
+			if err != nil {
+				fatalIf(err, "Unable to stat `"+targetURL+"`.")
+			}
 		}
+		defaultEncryption := statDefaultEncryption(targetURL)
 		for _, stat := range stats {
 			st := parseStat(stat)
+			st.DefaultEncryption = defaultEncryption
 			if !globalJSON {
 				printStat(st)
 			} else {
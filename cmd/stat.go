@@ -37,7 +37,9 @@ type statMessage struct {
 	ETag              string            `json:"etag"`
 	Type              string            `json:"type"`
 	Expires           time.Time         `json:"expires"`
+	VersionID         string            `json:"versionId,omitempty"`
 	EncryptionHeaders map[string]string `json:"encryption,omitempty"`
+	DefaultEncryption string            `json:"defaultEncryption,omitempty"`
 	Metadata          map[string]string `json:"metadata"`
 }
 
@@ -52,6 +54,9 @@ func printStat(stat statMessage) {
 		console.Println(fmt.Sprintf("%-10s: %s ", "ETag", stat.ETag))
 	}
 	console.Println(fmt.Sprintf("%-10s: %s ", "Type", stat.Type))
+	if stat.VersionID != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "VersionID", stat.VersionID))
+	}
 	if !stat.Expires.IsZero() {
 		console.Println(fmt.Sprintf("%-10s: %s ", "Expires", stat.Expires.Format(printDate)))
 	}
@@ -79,6 +84,9 @@ func printStat(stat statMessage) {
 			console.Println(fmt.Sprintf("  %-*.*s: %s ", maxKey, maxKey, k, v))
 		}
 	}
+	if stat.DefaultEncryption != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "Bucket SSE", stat.DefaultEncryption))
+	}
 	console.Println()
 }
 
@@ -108,10 +116,42 @@ func parseStat(c *clientContent) statMessage {
 	content.ETag = strings.TrimPrefix(c.ETag, "\"")
 	content.ETag = strings.TrimSuffix(content.ETag, "\"")
 	content.Expires = c.Expires
+	content.VersionID = c.VersionID
 	content.EncryptionHeaders = c.EncryptionHeaders
 	return content
 }
 
+// statDefaultEncryption - printable default encryption of the bucket
+// of targetURL, empty when the bucket has none or it is unknown.
+func statDefaultEncryption(targetURL string) string {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return ""
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return ""
+	}
+	// Reading the default encryption may be denied or not supported,
+	// stat output does not depend on it.
+	algorithm, keyID, err := s3Clnt.GetBucketEncryption()
+	if err != nil || algorithm == "" {
+		return ""
+	}
+	return sseAlgorithmString(algorithm, keyID)
+}
+
+// statURLVersion - stat a specific version of an object.
+func statURLVersion(targetURL, versionID string, encKeyDB map[string][]prefixSSEPair) (*clientContent, *probe.Error) {
+	clnt, stat, err := url2StatVersion(targetURL, versionID, time.Time{}, encKeyDB)
+	if err != nil {
+		return nil, err
+	}
+	separator := string(clnt.GetURL().Separator)
+	stat.URL.Path = stat.URL.Path[strings.LastIndex(stat.URL.Path, separator)+1:]
+	return stat, nil
+}
+
 // statURL - simple or recursive listing
 func statURL(targetURL string, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair) ([]*clientContent, *probe.Error) {
 	var stats []*clientContent
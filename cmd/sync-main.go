@@ -0,0 +1,454 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Policies resolving paths changed on both sides.
+const (
+	syncNewerWins = "newer-wins"
+	syncKeepBoth  = "keep-both"
+	syncFail      = "fail"
+)
+
+var (
+	syncFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "conflict",
+			Usage: "resolve objects changed on both sides with newer-wins, keep-both or fail",
+			Value: syncFail,
+		},
+		cli.BoolFlag{
+			Name:  "fake",
+			Usage: "perform a fake sync operation",
+		},
+	}
+)
+
+// Keep two locations in sync.
+var syncCmd = cli.Command{
+	Name:   "sync",
+	Usage:  "synchronize object(s) between two locations in both directions",
+	Action: mainSync,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(syncFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FIRST SECOND
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Objects new or changed on one side are copied to the other side, and
+  objects removed on one side are removed on the other side. The state
+  of both sides is kept in the config folder, to tell an object removed
+  on one side from an object new on the other side. It is saved as the
+  sync goes on, an interrupted sync resumes where it stopped. Only one
+  sync of the same locations runs at a time.
+
+  Objects changed on both sides, or new on both sides with different
+  content, are conflicts resolved with --conflict:
+    newer-wins - the object modified last is copied to the other side.
+    keep-both  - the object of FIRST is kept on both sides, the object
+                 of SECOND is kept on both sides with a conflict suffix.
+    fail       - the object is left as it is on both sides and reported.
+
+  A change on one side always wins over a removal on the other side.
+
+EXAMPLES:
+  1. Synchronize a local folder with a folder on MinIO cloud storage.
+     $ {{.HelpName}} ~/reports play/field-team/reports
+
+  2. Synchronize two buckets, the object modified last wins a conflict.
+     $ {{.HelpName}} --conflict newer-wins s3/reports play/reports
+
+  3. Show what a sync would do, keeping both versions of conflicts.
+     $ {{.HelpName}} --fake --conflict keep-both ~/reports play/field-team/reports
+`,
+}
+
+// syncMessage container for sync messages.
+type syncMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// String colorized sync message.
+func (s syncMessage) String() string {
+	if s.Source == "" {
+		return console.Colorize("SyncRemove", fmt.Sprintf("Removed `%s`.", s.Target))
+	}
+	return console.Colorize("Sync", fmt.Sprintf("`%s` -> `%s`", s.Source, s.Target))
+}
+
+// JSON jsonified sync message.
+func (s syncMessage) JSON() string {
+	s.Status = "success"
+	syncMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(syncMessageBytes)
+}
+
+// syncLocation - one of the two locations kept in sync.
+type syncLocation struct {
+	alias string
+	url   string
+}
+
+// newSyncLocation - expand the aliased URL of a location, which is
+// always a directory.
+func newSyncLocation(urlStr string) syncLocation {
+	separator := string(newClientURL(urlStr).Separator)
+	if !strings.HasSuffix(urlStr, separator) {
+		urlStr = urlStr + separator
+	}
+	alias, urlStr, _ := mustExpandAlias(urlStr)
+	return syncLocation{alias: alias, url: urlStr}
+}
+
+// join - URL of a path relative to the location.
+func (l syncLocation) join(name string) string {
+	return urlJoinPath(l.url, name)
+}
+
+// aliasedPath - path relative to the location as shown to the user.
+func (l syncLocation) aliasedPath(name string) string {
+	return filepath.ToSlash(filepath.Join(l.alias, newClientURL(l.join(name)).Path))
+}
+
+// list - all objects of the location by their path relative to it.
+func (l syncLocation) list() (map[string]*clientContent, *probe.Error) {
+	clnt, err := newClientFromAlias(l.alias, l.url)
+	if err != nil {
+		return nil, err.Trace(l.alias, l.url)
+	}
+	contents := make(map[string]*clientContent)
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(l.url)
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		name := filepath.ToSlash(strings.TrimPrefix(content.URL.String(), l.url))
+		contents[name] = content
+	}
+	return contents, nil
+}
+
+// stat - the object at a path relative to the location.
+func (l syncLocation) stat(name string, encKeyDB map[string][]prefixSSEPair) (*clientContent, *probe.Error) {
+	clnt, err := newClientFromAlias(l.alias, l.join(name))
+	if err != nil {
+		return nil, err.Trace(l.alias, name)
+	}
+	return clnt.Stat(false, false, getSSE(l.aliasedPath(name), encKeyDB[l.alias]))
+}
+
+// syncJob - a sync of two locations.
+type syncJob struct {
+	first, second syncLocation
+	conflict      string
+	isFake        bool
+	encKeyDB      map[string][]prefixSSEPair
+	compare       contentComparator
+
+	// mu guards changes of state, which is saved while paths are synced.
+	mu    sync.Mutex
+	state *syncState
+}
+
+// copy - copy an object from one location to a path of the other.
+func (j *syncJob) copy(from, to syncLocation, content *clientContent, name string) *probe.Error {
+	fromName := filepath.ToSlash(strings.TrimPrefix(content.URL.String(), from.url))
+	printMsg(syncMessage{
+		Source: from.aliasedPath(fromName),
+		Target: to.aliasedPath(name),
+		Size:   content.Size,
+	})
+	if j.isFake {
+		return nil
+	}
+	urls := URLs{
+		SourceAlias:   from.alias,
+		SourceContent: content,
+		TargetAlias:   to.alias,
+		TargetContent: &clientContent{URL: *newClientURL(to.join(name))},
+	}
+	return uploadSourceToTargetURL(context.Background(), urls, nil, j.encKeyDB, false).Error
+}
+
+// remove - remove an object of a location.
+func (j *syncJob) remove(from syncLocation, content *clientContent, name string) *probe.Error {
+	printMsg(syncMessage{Target: from.aliasedPath(name)})
+	if j.isFake {
+		return nil
+	}
+	clnt, err := newClientFromAlias(from.alias, from.url)
+	if err != nil {
+		return err.Trace(from.alias, from.url)
+	}
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- content
+	close(contentCh)
+	for err := range clnt.Remove(false, false, contentCh) {
+		if err != nil {
+			return err.Trace(from.aliasedPath(name))
+		}
+	}
+	return nil
+}
+
+// record - record a path as in sync, as it is now on both sides.
+func (j *syncJob) record(name string) *probe.Error {
+	if j.isFake {
+		return nil
+	}
+	first, err := j.first.stat(name, j.encKeyDB)
+	if err != nil {
+		return err.Trace(name)
+	}
+	second, err := j.second.stat(name, j.encKeyDB)
+	if err != nil {
+		return err.Trace(name)
+	}
+	j.mu.Lock()
+	j.state.Entries[name] = syncEntry{First: newSyncSide(first), Second: newSyncSide(second)}
+	j.mu.Unlock()
+	return nil
+}
+
+// forget - forget the state of a path removed on both sides.
+func (j *syncJob) forget(name string) {
+	j.mu.Lock()
+	delete(j.state.Entries, name)
+	j.mu.Unlock()
+}
+
+// saveState - save the state as synced so far.
+func (j *syncJob) saveState() *probe.Error {
+	if j.isFake {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state.save()
+}
+
+// conflictName - name an object of SECOND is kept under by keep-both.
+func conflictName(name string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + ".sync-conflict-" + UTCNow().Format("20060102-150405") + ext
+}
+
+// resolve - resolve a conflict of a path by the conflict policy.
+func (j *syncJob) resolve(name string, first, second *clientContent) *probe.Error {
+	if first.Size == second.Size {
+		same, err := j.compare(first, second)
+		if err != nil {
+			return err.Trace(name)
+		}
+		if same {
+			return j.record(name)
+		}
+	}
+	switch j.conflict {
+	case syncNewerWins:
+		if first.Time.After(second.Time) {
+			return j.copy(j.first, j.second, first, name)
+		}
+		return j.copy(j.second, j.first, second, name)
+	case syncKeepBoth:
+		kept := conflictName(name)
+		if err := j.copy(j.second, j.first, second, kept); err != nil {
+			return err
+		}
+		if err := j.copy(j.second, j.second, second, kept); err != nil {
+			return err
+		}
+		if err := j.record(kept); err != nil {
+			return err
+		}
+		return j.copy(j.first, j.second, first, name)
+	}
+	return probe.NewError(SyncConflict{
+		First:  j.first.aliasedPath(name),
+		Second: j.second.aliasedPath(name),
+	})
+}
+
+// syncPath - converge a path on both sides.
+func (j *syncJob) syncPath(name string, first, second *clientContent) *probe.Error {
+	var entry *syncEntry
+	if e, ok := j.state.Entries[name]; ok {
+		entry = &e
+	}
+	var err *probe.Error
+	switch syncDecide(first, second, entry) {
+	case syncNone:
+		return nil
+	case syncForget:
+		j.forget(name)
+		return nil
+	case syncCopyToSecond:
+		err = j.copy(j.first, j.second, first, name)
+	case syncCopyToFirst:
+		err = j.copy(j.second, j.first, second, name)
+	case syncRemoveFirst:
+		if err = j.remove(j.first, first, name); err == nil && !j.isFake {
+			j.forget(name)
+		}
+		return err
+	case syncRemoveSecond:
+		if err = j.remove(j.second, second, name); err == nil && !j.isFake {
+			j.forget(name)
+		}
+		return err
+	case syncConflict:
+		err = j.resolve(name, first, second)
+	}
+	if err != nil {
+		return err
+	}
+	return j.record(name)
+}
+
+// run - sync both locations, paths are synced in sorted order.
+func (j *syncJob) run() error {
+	firstContents, err := j.first.list()
+	fatalIf(err, "Unable to list `"+j.first.url+"`.")
+	secondContents, err := j.second.list()
+	fatalIf(err, "Unable to list `"+j.second.url+"`.")
+
+	names := make(map[string]bool)
+	for _, contents := range []map[string]*clientContent{firstContents, secondContents} {
+		for name := range contents {
+			names[name] = true
+		}
+	}
+	for name := range j.state.Entries {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	// The state is saved as paths are synced, and when interrupted,
+	// paths synced so far are not synced again.
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	doneCh := make(chan error, 1)
+	go func() {
+		var retErr error
+		for i, name := range sorted {
+			if err := j.syncPath(name, firstContents[name], secondContents[name]); err != nil {
+				errorIf(err, "Unable to sync `"+name+"`.")
+				retErr = exitStatus(globalErrorExitStatus)
+			}
+			if (i+1)%syncStateSaveInterval == 0 {
+				errorIf(j.saveState(), "Unable to save sync state.")
+			}
+		}
+		doneCh <- retErr
+	}()
+
+	select {
+	case retErr := <-doneCh:
+		fatalIf(j.saveState(), "Unable to save sync state.")
+		return retErr
+	case <-trapCh:
+		fatalIf(j.saveState(), "Unable to save sync state.")
+		return exitStatus(globalErrorExitStatus)
+	}
+}
+
+// checkSyncSyntax - validate all the passed arguments
+func checkSyncSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "sync", 1) // last argument is exit code
+	}
+	switch ctx.String("conflict") {
+	case syncNewerWins, syncKeepBoth, syncFail:
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("conflict")),
+			"Unknown conflict policy `"+ctx.String("conflict")+"`, use newer-wins, keep-both or fail.")
+	}
+	for _, urlStr := range ctx.Args() {
+		if strings.TrimSpace(urlStr) == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Unable to validate empty argument.")
+		}
+		_, content, err := url2Stat(urlStr, false, encKeyDB)
+		fatalIf(err.Trace(urlStr), "Unable to stat `"+urlStr+"`.")
+		if !content.Type.IsDir() {
+			fatalIf(errInvalidArgument().Trace(urlStr), "`"+urlStr+"` is not a folder.")
+		}
+	}
+}
+
+// mainSync is the entry point for sync command.
+func mainSync(ctx *cli.Context) error {
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	// check 'sync' cli arguments.
+	checkSyncSyntax(ctx, encKeyDB)
+
+	// Additional command specific theme customization.
+	console.SetColor("Sync", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SyncRemove", color.New(color.FgRed, color.Bold))
+
+	first := newSyncLocation(ctx.Args().Get(0))
+	second := newSyncLocation(ctx.Args().Get(1))
+	stateLock, err := lockSyncState(first.url, second.url)
+	fatalIf(err, "Unable to lock sync state.")
+	defer stateLock.Close()
+	state, err := loadSyncState(first.url, second.url)
+	fatalIf(err, "Unable to load sync state.")
+
+	j := &syncJob{
+		first:    first,
+		second:   second,
+		conflict: ctx.String("conflict"),
+		isFake:   ctx.Bool("fake"),
+		encKeyDB: encKeyDB,
+		state:    state,
+		compare:  newChecksumComparator(first.alias, second.alias, encKeyDB),
+	}
+	return j.run()
+}
@@ -0,0 +1,204 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/lock"
+	"github.com/minio/minio/pkg/quick"
+)
+
+const syncStateVersion = "1"
+
+// syncStateSaveInterval - number of synced paths after which the state
+// is saved, so that an aborted sync does not start over.
+const syncStateSaveInterval = 100
+
+// syncSide - a path on one side as seen at the last sync.
+type syncSide struct {
+	Size int64     `json:"size"`
+	ETag string    `json:"etag,omitempty"`
+	Time time.Time `json:"lastModified"`
+}
+
+// newSyncSide - record the content of a path on one side.
+func newSyncSide(content *clientContent) syncSide {
+	return syncSide{
+		Size: content.Size,
+		ETag: strings.Trim(content.ETag, "\""),
+		Time: content.Time,
+	}
+}
+
+// matches - tells whether the content is unchanged since the last
+// sync. Objects are compared by ETag, since listings and stat report
+// their modification time with a different precision, and files by
+// modification time.
+func (s syncSide) matches(content *clientContent) bool {
+	if s.Size != content.Size {
+		return false
+	}
+	if etag := strings.Trim(content.ETag, "\""); s.ETag != "" || etag != "" {
+		return s.ETag == etag
+	}
+	return s.Time.Equal(content.Time)
+}
+
+// syncEntry - a path on both sides as seen at the last sync.
+type syncEntry struct {
+	First  syncSide `json:"first"`
+	Second syncSide `json:"second"`
+}
+
+// syncState - paths of two locations as seen when they were last in
+// sync, keyed by their path relative to the locations.
+type syncState struct {
+	Version string               `json:"version"`
+	First   string               `json:"first"`
+	Second  string               `json:"second"`
+	Entries map[string]syncEntry `json:"entries"`
+}
+
+// getSyncStateFile - state file of the sync between two expanded URLs.
+func getSyncStateFile(firstURL, secondURL string) (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	sum := sha256.Sum256([]byte(firstURL + "\n" + secondURL))
+	return filepath.Join(configDir, globalSyncDir, hex.EncodeToString(sum[:16])+".json"), nil
+}
+
+// lockSyncState - lock the state of the sync between two expanded URLs
+// against concurrent syncs. The lock is held until the returned file is
+// closed or mc exits.
+func lockSyncState(firstURL, secondURL string) (*lock.LockedFile, *probe.Error) {
+	stateFile, err := getSyncStateFile(firstURL, secondURL)
+	if err != nil {
+		return nil, err.Trace(firstURL, secondURL)
+	}
+	if e := os.MkdirAll(filepath.Dir(stateFile), 0700); e != nil {
+		return nil, probe.NewError(e)
+	}
+	lockFile, e := lock.TryLockedOpenFile(stateFile+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if e == lock.ErrAlreadyLocked {
+		return nil, errSyncInProgress(firstURL, secondURL)
+	}
+	if e != nil {
+		return nil, probe.NewError(e).Trace(stateFile)
+	}
+	return lockFile, nil
+}
+
+// loadSyncState - load the state of the last sync between two
+// expanded URLs, the state is empty if they were never synced.
+func loadSyncState(firstURL, secondURL string) (*syncState, *probe.Error) {
+	state := &syncState{
+		Version: syncStateVersion,
+		First:   firstURL,
+		Second:  secondURL,
+		Entries: make(map[string]syncEntry),
+	}
+	stateFile, err := getSyncStateFile(firstURL, secondURL)
+	if err != nil {
+		return nil, err.Trace(firstURL, secondURL)
+	}
+	if _, e := os.Stat(stateFile); os.IsNotExist(e) {
+		return state, nil
+	}
+	qs, e := quick.NewConfig(state, nil)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(stateFile)
+	}
+	if e = qs.Load(stateFile); e != nil {
+		return nil, probe.NewError(e).Trace(stateFile)
+	}
+	if state.Entries == nil {
+		state.Entries = make(map[string]syncEntry)
+	}
+	return state, nil
+}
+
+// save - save the state to the config folder.
+func (s *syncState) save() *probe.Error {
+	stateFile, err := getSyncStateFile(s.First, s.Second)
+	if err != nil {
+		return err.Trace(s.First, s.Second)
+	}
+	if e := os.MkdirAll(filepath.Dir(stateFile), 0700); e != nil {
+		return probe.NewError(e)
+	}
+	qs, e := quick.NewConfig(s, nil)
+	if e != nil {
+		return probe.NewError(e).Trace(stateFile)
+	}
+	if e = qs.Save(stateFile); e != nil {
+		return probe.NewError(e).Trace(stateFile)
+	}
+	return nil
+}
+
+// syncAction - what converges a path on both sides.
+type syncAction int
+
+const (
+	syncNone         syncAction = iota // in sync
+	syncForget                         // removed on both sides
+	syncCopyToSecond                   // new or changed on first
+	syncCopyToFirst                    // new or changed on second
+	syncRemoveFirst                    // removed on second
+	syncRemoveSecond                   // removed on first
+	syncConflict                       // new or changed on both sides
+)
+
+// syncDecide - action which converges a path, given its contents on
+// both sides and its state at the last sync. Missing contents are nil,
+// and entry is nil if the path was never synced. A change on one side
+// wins over a removal on the other side.
+func syncDecide(first, second *clientContent, entry *syncEntry) syncAction {
+	switch {
+	case first == nil && second == nil:
+		return syncForget
+	case entry == nil && second == nil:
+		return syncCopyToSecond
+	case entry == nil && first == nil:
+		return syncCopyToFirst
+	case entry == nil:
+		return syncConflict
+	}
+	firstChanged := first == nil || !entry.First.matches(first)
+	secondChanged := second == nil || !entry.Second.matches(second)
+	switch {
+	case !firstChanged && !secondChanged:
+		return syncNone
+	case first == nil && !secondChanged:
+		return syncRemoveSecond
+	case second == nil && !firstChanged:
+		return syncRemoveFirst
+	case first == nil, !firstChanged:
+		return syncCopyToFirst
+	case second == nil, !secondChanged:
+		return syncCopyToSecond
+	}
+	return syncConflict
+}
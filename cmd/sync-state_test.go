@@ -0,0 +1,87 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSyncDecide(t *testing.T) {
+	now := time.Now()
+	file := &clientContent{Size: 10, Time: now}
+	object := &clientContent{Size: 10, Time: now, ETag: "\"5d41402abc4b2a76b9719d911017c592\""}
+	changedFile := &clientContent{Size: 10, Time: now.Add(time.Second)}
+	changedObject := &clientContent{Size: 10, Time: now, ETag: "7d793037a0760186574b0282f2f435e7"}
+	entry := &syncEntry{First: newSyncSide(file), Second: newSyncSide(object)}
+
+	testCases := []struct {
+		first, second *clientContent
+		entry         *syncEntry
+		expected      syncAction
+	}{
+		{nil, nil, entry, syncForget},
+		{file, nil, nil, syncCopyToSecond},
+		{nil, object, nil, syncCopyToFirst},
+		{file, object, nil, syncConflict},
+		{file, object, entry, syncNone},
+		{changedFile, object, entry, syncCopyToSecond},
+		{file, changedObject, entry, syncCopyToFirst},
+		{changedFile, changedObject, entry, syncConflict},
+		{nil, object, entry, syncRemoveSecond},
+		{file, nil, entry, syncRemoveFirst},
+		{nil, changedObject, entry, syncCopyToFirst},
+		{changedFile, nil, entry, syncCopyToSecond},
+	}
+	for i, testCase := range testCases {
+		action := syncDecide(testCase.first, testCase.second, testCase.entry)
+		if action != testCase.expected {
+			t.Errorf("Test %d: Expected action %d, got %d", i+1, testCase.expected, action)
+		}
+	}
+}
+
+func TestLockSyncState(t *testing.T) {
+	configDir, e := ioutil.TempDir(os.TempDir(), "mc-sync-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(configDir)
+	defer func(dir string) { mcCustomConfigDir = dir }(mcCustomConfigDir)
+	mcCustomConfigDir = configDir
+
+	stateLock, err := lockSyncState("/first", "/second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = lockSyncState("/first", "/second"); err == nil {
+		t.Fatal("Expected the state to be locked by the first sync")
+	}
+	// Other locations are synced independently.
+	otherLock, err := lockSyncState("/first", "/third")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherLock.Close()
+
+	stateLock.Close()
+	if stateLock, err = lockSyncState("/first", "/second"); err != nil {
+		t.Fatal(err)
+	}
+	stateLock.Close()
+}
@@ -0,0 +1,111 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	tagGetFlags = []cli.Flag{}
+)
+
+var tagGetCmd = cli.Command{
+	Name:   "get",
+	Usage:  "show tags of an object",
+	Action: mainTagGet,
+	Before: setGlobalsFromContext,
+	Flags:  append(tagGetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Show tags of an object
+     $ {{.HelpName}} s3/mybucket/myobject.txt
+
+`,
+}
+
+// checkTagGetSyntax - validate all the passed arguments
+func checkTagGetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "get", 1) // last argument is exit code
+	}
+}
+
+// tagGetMessage container
+type tagGetMessage struct {
+	Status string            `json:"status"`
+	URL    string            `json:"url"`
+	Tags   map[string]string `json:"tags"`
+}
+
+// JSON jsonified tag get message.
+func (t tagGetMessage) JSON() string {
+	t.Status = "success"
+	tagGetMessageJSONBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(tagGetMessageJSONBytes)
+}
+
+func (t tagGetMessage) String() string {
+	if len(t.Tags) == 0 {
+		return console.Colorize("URL", t.URL+": ") + "No tags found."
+	}
+	keys := make([]string, 0, len(t.Tags))
+	for k := range t.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := []string{console.Colorize("URL", t.URL+":")}
+	for _, k := range keys {
+		lines = append(lines, "  "+console.Colorize("Key", k)+" : "+t.Tags[k])
+	}
+	return strings.Join(lines, "\n")
+}
+
+func mainTagGet(ctx *cli.Context) error {
+	console.SetColor("URL", color.New(color.Bold))
+	console.SetColor("Key", color.New(color.FgCyan, color.Bold))
+
+	checkTagGetSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	tags, err := client.GetTags("")
+	fatalIf(err.Trace(urlStr), "Cannot get tags of the specified object.")
+	printMsg(tagGetMessage{
+		URL:  urlStr,
+		Tags: tags,
+	})
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	tagFlags = []cli.Flag{}
+)
+
+var tagCmd = cli.Command{
+	Name:            "tag",
+	Usage:           "manage tags of objects",
+	HideHelpCommand: true,
+	Action:          mainTag,
+	Before:          setGlobalsFromContext,
+	Flags:           append(tagFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		tagSetCmd,
+		tagGetCmd,
+		tagRemoveCmd,
+	},
+}
+
+// mainTag is the handle for "mc tag" command.
+func mainTag(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "get", "remove" have their own main.
+}
+
+// Object tag limits imposed by S3.
+const (
+	maxTagCount       = 10
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// parseTags parses tags of the form key1=value1&key2=value2, an
+// empty string yields no tags.
+func parseTags(tags string) (map[string]string, *probe.Error) {
+	tagMap := make(map[string]string)
+	if tags == "" {
+		return tagMap, nil
+	}
+	values, e := url.ParseQuery(tags)
+	if e != nil {
+		return nil, errInvalidTags(tags, e.Error())
+	}
+	if len(values) > maxTagCount {
+		return nil, errInvalidTags(tags, fmt.Sprintf("at most %d tags are allowed", maxTagCount))
+	}
+	for k, v := range values {
+		switch {
+		case k == "":
+			return nil, errInvalidTags(tags, "empty tag key")
+		case len(v) > 1:
+			return nil, errInvalidTags(tags, "duplicate tag key `"+k+"`")
+		case utf8.RuneCountInString(k) > maxTagKeyLength:
+			return nil, errInvalidTags(tags, fmt.Sprintf("tag key `%s` is longer than %d characters", k, maxTagKeyLength))
+		case utf8.RuneCountInString(v[0]) > maxTagValueLength:
+			return nil, errInvalidTags(tags, fmt.Sprintf("tag value of `%s` is longer than %d characters", k, maxTagValueLength))
+		}
+		tagMap[k] = v[0]
+	}
+	return tagMap, nil
+}
+
+// tagsToString is the inverse of parseTags, keys are sorted.
+func tagsToString(tags map[string]string) string {
+	values := url.Values{}
+	for k, v := range tags {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
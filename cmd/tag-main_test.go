@@ -0,0 +1,56 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	testCases := []struct {
+		tags     string
+		expected map[string]string
+		success  bool
+	}{
+		{tags: "", expected: map[string]string{}, success: true},
+		{tags: "project=mc", expected: map[string]string{"project": "mc"}, success: true},
+		{tags: "project=mc&cost-center=eng%20ops", expected: map[string]string{"project": "mc", "cost-center": "eng ops"}, success: true},
+		{tags: "empty=", expected: map[string]string{"empty": ""}, success: true},
+		{tags: "=value", success: false},
+		{tags: "k=1&k=2", success: false},
+		{tags: "a=1&b=2&c=3&d=4&e=5&f=6&g=7&h=8&i=9&j=10&k=11", success: false},
+		{tags: "k=" + strings.Repeat("v", 257), success: false},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := parseTags(testCase.tags)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: Expected success, got %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: Expected error, got success", i+1)
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(tags, testCase.expected) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expected, tags)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	tagRemoveFlags = []cli.Flag{}
+)
+
+var tagRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove all tags of an object",
+	Action: mainTagRemove,
+	Before: setGlobalsFromContext,
+	Flags:  append(tagRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Remove all tags of an object
+     $ {{.HelpName}} s3/mybucket/myobject.txt
+
+`,
+}
+
+// checkTagRemoveSyntax - validate all the passed arguments
+func checkTagRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+}
+
+// tagRemoveMessage container
+type tagRemoveMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+// JSON jsonified tag remove message.
+func (t tagRemoveMessage) JSON() string {
+	t.Status = "success"
+	tagRemoveMessageJSONBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(tagRemoveMessageJSONBytes)
+}
+
+func (t tagRemoveMessage) String() string {
+	return console.Colorize("Tag", "Successfully removed tags of `"+t.URL+"`.")
+}
+
+func mainTagRemove(ctx *cli.Context) error {
+	console.SetColor("Tag", color.New(color.FgGreen, color.Bold))
+
+	checkTagRemoveSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	err = client.DeleteTags()
+	fatalIf(err.Trace(urlStr), "Cannot remove tags of the specified object.")
+	printMsg(tagRemoveMessage{URL: urlStr})
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	tagSetFlags = []cli.Flag{}
+)
+
+var tagSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set tags of an object, replacing existing tags",
+	Action: mainTagSet,
+	Before: setGlobalsFromContext,
+	Flags:  append(tagSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET TAGS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Set tags of an object
+     $ {{.HelpName}} s3/mybucket/myobject.txt "project=mc&cost-center=eng"
+
+`,
+}
+
+// checkTagSetSyntax - validate all the passed arguments
+func checkTagSetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+}
+
+// tagSetMessage container
+type tagSetMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+// JSON jsonified tag set message.
+func (t tagSetMessage) JSON() string {
+	t.Status = "success"
+	tagSetMessageJSONBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(tagSetMessageJSONBytes)
+}
+
+func (t tagSetMessage) String() string {
+	return console.Colorize("Tag", "Successfully set tags on `"+t.URL+"`.")
+}
+
+func mainTagSet(ctx *cli.Context) error {
+	console.SetColor("Tag", color.New(color.FgGreen, color.Bold))
+
+	checkTagSetSyntax(ctx)
+
+	urlStr := ctx.Args().Get(0)
+	tags, err := parseTags(ctx.Args().Get(1))
+	fatalIf(err, "Unable to parse tags.")
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Cannot parse the provided url.")
+
+	err = client.SetTags(tags)
+	fatalIf(err.Trace(urlStr), "Cannot set tags on the specified object.")
+	printMsg(tagSetMessage{URL: urlStr})
+	return nil
+}
@@ -0,0 +1,325 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Outcomes of the objects of a transfer report.
+const (
+	reportCopied  = "copied"
+	reportSkipped = "skipped"
+	reportRemoved = "removed"
+	reportFailed  = "failed"
+)
+
+// reportObject - the outcome of an object processed by a command.
+type reportObject struct {
+	Status string `json:"status"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+	Size   int64  `json:"size"`
+	// Duration in seconds, throughput in bytes per second.
+	Duration   float64 `json:"duration"`
+	Throughput float64 `json:"throughput"`
+	Reason     string  `json:"reason,omitempty"`
+}
+
+// reportTotals - the totals of a transfer report, Bytes is the size
+// of the copied objects.
+type reportTotals struct {
+	Objects    int64   `json:"objects"`
+	Copied     int64   `json:"copied"`
+	Skipped    int64   `json:"skipped"`
+	Removed    int64   `json:"removed"`
+	Failed     int64   `json:"failed"`
+	Bytes      int64   `json:"bytes"`
+	Duration   float64 `json:"duration"`
+	Throughput float64 `json:"throughput"`
+}
+
+// reportFailure - an object which failed, as listed at the end of a
+// transfer report.
+type reportFailure struct {
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+	Error  string `json:"error"`
+}
+
+// transferReport - a file recording the outcome of every object
+// processed by cp, mirror or rm. It is written as CSV if its name ends
+// with .csv, as JSON otherwise. Objects are written as they are
+// processed, totals and failed objects when the report is closed.
+type transferReport struct {
+	mutex sync.Mutex
+
+	path  string
+	file  *os.File
+	buf   *bufio.Writer
+	csv   *csv.Writer
+	err   error
+	start time.Time
+
+	totals reportTotals
+	failed []reportFailure
+}
+
+// csvReportHeader - columns of a CSV transfer report. Its last row
+// holds the totals, with the status "total" and the number of objects
+// of every outcome as the reason.
+var csvReportHeader = []string{"status", "source", "target", "size", "duration", "throughput", "reason"}
+
+// newTransferReport - create the report file of a command, no report
+// is written if path is empty. With isAppend the report is added to
+// the one of an earlier run, such as the run of a session which was
+// interrupted: a JSON report is appended as a JSON document of its
+// own, a CSV report as more rows.
+func newTransferReport(path, command string, args []string, isAppend bool) (*transferReport, *probe.Error) {
+	if path == "" {
+		return nil, nil
+	}
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if isAppend {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, e := os.OpenFile(path, flag, 0666)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	st, e := f.Stat()
+	if e != nil {
+		f.Close()
+		return nil, probe.NewError(e).Trace(path)
+	}
+	r := &transferReport{
+		path:  path,
+		file:  f,
+		buf:   bufio.NewWriter(f),
+		start: UTCNow(),
+	}
+	if isCSVReport(path) {
+		r.csv = csv.NewWriter(r.buf)
+		if st.Size() == 0 {
+			r.err = r.csv.Write(csvReportHeader)
+		}
+		return r, nil
+	}
+	r.err = r.writeJSONHeader(command, args)
+	return r, nil
+}
+
+// writeJSONField - write a field of a JSON report after prefix.
+func writeJSONField(w io.Writer, prefix, name string, value interface{}) error {
+	valueBytes, e := json.Marshal(value)
+	if e != nil {
+		return e
+	}
+	_, e = fmt.Fprintf(w, "%s %q: %s", prefix, name, valueBytes)
+	return e
+}
+
+// writeJSONHeader - begin a JSON report, up to its objects which are
+// added as they are recorded.
+func (r *transferReport) writeJSONHeader(command string, args []string) error {
+	if e := writeJSONField(r.buf, "{\n", "command", command); e != nil {
+		return e
+	}
+	if e := writeJSONField(r.buf, ",\n", "args", args); e != nil {
+		return e
+	}
+	if e := writeJSONField(r.buf, ",\n", "start", r.start); e != nil {
+		return e
+	}
+	_, e := io.WriteString(r.buf, ",\n \"objects\": [")
+	return e
+}
+
+// isCSVReport - returns true if the report at path is written as CSV.
+func isCSVReport(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// reportPath - the user facing path of an object of URLs.
+func reportPath(alias string, content *clientContent) string {
+	if content == nil {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+}
+
+// recordURLs - record the outcome of the copy or the removal of URLs,
+// the reason of a failure defaults to its error.
+func (r *transferReport) recordURLs(sURLs URLs, status, reason string) {
+	if r == nil {
+		return
+	}
+	if reason == "" && sURLs.Error != nil {
+		reason = sURLs.Error.ToGoError().Error()
+	}
+	object := reportObject{
+		Status:   status,
+		Source:   reportPath(sURLs.SourceAlias, sURLs.SourceContent),
+		Target:   reportPath(sURLs.TargetAlias, sURLs.TargetContent),
+		Duration: sURLs.duration.Seconds(),
+		Reason:   reason,
+	}
+	switch {
+	case sURLs.SourceContent != nil:
+		object.Size = sURLs.SourceContent.Size
+	case sURLs.TargetContent != nil:
+		object.Size = sURLs.TargetContent.Size
+	}
+	r.record(object)
+}
+
+// record - record the outcome of an object.
+func (r *transferReport) record(object reportObject) {
+	if r == nil {
+		return
+	}
+	if object.Status == reportCopied && object.Duration > 0 {
+		object.Throughput = float64(object.Size) / object.Duration
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch object.Status {
+	case reportCopied:
+		r.totals.Copied++
+		r.totals.Bytes += object.Size
+	case reportSkipped:
+		r.totals.Skipped++
+	case reportRemoved:
+		r.totals.Removed++
+	case reportFailed:
+		r.totals.Failed++
+		r.failed = append(r.failed, reportFailure{Source: object.Source, Target: object.Target, Error: object.Reason})
+	}
+	r.totals.Objects++
+
+	if r.err != nil {
+		return
+	}
+	if r.csv != nil {
+		r.err = r.csv.Write([]string{
+			object.Status,
+			object.Source,
+			object.Target,
+			strconv.FormatInt(object.Size, 10),
+			strconv.FormatFloat(object.Duration, 'f', 3, 64),
+			strconv.FormatFloat(object.Throughput, 'f', 0, 64),
+			object.Reason,
+		})
+		return
+	}
+	objectBytes, e := json.Marshal(object)
+	if e != nil {
+		r.err = e
+		return
+	}
+	separator := ","
+	if r.totals.Objects == 1 {
+		separator = ""
+	}
+	_, r.err = fmt.Fprintf(r.buf, "%s\n  %s", separator, objectBytes)
+}
+
+// close - write the totals and the failed objects, and close the
+// report file.
+func (r *transferReport) close() *probe.Error {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	r.totals.Duration = time.Since(r.start).Seconds()
+	if r.totals.Duration > 0 {
+		r.totals.Throughput = float64(r.totals.Bytes) / r.totals.Duration
+	}
+
+	if r.err == nil {
+		if r.csv != nil {
+			r.err = r.csv.Write([]string{
+				"total", "", "",
+				strconv.FormatInt(r.totals.Bytes, 10),
+				strconv.FormatFloat(r.totals.Duration, 'f', 3, 64),
+				strconv.FormatFloat(r.totals.Throughput, 'f', 0, 64),
+				fmt.Sprintf("copied=%d skipped=%d removed=%d failed=%d",
+					r.totals.Copied, r.totals.Skipped, r.totals.Removed, r.totals.Failed),
+			})
+			r.csv.Flush()
+			if r.err == nil {
+				r.err = r.csv.Error()
+			}
+		} else {
+			r.err = r.writeJSONTrailer()
+		}
+	}
+	if r.err == nil {
+		r.err = r.buf.Flush()
+	}
+	if e := r.file.Close(); r.err == nil {
+		r.err = e
+	}
+	r.file = nil
+	if r.err != nil {
+		return probe.NewError(r.err).Trace(r.path)
+	}
+	return nil
+}
+
+// writeJSONTrailer - end the objects of a JSON report, followed by the
+// totals and the failed objects.
+func (r *transferReport) writeJSONTrailer() error {
+	failed := r.failed
+	if failed == nil {
+		failed = []reportFailure{}
+	}
+	if _, e := io.WriteString(r.buf, "\n ]"); e != nil {
+		return e
+	}
+	if e := writeJSONField(r.buf, ",\n", "end", UTCNow()); e != nil {
+		return e
+	}
+	if e := writeJSONField(r.buf, ",\n", "totals", r.totals); e != nil {
+		return e
+	}
+	if e := writeJSONField(r.buf, ",\n", "failed", failed); e != nil {
+		return e
+	}
+	_, e := io.WriteString(r.buf, "\n}\n")
+	return e
+}
@@ -0,0 +1,188 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTransferReport(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-report-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	objects := []reportObject{
+		{Status: reportCopied, Source: "src/a", Target: "dst/a", Size: 100, Duration: 2},
+		{Status: reportSkipped, Source: "src/b", Target: "dst/b", Size: 10, Reason: "unchanged"},
+		{Status: reportFailed, Source: "src/c", Target: "dst/c", Size: 20, Reason: "Access Denied."},
+		{Status: reportRemoved, Target: "dst/d", Size: 30},
+	}
+
+	jsonPath := filepath.Join(dir, "report.json")
+	report, err := newTransferReport(jsonPath, "mirror", []string{"src", "dst"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range objects {
+		report.record(object)
+	}
+	if err = report.close(); err != nil {
+		t.Fatal(err)
+	}
+	data, e := ioutil.ReadFile(jsonPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var jsonReport struct {
+		Command string
+		Objects []reportObject
+		Totals  reportTotals
+		Failed  []reportFailure
+	}
+	if e = json.Unmarshal(data, &jsonReport); e != nil {
+		t.Fatalf("Unable to parse report: %v\n%s", e, data)
+	}
+	if jsonReport.Command != "mirror" || len(jsonReport.Objects) != len(objects) {
+		t.Fatalf("Unexpected report %s", data)
+	}
+	if jsonReport.Objects[0].Throughput != 50 {
+		t.Errorf("Expected a throughput of 50, got %v", jsonReport.Objects[0].Throughput)
+	}
+	totals := jsonReport.Totals
+	if totals.Objects != 4 || totals.Copied != 1 || totals.Skipped != 1 || totals.Failed != 1 || totals.Removed != 1 || totals.Bytes != 100 {
+		t.Errorf("Unexpected totals %+v", totals)
+	}
+	expectedFailed := []reportFailure{{Source: "src/c", Target: "dst/c", Error: "Access Denied."}}
+	if !reflect.DeepEqual(jsonReport.Failed, expectedFailed) {
+		t.Errorf("Expected failed objects %v, got %v", expectedFailed, jsonReport.Failed)
+	}
+
+	// An empty report is valid JSON as well.
+	report, err = newTransferReport(jsonPath, "cp", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = report.close(); err != nil {
+		t.Fatal(err)
+	}
+	if data, e = ioutil.ReadFile(jsonPath); e != nil {
+		t.Fatal(e)
+	}
+	if e = json.Unmarshal(data, &jsonReport); e != nil || len(jsonReport.Objects) != 0 {
+		t.Errorf("Unexpected empty report %s (%v)", data, e)
+	}
+
+	csvPath := filepath.Join(dir, "report.csv")
+	report, err = newTransferReport(csvPath, "rm", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range objects {
+		report.record(object)
+	}
+	if err = report.close(); err != nil {
+		t.Fatal(err)
+	}
+	f, e := os.Open(csvPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	rows, e := csv.NewReader(f).ReadAll()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(rows) != len(objects)+2 || !reflect.DeepEqual(rows[0], csvReportHeader) {
+		t.Fatalf("Unexpected CSV report %v", rows)
+	}
+	if total := rows[len(rows)-1]; total[0] != "total" || total[3] != "100" || total[6] != "copied=1 skipped=1 removed=1 failed=1" {
+		t.Errorf("Unexpected totals %v", total)
+	}
+}
+
+func TestTransferReportAppend(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-report-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	// A resumed session appends its report to the one of the
+	// interrupted run.
+	runs := [][]reportObject{
+		{{Status: reportCopied, Source: "src/a", Target: "dst/a", Size: 100, Duration: 2}},
+		{{Status: reportFailed, Source: "src/b", Target: "dst/b", Size: 10, Reason: "Access Denied."}},
+	}
+	for _, path := range []string{filepath.Join(dir, "report.json"), filepath.Join(dir, "report.csv")} {
+		for i, objects := range runs {
+			report, err := newTransferReport(path, "cp", []string{"src", "dst"}, i > 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, object := range objects {
+				report.record(object)
+			}
+			if err = report.close(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	f, e := os.Open(filepath.Join(dir, "report.json"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	decoder := json.NewDecoder(f)
+	for i, objects := range runs {
+		var jsonReport struct {
+			Objects []reportObject
+			Totals  reportTotals
+		}
+		if e = decoder.Decode(&jsonReport); e != nil {
+			t.Fatalf("Run %d: unable to parse report: %v", i+1, e)
+		}
+		if len(jsonReport.Objects) != 1 || jsonReport.Objects[0].Source != objects[0].Source || jsonReport.Totals.Objects != 1 {
+			t.Fatalf("Run %d: unexpected report %+v", i+1, jsonReport)
+		}
+	}
+	if decoder.More() {
+		t.Fatal("Expected one JSON report per run")
+	}
+
+	c, e := os.Open(filepath.Join(dir, "report.csv"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer c.Close()
+	rows, e := csv.NewReader(c).ReadAll()
+	if e != nil {
+		t.Fatal(e)
+	}
+	// The header is written once, every run ends with its totals.
+	if len(rows) != 5 || !reflect.DeepEqual(rows[0], csvReportHeader) || rows[3][1] != "src/b" || rows[4][0] != "total" {
+		t.Fatalf("Unexpected CSV report %v", rows)
+	}
+}
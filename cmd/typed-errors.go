@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 )
@@ -125,3 +126,94 @@ var errConflictSSE = func(sseServer, sseKeys string) *probe.Error {
 	err := fmt.Errorf("SSE alias '%s' overlaps with SSE-C aliases '%s'", sseServer, sseKeys)
 	return probe.NewError(conflictSSEErr(err)).Untrace()
 }
+
+type invalidTagsErr error
+
+var errInvalidTags = func(tags, reason string) *probe.Error {
+	msg := "Invalid tags `" + tags + "`, " + reason + ". Tags should be of the form key1=value1&key2=value2."
+	return probe.NewError(invalidTagsErr(errors.New(msg))).Untrace()
+}
+
+type invalidLifecycleRuleErr error
+
+var errInvalidLifecycleRule = func(id, reason string) *probe.Error {
+	msg := "Invalid lifecycle rule `" + id + "`, " + reason + "."
+	return probe.NewError(invalidLifecycleRuleErr(errors.New(msg))).Untrace()
+}
+
+type lifecycleRuleNotFoundErr error
+
+var errLifecycleRuleNotFound = func(id string) *probe.Error {
+	msg := "Lifecycle rule `" + id + "` not found."
+	return probe.NewError(lifecycleRuleNotFoundErr(errors.New(msg))).Untrace()
+}
+
+type invalidCSEKeyErr error
+
+var errInvalidCSEKey = func(keyFile string) *probe.Error {
+	msg := "Invalid client-side encryption key file `" + keyFile + "`. It should contain 32 bytes or 64 hex characters."
+	return probe.NewError(invalidCSEKeyErr(errors.New(msg))).Untrace()
+}
+
+type cseMixedTargetsErr error
+
+var errCSEMixedTargets = func() *probe.Error {
+	msg := "Client-side encryption cannot copy to filesystems and object storage at once."
+	return probe.NewError(cseMixedTargetsErr(errors.New(msg))).Untrace()
+}
+
+type invalidCompressionErr error
+
+var errInvalidCompression = func(algorithm string) *probe.Error {
+	msg := "Unsupported compression algorithm `" + algorithm + "`. Only `" + compressionGzip + "` and `" + compressionZstd + "` are supported."
+	return probe.NewError(invalidCompressionErr(errors.New(msg))).Untrace()
+}
+
+type targetStalledErr error
+
+var errTargetStalled = func(timeout time.Duration) *probe.Error {
+	msg := fmt.Sprintf("Target did not keep up with the source for %s, it is left behind.", timeout)
+	return probe.NewError(targetStalledErr(errors.New(msg))).Untrace()
+}
+
+type targetWatchStalledErr error
+
+var errTargetWatchStalled = func(timeout time.Duration) *probe.Error {
+	msg := fmt.Sprintf("Target did not keep up with the source for %s, its events are mirrored once it caught up.", timeout)
+	return probe.NewError(targetWatchStalledErr(errors.New(msg))).Untrace()
+}
+
+type invalidBandwidthErr error
+
+var errInvalidBandwidth = func(bandwidth string) *probe.Error {
+	msg := "Invalid bandwidth `" + bandwidth + "`. It should be a size per second, e.g. `10MiB/s`."
+	return probe.NewError(invalidBandwidthErr(errors.New(msg))).Untrace()
+}
+
+type invalidRetryErr error
+
+var errInvalidRetry = func(option string) *probe.Error {
+	msg := "Invalid retry option `" + option + "`. Attempts cannot be negative and backoff should be a positive duration, e.g. `2s`."
+	return probe.NewError(invalidRetryErr(errors.New(msg))).Untrace()
+}
+
+type deleteLimitExceededErr error
+
+var errDeleteLimitExceeded = func(removals, targetObjects int64) *probe.Error {
+	msg := fmt.Sprintf("%d of %d object(s) would be removed, which exceeds `--max-delete` or `--max-delete-percent`.", removals, targetObjects)
+	return probe.NewError(deleteLimitExceededErr(errors.New(msg))).Untrace()
+}
+
+type invalidCopyListErr error
+
+var errInvalidCopyList = func(path string, line int, reason string) *probe.Error {
+	msg := fmt.Sprintf("Invalid entry at line %d of list `%s`, %s.", line, path, reason)
+	return probe.NewError(invalidCopyListErr(errors.New(msg))).Untrace()
+}
+
+type syncInProgressErr error
+
+var errSyncInProgress = func(first, second string) *probe.Error {
+	msg := "Another sync of `" + first + "` and `" + second + "` is in progress."
+	return probe.NewError(syncInProgressErr(errors.New(msg))).Untrace()
+}
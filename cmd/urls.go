@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/minio/mc/pkg/probe"
 )
 
@@ -30,6 +32,16 @@ type URLs struct {
 	TotalSize     int64
 	encKeyDB      map[string][]prefixSSEPair
 	Error         *probe.Error `json:"-"`
+
+	// SSE-C key of the target, given per object by a copy list
+	TargetEncryptKey string `json:",omitempty"`
+
+	// index of the session entry the urls were read from
+	sessionEntry int64
+
+	// time taken by the action on the urls, set by the worker
+	// executing it
+	duration time.Duration
 }
 
 // WithError sets the error and returns object
@@ -206,11 +206,18 @@ type prefixSSEPair struct {
 }
 
 // parse and validate encryption keys entered on command line
-func parseAndValidateEncryptionKeys(sseKeys string, sse string) (encMap map[string][]prefixSSEPair, err *probe.Error) {
+func parseAndValidateEncryptionKeys(sseKeys, sseKMS, sse string) (encMap map[string][]prefixSSEPair, err *probe.Error) {
 	encMap, err = parseEncryptionKeys(sseKeys)
 	if err != nil {
 		return nil, err
 	}
+	kmsMap, err := parseKMSKeys(sseKMS)
+	if err != nil {
+		return nil, err
+	}
+	for alias, ps := range kmsMap {
+		encMap[alias] = append(encMap[alias], ps...)
+	}
 	if sse != "" {
 		for _, prefix := range strings.Split(sse, ",") {
 			alias, _ := url2Alias(prefix)
@@ -226,6 +233,15 @@ func parseAndValidateEncryptionKeys(sseKeys string, sse string) (encMap map[stri
 				return nil, probe.NewError(errors.New("SSE prefix " + p.Prefix + " has invalid alias"))
 			}
 		}
+		prefixes := make(map[string]bool, len(ps))
+		for _, p := range ps {
+			if prefixes[p.Prefix] {
+				return nil, probe.NewError(errors.New("SSE prefix " + p.Prefix + " has more than one encryption key"))
+			}
+			prefixes[p.Prefix] = true
+		}
+		// Longest prefix should match first, whatever the kind of key.
+		sort.Stable(byPrefixLength(ps))
 	}
 	return encMap, nil
 }
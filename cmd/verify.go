@@ -0,0 +1,116 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// uploadDigest - checksums of the data uploaded to a target, hooked
+// into the upload like a progress bar.
+type uploadDigest struct {
+	md5  hash.Hash
+	etag *etagHash
+}
+
+// newUploadDigest - digest of an upload of size bytes, the multipart
+// ETag is calculated with the part size mc uploads with.
+func newUploadDigest(size int64) *uploadDigest {
+	return &uploadDigest{md5: md5.New(), etag: newETagHash(mcPartSize(size))}
+}
+
+// Read - all data read from the source is reported here by hookreader.
+func (d *uploadDigest) Read(p []byte) (int, error) {
+	d.md5.Write(p)
+	d.etag.Write(p)
+	return len(p), nil
+}
+
+// verifyTarget - verify that the target matches the uploaded data. The
+// ETag of the target is trusted when it is a checksum of its data, the
+// target is read back otherwise.
+func verifyTarget(clnt Client, digest *uploadDigest, sse encrypt.ServerSide) *probe.Error {
+	urlStr := clnt.GetURL().String()
+	sum := hex.EncodeToString(digest.md5.Sum(nil))
+	if clnt.GetURL().Type == objectStorage {
+		content, err := clnt.Stat(false, true, sse)
+		if err != nil {
+			return err.Trace(urlStr)
+		}
+		etagSum, parts := parseETag(content.ETag)
+		if etagSum != "" && !isEncrypted(content) {
+			etag := strings.ToLower(strings.Trim(content.ETag, "\""))
+			if parts == 0 && etagSum == sum || parts > 0 && etag == digest.etag.ETag() {
+				return nil
+			}
+			// A multipart ETag of a different part size needs the data.
+			if parts == 0 {
+				return probe.NewError(ObjectCorrupted{Object: urlStr})
+			}
+		}
+	}
+	reader, err := clnt.Get(sse)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	defer reader.Close()
+	targetSum, e := multipartETag(reader, 0)
+	if e != nil {
+		return probe.NewError(e).Trace(urlStr)
+	}
+	if targetSum != sum {
+		return probe.NewError(ObjectCorrupted{Object: urlStr})
+	}
+	return nil
+}
+
+// isErrCorrupted - tells whether an upload failed verification.
+func isErrCorrupted(err *probe.Error) bool {
+	_, ok := err.ToGoError().(ObjectCorrupted)
+	return ok
+}
+
+// verifySummaryMessage - objects which failed verification after all
+// attempts, printed at the end of a copy or mirror.
+type verifySummaryMessage struct {
+	Status string   `json:"status"`
+	Failed []string `json:"failed"`
+}
+
+// String colorized verify summary message.
+func (v verifySummaryMessage) String() string {
+	msg := fmt.Sprintf("%d object(s) failed verification after all attempts:", len(v.Failed))
+	for _, object := range v.Failed {
+		msg += "\n  " + object
+	}
+	return msg
+}
+
+// JSON jsonified verify summary message.
+func (v verifySummaryMessage) JSON() string {
+	v.Status = "error"
+	jsonMessageBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
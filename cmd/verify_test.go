@@ -0,0 +1,58 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/mc/pkg/hookreader"
+)
+
+func TestVerifyTarget(t *testing.T) {
+	root, e := ioutil.TempDir(os.TempDir(), "mc-verify-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(root)
+
+	data := []byte("archive contents")
+	target := filepath.Join(root, "object")
+	if e = ioutil.WriteFile(target, data, 0644); e != nil {
+		t.Fatal(e)
+	}
+
+	clnt, err := fsNew(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := newUploadDigest(int64(len(data)))
+	hookreader.NewHook(bytes.NewReader(data), digest).Read(make([]byte, len(data)))
+	if err = verifyTarget(clnt, digest, nil); err != nil {
+		t.Fatalf("expected target to match, got %v", err)
+	}
+
+	digest = newUploadDigest(int64(len(data)))
+	hookreader.NewHook(bytes.NewReader([]byte("archive c0ntents")), digest).Read(make([]byte, len(data)))
+	if err = verifyTarget(clnt, digest, nil); !isErrCorrupted(err) {
+		t.Fatalf("expected target to be corrupted, got %v", err)
+	}
+}
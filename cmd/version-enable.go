@@ -0,0 +1,109 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	versionEnableFlags = []cli.Flag{}
+)
+
+var versionEnableCmd = cli.Command{
+	Name:   "enable",
+	Usage:  "enable bucket versioning",
+	Action: mainVersionEnable,
+	Before: setGlobalsFromContext,
+	Flags:  append(versionEnableFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Enable versioning on bucket 'mybucket'
+     $ {{.HelpName}} s3/mybucket
+
+`,
+}
+
+// checkVersionSetSyntax - validate all the passed arguments
+func checkVersionSetSyntax(ctx *cli.Context, cmdName string) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, cmdName, 1) // last argument is exit code
+	}
+}
+
+// versionSetMessage container
+type versionSetMessage struct {
+	Status     string `json:"status"`
+	URL        string `json:"url"`
+	Versioning string `json:"versioning"`
+}
+
+// JSON jsonified version set message.
+func (v versionSetMessage) JSON() string {
+	v.Status = "success"
+	versionSetMessageJSONBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(versionSetMessageJSONBytes)
+}
+
+func (v versionSetMessage) String() string {
+	if v.Versioning == versioningEnabled {
+		return console.Colorize("Versioning", "Successfully enabled versioning on `"+v.URL+"`.")
+	}
+	return console.Colorize("Versioning", "Successfully suspended versioning on `"+v.URL+"`.")
+}
+
+// setBucketVersioning - set versioning status of the bucket at urlStr.
+func setBucketVersioning(urlStr, status string) {
+	client, err := newClient(urlStr)
+	if err != nil {
+		fatalIf(err.Trace(), "Cannot parse the provided url.")
+	}
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
+	}
+
+	err = s3Client.SetVersioning(status)
+	fatalIf(err.Trace(urlStr), "Cannot set versioning on the specified bucket.")
+	printMsg(versionSetMessage{
+		URL:        urlStr,
+		Versioning: status,
+	})
+}
+
+func mainVersionEnable(ctx *cli.Context) error {
+	console.SetColor("Versioning", color.New(color.FgGreen, color.Bold))
+
+	checkVersionSetSyntax(ctx, "enable")
+
+	setBucketVersioning(ctx.Args().First(), versioningEnabled)
+	return nil
+}
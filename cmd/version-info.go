@@ -0,0 +1,107 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	versionInfoFlags = []cli.Flag{}
+)
+
+var versionInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show bucket versioning status",
+	Action: mainVersionInfo,
+	Before: setGlobalsFromContext,
+	Flags:  append(versionInfoFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Show versioning status of bucket 'mybucket'
+     $ {{.HelpName}} s3/mybucket
+
+`,
+}
+
+// checkVersionInfoSyntax - validate all the passed arguments
+func checkVersionInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+// versionInfoMessage container
+type versionInfoMessage struct {
+	Status     string `json:"status"`
+	URL        string `json:"url"`
+	Versioning string `json:"versioning"`
+}
+
+// JSON jsonified version info message.
+func (v versionInfoMessage) JSON() string {
+	v.Status = "success"
+	versionInfoMessageJSONBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(versionInfoMessageJSONBytes)
+}
+
+func (v versionInfoMessage) String() string {
+	versioning := v.Versioning
+	if versioning == "" {
+		versioning = "Unversioned"
+	}
+	return console.Colorize("URL", v.URL+": ") + console.Colorize("Versioning", versioning)
+}
+
+func mainVersionInfo(ctx *cli.Context) error {
+	console.SetColor("URL", color.New(color.Bold))
+	console.SetColor("Versioning", color.New(color.FgGreen, color.Bold))
+
+	checkVersionInfoSyntax(ctx)
+
+	urlStr := ctx.Args().First()
+	client, err := newClient(urlStr)
+	if err != nil {
+		fatalIf(err.Trace(), "Cannot parse the provided url.")
+	}
+
+	s3Client, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
+	}
+
+	status, err := s3Client.GetVersioning()
+	fatalIf(err.Trace(urlStr), "Cannot get versioning status of the specified bucket.")
+	printMsg(versionInfoMessage{
+		URL:        urlStr,
+		Versioning: status,
+	})
+	return nil
+}
@@ -29,7 +29,7 @@ import (
 // Print version.
 var versionCmd = cli.Command{
 	Name:   "version",
-	Usage:  "show version info",
+	Usage:  "show version info, manage bucket versioning",
 	Action: mainVersion,
 	Before: setGlobalsFromContext,
 	Flags: []cli.Flag{
@@ -42,18 +42,31 @@ var versionCmd = cli.Command{
 			Usage: "enable JSON formatted output",
 		},
 	},
+	Subcommands: []cli.Command{
+		versionEnableCmd,
+		versionSuspendCmd,
+		versionInfoCmd,
+	},
+	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}}{{if .VisibleFlags}} [FLAGS]{{end}}
-{{if .VisibleFlags}}
+  {{.HelpName}} COMMAND [COMMAND FLAGS | -h] [ARGUMENTS...]
+
+COMMANDS:
+  {{range .VisibleCommands}}{{join .Names ", "}}{{ "\t" }}{{.Usage}}
+  {{end}}{{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}{{end}}
 EXAMPLES:
    1. Prints the MinIO Client version:
        $ {{.HelpName}}
+
+   2. Enable versioning on bucket 'mybucket':
+       $ {{.HelpName}} enable s3/mybucket
 `,
 }
 
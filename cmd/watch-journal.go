@@ -0,0 +1,254 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// maxWatchJournalSize - size above which the journal is rewritten
+// to hold the pending events only.
+const maxWatchJournalSize = 4 << 20
+
+// watchJournalRecord - a line of the journal, either a received
+// event or the completion of the event with the same sequence number.
+type watchJournalRecord struct {
+	Seq   int64      `json:"seq"`
+	Done  bool       `json:"done,omitempty"`
+	Event *EventInfo `json:"event,omitempty"`
+}
+
+// watchJournalEvent - an event of the journal which is yet to be
+// mirrored to all targets.
+type watchJournalEvent struct {
+	Seq   int64
+	Event EventInfo
+}
+
+// watchJournal - events received by a watching mirror, kept on disk
+// until they are mirrored to all targets so that the events pending
+// when mc stops are mirrored once it is started again.
+type watchJournal struct {
+	mutex   sync.Mutex
+	path    string
+	file    *os.File
+	size    int64
+	seq     int64
+	pending map[int64]EventInfo
+}
+
+// getWatchJournalFile - journal file of a watching mirror, identified
+// by the current folder, its source and its targets.
+func getWatchJournalFile(sourceURL string, targetURLs []string) (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	rootPath, e := os.Getwd()
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	key := append([]string{rootPath, sourceURL}, targetURLs...)
+	sum := sha256.Sum256([]byte(strings.Join(key, "\n")))
+	return filepath.Join(configDir, globalWatchDir, hex.EncodeToString(sum[:16])+".journal"), nil
+}
+
+// openWatchJournal - open the journal of a watching mirror, the
+// events which were pending when it was last stopped are returned
+// in the order they were received.
+func openWatchJournal(sourceURL string, targetURLs []string) (*watchJournal, []watchJournalEvent, *probe.Error) {
+	journalFile, err := getWatchJournalFile(sourceURL, targetURLs)
+	if err != nil {
+		return nil, nil, err.Trace(sourceURL)
+	}
+	if e := os.MkdirAll(filepath.Dir(journalFile), 0700); e != nil {
+		return nil, nil, probe.NewError(e)
+	}
+	j := &watchJournal{
+		path:    journalFile,
+		pending: make(map[int64]EventInfo),
+	}
+	if err = j.load(); err != nil {
+		return nil, nil, err.Trace(journalFile)
+	}
+	if err = j.compact(); err != nil {
+		return nil, nil, err.Trace(journalFile)
+	}
+
+	events := make([]watchJournalEvent, 0, len(j.pending))
+	for seq, event := range j.pending {
+		events = append(events, watchJournalEvent{Seq: seq, Event: event})
+	}
+	sort.Slice(events, func(i, k int) bool {
+		return events[i].Seq < events[k].Seq
+	})
+	return j, events, nil
+}
+
+// load - read the pending events of the journal file, a record cut
+// short by a crash ends the journal.
+func (j *watchJournal) load() *probe.Error {
+	file, e := os.Open(j.path)
+	if os.IsNotExist(e) {
+		return nil
+	}
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var record watchJournalRecord
+		if e = json.Unmarshal(scanner.Bytes(), &record); e != nil {
+			break
+		}
+		if record.Seq > j.seq {
+			j.seq = record.Seq
+		}
+		if record.Done {
+			delete(j.pending, record.Seq)
+		} else if record.Event != nil {
+			j.pending[record.Seq] = *record.Event
+		}
+	}
+	if e = scanner.Err(); e != nil && e != bufio.ErrTooLong {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// compact - rewrite the journal file with the pending events only,
+// and keep it open to append new records.
+func (j *watchJournal) compact() *probe.Error {
+	tmpPath := j.path + ".tmp"
+	tmpFile, e := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	w := bufio.NewWriter(tmpFile)
+	var size int64
+	seqs := make([]int64, 0, len(j.pending))
+	for seq := range j.pending {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, k int) bool { return seqs[i] < seqs[k] })
+	for _, seq := range seqs {
+		event := j.pending[seq]
+		data, e := json.Marshal(watchJournalRecord{Seq: seq, Event: &event})
+		if e != nil {
+			tmpFile.Close()
+			return probe.NewError(e)
+		}
+		n, _ := w.Write(append(data, '\n'))
+		size += int64(n)
+	}
+	if e = w.Flush(); e == nil {
+		e = tmpFile.Sync()
+	}
+	if e != nil {
+		tmpFile.Close()
+		return probe.NewError(e)
+	}
+	if e = tmpFile.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	if e = os.Rename(tmpPath, j.path); e != nil {
+		return probe.NewError(e)
+	}
+
+	if j.file != nil {
+		j.file.Close()
+	}
+	if j.file, e = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600); e != nil {
+		return probe.NewError(e)
+	}
+	j.size = size
+	return nil
+}
+
+// write - append a record to the journal file, received events are
+// synced to disk before they are mirrored.
+func (j *watchJournal) write(record watchJournalRecord) *probe.Error {
+	data, e := json.Marshal(record)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	n, e := j.file.Write(append(data, '\n'))
+	j.size += int64(n)
+	if e != nil {
+		return probe.NewError(e).Trace(j.path)
+	}
+	if record.Event != nil {
+		if e = j.file.Sync(); e != nil {
+			return probe.NewError(e).Trace(j.path)
+		}
+	}
+	return nil
+}
+
+// add - journal a received event, returns its sequence number.
+func (j *watchJournal) add(event EventInfo) (int64, *probe.Error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.seq++
+	j.pending[j.seq] = event
+	return j.seq, j.write(watchJournalRecord{Seq: j.seq, Event: &event})
+}
+
+// done - journal that the events were mirrored to all targets.
+func (j *watchJournal) done(seqs ...int64) *probe.Error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	for _, seq := range seqs {
+		if _, ok := j.pending[seq]; !ok {
+			continue
+		}
+		delete(j.pending, seq)
+		if err := j.write(watchJournalRecord{Seq: seq, Done: true}); err != nil {
+			return err
+		}
+	}
+	if j.size > maxWatchJournalSize {
+		return j.compact()
+	}
+	return nil
+}
+
+// close - close the journal file, pending events are kept.
+func (j *watchJournal) close() *probe.Error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if e := j.file.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestWatchJournal(t *testing.T) {
+	configDir, e := ioutil.TempDir("", "mc-journal-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(configDir)
+	defer func(dir string) { mcCustomConfigDir = dir }(mcCustomConfigDir)
+	mcCustomConfigDir = configDir
+
+	journal, events, err := openWatchJournal("src", []string{"dst"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Expected no pending events, got %v", events)
+	}
+	for _, path := range []string{"a", "b", "c"} {
+		if _, err = journal.add(EventInfo{Path: path, Type: EventCreate}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = journal.done(1, 3); err != nil {
+		t.Fatal(err)
+	}
+	journal.close()
+
+	// A record cut short by a crash is dropped.
+	journalFile, err := getWatchJournalFile("src", []string{"dst"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, e := os.OpenFile(journalFile, os.O_WRONLY|os.O_APPEND, 0600)
+	if e != nil {
+		t.Fatal(e)
+	}
+	f.WriteString(`{"seq":4,"event":{"Pa`)
+	f.Close()
+
+	journal, events, err = openWatchJournal("src", []string{"dst"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.close()
+	if len(events) != 1 || events[0].Seq != 2 || events[0].Event.Path != "b" {
+		t.Fatalf("Expected the pending event b, got %v", events)
+	}
+	if seq, err := journal.add(EventInfo{Path: "d", Type: EventRemove}); err != nil || seq != 4 {
+		t.Fatalf("Expected sequence number 4, got %d (%v)", seq, err)
+	}
+
+	// Every mirror has a journal of its own.
+	_, events, err = openWatchJournal("src", []string{"other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Expected no pending events, got %v", events)
+	}
+}
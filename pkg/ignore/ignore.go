@@ -0,0 +1,189 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ignore matches slash separated paths against patterns of
+// the gitignore format. Blank lines and lines starting with '#' are
+// skipped, a leading '!' negates a pattern, a trailing '/' matches
+// folders only, a pattern containing a '/' elsewhere is anchored to
+// the folder of the patterns, and '**' matches any number of folders.
+package ignore
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// pattern - a compiled line of patterns.
+type pattern struct {
+	re      *regexp.Regexp
+	negated bool
+	dirOnly bool
+}
+
+// Matcher - an ordered list of patterns, the last pattern matching
+// a path decides whether it is ignored.
+type Matcher struct {
+	patterns []pattern
+}
+
+// New returns a matcher of the given lines of patterns.
+func New(lines ...string) *Matcher {
+	m := &Matcher{}
+	for _, line := range lines {
+		m.Add(line)
+	}
+	return m
+}
+
+// Read returns a matcher of the lines of patterns read from r.
+func Read(r io.Reader) (*Matcher, error) {
+	m := &Matcher{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m.Add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Len returns the number of patterns of the matcher.
+func (m *Matcher) Len() int {
+	return len(m.patterns)
+}
+
+// Add appends a line of patterns, lines which hold no pattern are
+// skipped.
+func (m *Matcher) Add(line string) {
+	line = strings.TrimSuffix(line, "\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	// Trailing spaces are ignored unless escaped.
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimSuffix(line, " ")
+	}
+
+	var p pattern
+	if strings.HasPrefix(line, "!") {
+		p.negated = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return
+	}
+
+	// A pattern without a separator matches at any depth.
+	expr := "^"
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		expr += "(?:.*/)?"
+	}
+	re, err := regexp.Compile(expr + translate(line) + "$")
+	if err != nil {
+		return
+	}
+	p.re = re
+	m.patterns = append(m.patterns, p)
+}
+
+// translate - translate a pattern without leading or trailing
+// separators into a regular expression.
+func translate(line string) string {
+	var expr strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case strings.HasPrefix(line[i:], "**/") && (i == 0 || line[i-1] == '/'):
+			// Zero or more folders.
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case line[i:] == "**" && i > 0 && line[i-1] == '/':
+			// Everything inside the folder.
+			expr.WriteString(".+")
+			i++
+		case c == '*':
+			for i+1 < len(line) && line[i+1] == '*' {
+				i++
+			}
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(line[i+1:], ']')
+			if end < 0 {
+				expr.WriteString("\\[")
+				continue
+			}
+			class := line[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.Replace(class, "\\", "\\\\", -1) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(line):
+			i++
+			expr.WriteString(regexp.QuoteMeta(line[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(line[i : i+1]))
+		}
+	}
+	return expr.String()
+}
+
+// Match tells whether a pattern matches the path, relative to the
+// folder of the patterns, and if so whether the path is ignored.
+// Folders which are ignored ignore all paths inside them, which is
+// not checked here.
+func (m *Matcher) Match(path string, isDir bool) (matched, ignored bool) {
+	path = strings.Trim(path, "/")
+	for i := len(m.patterns) - 1; i >= 0; i-- {
+		p := m.patterns[i]
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.re.MatchString(path) {
+			return true, !p.negated
+		}
+	}
+	return false, false
+}
+
+// Ignored tells whether the path, relative to the folder of the
+// patterns, is ignored, either by itself or by one of its parent
+// folders.
+func (m *Matcher) Ignored(path string, isDir bool) bool {
+	path = strings.Trim(path, "/")
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			if _, ignored := m.Match(path[:i], true); ignored {
+				return true
+			}
+		}
+	}
+	_, ignored := m.Match(path, isDir)
+	return ignored
+}
@@ -0,0 +1,95 @@
+/*
+ * MinIO Client (C) 2016 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ignore
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	testCases := []struct {
+		patterns []string
+		path     string
+		isDir    bool
+		ignored  bool
+	}{
+		// Comments, blank lines and escapes.
+		{[]string{"# comment", "", "*.o"}, "a/b/c.o", false, true},
+		{[]string{"\\#notes"}, "#notes", false, true},
+		{[]string{"\\!important"}, "!important", false, true},
+		{[]string{"*.log   "}, "x.log", false, true},
+		// Unanchored patterns match at any depth.
+		{[]string{"build"}, "build", true, true},
+		{[]string{"build"}, "src/build", true, true},
+		{[]string{"build"}, "src/build.go", false, false},
+		// Anchored patterns.
+		{[]string{"/build"}, "build", true, true},
+		{[]string{"/build"}, "src/build", true, false},
+		{[]string{"doc/*.txt"}, "doc/a.txt", false, true},
+		{[]string{"doc/*.txt"}, "doc/sub/a.txt", false, false},
+		{[]string{"doc/*.txt"}, "x/doc/a.txt", false, false},
+		// Folder only patterns.
+		{[]string{"out/"}, "out", true, true},
+		{[]string{"out/"}, "out", false, false},
+		{[]string{"out/"}, "out/a.bin", false, true},
+		// Negation, the last matching pattern wins.
+		{[]string{"*.log", "!keep.log"}, "keep.log", false, false},
+		{[]string{"*.log", "!keep.log"}, "drop.log", false, true},
+		{[]string{"!keep.log", "*.log"}, "keep.log", false, true},
+		// A file of an ignored folder cannot be re-included.
+		{[]string{"out/", "!out/keep"}, "out/keep", false, true},
+		{[]string{"out/*", "!out/keep"}, "out/keep", false, false},
+		// Double asterisks.
+		{[]string{"**/cache"}, "cache", true, true},
+		{[]string{"**/cache"}, "a/b/cache", true, true},
+		{[]string{"a/**/b"}, "a/b", false, true},
+		{[]string{"a/**/b"}, "a/x/y/b", false, true},
+		{[]string{"a/**/b"}, "c/a/x/b", false, false},
+		{[]string{"logs/**"}, "logs/2019/01.log", false, true},
+		{[]string{"logs/**"}, "logs", true, false},
+		// Wildcards do not match separators.
+		{[]string{"a?c"}, "abc", false, true},
+		{[]string{"a?c"}, "a/c", false, false},
+		{[]string{"a*c"}, "a/c", false, false},
+		{[]string{"[a-c].txt"}, "b.txt", false, true},
+		{[]string{"[!a-c].txt"}, "b.txt", false, false},
+		{[]string{"[!a-c].txt"}, "d.txt", false, true},
+	}
+	for i, testCase := range testCases {
+		m := New(testCase.patterns...)
+		if ignored := m.Ignored(testCase.path, testCase.isDir); ignored != testCase.ignored {
+			t.Errorf("Test %d: Expected %q with %q to be ignored %t, got %t", i+1, testCase.path, testCase.patterns, testCase.ignored, ignored)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	m, err := Read(strings.NewReader("# artifacts\r\n*.o\r\n\r\n!main.o\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 2 {
+		t.Fatalf("Expected 2 patterns, got %d", m.Len())
+	}
+	if matched, ignored := m.Match("main.o", false); !matched || ignored {
+		t.Fatalf("Expected main.o to be re-included, got matched %t ignored %t", matched, ignored)
+	}
+	if matched, _ := m.Match("main.c", false); matched {
+		t.Fatal("Expected main.c to match no pattern")
+	}
+}